@@ -3,6 +3,7 @@ module github.com/wangwei518/gin-admin
 go 1.14
 
 require (
+	github.com/LyricTian/captcha v1.1.0
 	github.com/LyricTian/queue v1.2.0
 	github.com/LyricTian/structs v1.1.1
 	github.com/alecthomas/template v0.0.0-20190718012654-fb15b899a751
@@ -29,6 +30,7 @@ require (
 	github.com/tidwall/buntdb v1.1.2
 	github.com/urfave/cli/v2 v2.2.0
 	go.mongodb.org/mongo-driver v1.3.1
+	golang.org/x/crypto v0.0.0-20191205180655-e7c4368fe9dd
 	golang.org/x/time v0.0.0-20191024005414-555d28b269f0
 	golang.org/x/tools v0.0.0-20191030062658-86caa796c7ab // indirect
 	gopkg.in/yaml.v2 v2.2.8
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	"github.com/wangwei518/gin-admin/internal/app/ginplus"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+)
+
+// APITokenSet 注入APIToken
+var APITokenSet = wire.NewSet(wire.Struct(new(APIToken), "*"))
+
+// APIToken 访问令牌管理
+type APIToken struct {
+	APITokenBll bll.IAPIToken
+}
+
+// Query 查询用户的访问令牌
+func (a *APIToken) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	tokens, err := a.APITokenBll.QueryUser(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, tokens)
+}
+
+// Create 为用户(或服务账号)创建访问令牌
+func (a *APIToken) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.APITokenCreateParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	item.Creator = ginplus.GetUserID(c)
+	env := abac.NewRequestEnv(c.Request, c.ClientIP())
+	info, err := a.APITokenBll.CreateUser(ctx, c.Param("id"), item, env)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, info)
+}
+
+// Delete 删除用户的访问令牌
+func (a *APIToken) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.APITokenBll.DeleteUser(ctx, c.Param("id"), c.Param("tid"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// QueryCurrent 查询当前用户的访问令牌
+func (a *APIToken) QueryCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	tokens, err := a.APITokenBll.Query(ctx, ginplus.GetUserID(c))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, tokens)
+}
+
+// CreateCurrent 为当前用户创建访问令牌
+func (a *APIToken) CreateCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.APITokenCreateParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	item.Creator = ginplus.GetUserID(c)
+	info, err := a.APITokenBll.Create(ctx, item.Creator, item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, info)
+}
+
+// DeleteCurrent 删除当前用户的访问令牌
+func (a *APIToken) DeleteCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.APITokenBll.Delete(ctx, ginplus.GetUserID(c), c.Param("tid"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/wangwei518/gin-admin/internal/app/ginplus"
+	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// JWKSSet 注入JWKS
+var JWKSSet = wire.NewSet(wire.Struct(new(JWKS), "*"))
+
+// JWKS 令牌校验公钥
+type JWKS struct {
+	KeySet *jwtauth.KeySet
+}
+
+// Get 查询令牌校验公钥
+func (a *JWKS) Get(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	ginplus.ResJSON(c, http.StatusOK, a.KeySet.JWKS())
+}
@@ -1,12 +1,16 @@
 package api
 
 import (
-	// "github.com/LyricTian/captcha"
+	"context"
+	"net/http"
+
+	"github.com/LyricTian/captcha"
 	"github.com/wangwei518/gin-admin/internal/app/bll"
-	// "github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/config"
 	"github.com/wangwei518/gin-admin/internal/app/ginplus"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
-	// "github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/auth"
+	"github.com/wangwei518/gin-admin/pkg/errors"
 	"github.com/wangwei518/gin-admin/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
@@ -21,7 +25,6 @@ type Login struct {
 }
 
 // GetCaptcha 获取验证码信息
-/*
 func (a *Login) GetCaptcha(c *gin.Context) {
 	ctx := c.Request.Context()
 	item, err := a.LoginBll.GetCaptcha(ctx, config.C.Captcha.Length)
@@ -31,9 +34,8 @@ func (a *Login) GetCaptcha(c *gin.Context) {
 	}
 	ginplus.ResSuccess(c, item)
 }
-*/
+
 // ResCaptcha 响应图形验证码
-/*
 func (a *Login) ResCaptcha(c *gin.Context) {
 	ctx := c.Request.Context()
 	captchaID := c.Query("id")
@@ -55,10 +57,10 @@ func (a *Login) ResCaptcha(c *gin.Context) {
 		ginplus.ResError(c, err)
 	}
 }
-*/
+
 // Login 用户登录
 func (a *Login) Login(c *gin.Context) {
-	ctx := c.Request.Context()
+	ctx := newClientContext(c)
 
 	// 数据schema来自 /schema/s_login.go
 	var item schema.LoginParam
@@ -69,17 +71,121 @@ func (a *Login) Login(c *gin.Context) {
 		return
 	}
 
-	// 不做验证码校验
-	//if !captcha.VerifyString(item.CaptchaID, item.CaptchaCode) {
-	//	ginplus.ResError(c, errors.New400Response("无效的验证码"))
-	//	return
-	//}
+	view, err := a.LoginBll.CheckView(item.View)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	// 校验验证码(未启用或同一IP登录失败次数未达到阈值时不校验)
+	if err := a.LoginBll.VerifyCaptcha(ctx, item.CaptchaID, item.CaptchaCode); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	user, challenge, err := a.LoginBll.Verify(ctx, item.UserName, item.Password)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	} else if challenge != nil {
+		// 需要提交动态验证码完成登录
+		ginplus.ResSuccess(c, challenge)
+		return
+	}
+
+	a.generateToken(c, user, view, nil)
+}
+
+// LoginMFA 提交动态验证码(或恢复码)完成登录
+func (a *Login) LoginMFA(c *gin.Context) {
+	ctx := newClientContext(c)
+	var item schema.LoginMFAParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	view, err := a.LoginBll.CheckView(item.View)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	user, recoveryCodes, err := a.LoginBll.VerifyMFA(ctx, item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	a.generateToken(c, user, view, recoveryCodes)
+}
+
+// LoginMFAEnroll 登录时绑定认证器
+func (a *Login) LoginMFAEnroll(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.LoginMFAEnrollParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	info, err := a.LoginBll.BeginMFAEnroll(ctx, item.MFAToken)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, info)
+}
+
+// LoginOIDC 发起OIDC登录
+func (a *Login) LoginOIDC(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.LoginBll.BeginOIDC(ctx, c.Query("view"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	if c.Query("redirect") != "" {
+		c.Redirect(http.StatusFound, item.AuthURL)
+		return
+	}
+	ginplus.ResSuccess(c, item)
+}
+
+// LoginOIDCCallback OIDC登录回调
+func (a *Login) LoginOIDCCallback(c *gin.Context) {
+	ctx := newClientContext(c)
+	var item schema.LoginOIDCCallbackParam
+	if err := ginplus.ParseQuery(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
 
-	user, err := a.LoginBll.Verify(ctx, item.UserName, item.Password)
+	user, view, challenge, err := a.LoginBll.VerifyOIDC(ctx, item)
 	if err != nil {
 		ginplus.ResError(c, err)
 		return
+	} else if challenge != nil {
+		ginplus.ResSuccess(c, challenge)
+		return
 	}
+
+	a.generateToken(c, user, view, nil)
+}
+
+// 记录会话的客户端信息
+func newClientContext(c *gin.Context) context.Context {
+	return auth.NewClientContext(c.Request.Context(), auth.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+}
+
+// 验证通过后生成指定视图的令牌
+func (a *Login) generateToken(c *gin.Context, user *schema.User, view string, recoveryCodes []string) {
+	ctx := newClientContext(c)
+
         // if verify pass
 	// get RecordID
 	userID := user.RecordID
@@ -89,12 +195,14 @@ func (a *Login) Login(c *gin.Context) {
         // new logger
 	ctx = logger.NewUserIDContext(ctx, userID)
 
+
         //
-	tokenInfo, err := a.LoginBll.GenerateToken(ctx, userID)
+	tokenInfo, err := a.LoginBll.GenerateToken(ctx, userID, view)
 	if err != nil {
 		ginplus.ResError(c, err)
 		return
 	}
+	tokenInfo.RecoveryCodes = recoveryCodes
 
 	logger.StartSpan(ctx, logger.SetSpanTitle("用户登录"), logger.SetSpanFuncName("Login")).Infof("登入系统")
 	ginplus.ResSuccess(c, tokenInfo)
@@ -115,10 +223,55 @@ func (a *Login) Logout(c *gin.Context) {
 	ginplus.ResOK(c)
 }
 
+// EndImpersonation 结束模拟登录
+func (a *Login) EndImpersonation(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.LoginBll.EndImpersonation(ctx, ginplus.GetToken(c))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// QueryTenants 查询当前用户可以切换的租户
+func (a *Login) QueryTenants(c *gin.Context) {
+	ctx := c.Request.Context()
+	tenants, err := a.LoginBll.QueryTenants(ctx, ginplus.GetUserID(c))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, tenants)
+}
+
+// SwitchTenant 切换租户
+func (a *Login) SwitchTenant(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.TenantSwitchParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	tokenInfo, err := a.LoginBll.SwitchTenant(ctx, ginplus.GetUserID(c), item.TenantID, ginplus.GetToken(c))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, tokenInfo)
+}
+
 // RefreshToken 刷新令牌
 func (a *Login) RefreshToken(c *gin.Context) {
 	ctx := c.Request.Context()
-	tokenInfo, err := a.LoginBll.GenerateToken(ctx, ginplus.GetUserID(c))
+	var item schema.RefreshTokenParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	tokenInfo, err := a.LoginBll.RefreshToken(ctx, item.RefreshToken)
 	if err != nil {
 		ginplus.ResError(c, err)
 		return
@@ -134,6 +287,8 @@ func (a *Login) GetUserInfo(c *gin.Context) {
 		ginplus.ResError(c, err)
 		return
 	}
+	info.TenantID = ginplus.GetTenantID(c)
+	info.ActorID = ginplus.GetActorID(c)
 	ginplus.ResSuccess(c, info)
 }
 
@@ -164,3 +319,121 @@ func (a *Login) UpdatePassword(c *gin.Context) {
 	}
 	ginplus.ResOK(c)
 }
+
+// ForgotPassword 找回密码
+func (a *Login) ForgotPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.ForgotPasswordParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	err := a.LoginBll.ForgotPassword(ctx, item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// ResetPassword 重置密码
+func (a *Login) ResetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.ResetPasswordParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	err := a.LoginBll.ResetPassword(ctx, item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// QuerySessions 查询当前用户的有效会话
+func (a *Login) QuerySessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	sessions, err := a.LoginBll.QuerySessions(ctx, ginplus.GetUserID(c))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, sessions)
+}
+
+// RevokeSession 撤销当前用户的指定会话
+func (a *Login) RevokeSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.LoginBll.RevokeSession(ctx, ginplus.GetUserID(c), c.Param("sid"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// EnrollMFA 绑定认证器
+func (a *Login) EnrollMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	info, err := a.LoginBll.EnrollMFA(ctx, ginplus.GetUserID(c))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, info)
+}
+
+// ActivateMFA 启用多因素认证
+func (a *Login) ActivateMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.MFACodeParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	codes, err := a.LoginBll.ActivateMFA(ctx, ginplus.GetUserID(c), item.Code)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, codes)
+}
+
+// DisableMFA 停用多因素认证
+func (a *Login) DisableMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.MFACodeParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	err := a.LoginBll.DisableMFA(ctx, ginplus.GetUserID(c), item.Code)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+func (a *Login) RegenerateRecoveryCodes(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.MFACodeParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	codes, err := a.LoginBll.RegenerateRecoveryCodes(ctx, ginplus.GetUserID(c), item.Code)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, codes)
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	"github.com/wangwei518/gin-admin/internal/app/ginplus"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// OrgSet 注入Org
+var OrgSet = wire.NewSet(wire.Struct(new(Org), "*"))
+
+// Org 部门管理
+type Org struct {
+	OrgBll bll.IOrg
+}
+
+// Query 查询数据
+func (a *Org) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.OrgQueryParam
+	if err := ginplus.ParseQuery(c, &params); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.OrgBll.Query(ctx, params, schema.OrgQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
+	})
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResPage(c, result.Data, result.PageResult)
+}
+
+// QueryTree 查询部门树
+func (a *Org) QueryTree(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.OrgQueryParam
+	if err := ginplus.ParseQuery(c, &params); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	result, err := a.OrgBll.Query(ctx, params, schema.OrgQueryOptions{
+		OrderFields: schema.NewOrderFields(schema.NewOrderField("sequence", schema.OrderByDESC)),
+	})
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, result.Data.ToTree())
+}
+
+// Get 查询指定数据
+func (a *Org) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.OrgBll.Get(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, item)
+}
+
+// Create 创建数据
+func (a *Org) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Org
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	item.Creator = ginplus.GetUserID(c)
+	result, err := a.OrgBll.Create(ctx, item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, result)
+}
+
+// Update 更新数据
+func (a *Org) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Org
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	err := a.OrgBll.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Move 移动部门
+func (a *Org) Move(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.OrgMoveParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	err := a.OrgBll.Move(ctx, c.Param("id"), item.ParentID)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Delete 删除数据
+func (a *Org) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.OrgBll.Delete(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Enable 启用数据
+func (a *Org) Enable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.OrgBll.UpdateStatus(ctx, c.Param("id"), 1)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Disable 禁用数据
+func (a *Org) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.OrgBll.UpdateStatus(ctx, c.Param("id"), 2)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
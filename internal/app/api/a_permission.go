@@ -0,0 +1,80 @@
+package api
+
+import (
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	"github.com/wangwei518/gin-admin/internal/app/ginplus"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// PermissionSet 注入Permission
+var PermissionSet = wire.NewSet(wire.Struct(new(Permission), "*"))
+
+// Permission 权限诊断
+type Permission struct {
+	PermissionBll bll.IPermission
+}
+
+// Explain 解释用户对指定请求的访问权限
+func (a *Permission) Explain(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.PermissionExplainParam
+	if err := ginplus.ParseQuery(c, &params); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	// 请求属性通过attrs[key]=value的查询参数传递
+	params.Attributes = c.QueryMap("attrs")
+	result, err := a.PermissionBll.Explain(ctx, params)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, result)
+}
+
+// Check 批量检查用户对请求的访问权限
+func (a *Permission) Check(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.PermissionCheckParam
+	if err := ginplus.ParseJSON(c, &params); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	if params.UserID == "" {
+		params.UserID = ginplus.GetUserID(c)
+	}
+	result, err := a.PermissionBll.Check(ctx, params)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, result)
+}
+
+// CheckCurrent 批量检查当前用户对请求的访问权限(用于前端路由守卫)
+func (a *Permission) CheckCurrent(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.PermissionCheckParam
+	if err := ginplus.ParseJSON(c, &params); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	params.UserID = ginplus.GetUserID(c)
+	params.View = ginplus.GetView(c)
+	// 使用当前请求的环境判定访问条件
+	env := abac.NewRequestEnv(c.Request, c.ClientIP())
+	params.IP = env.IP
+	params.Attributes = env.Attrs
+	result, err := a.PermissionBll.Check(ctx, params)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, result)
+}
@@ -101,6 +101,23 @@ func (a *Role) Update(c *gin.Context) {
 	ginplus.ResOK(c)
 }
 
+// DryRunUpdate 模拟更新数据(不保存)
+func (a *Role) DryRunUpdate(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Role
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	result, err := a.RoleBll.DryRunUpdate(ctx, c.Param("id"), item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, result)
+}
+
 // Delete 删除数据
 func (a *Role) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
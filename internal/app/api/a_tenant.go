@@ -0,0 +1,115 @@
+package api
+
+import (
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	"github.com/wangwei518/gin-admin/internal/app/ginplus"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"))
+
+// Tenant 租户管理
+type Tenant struct {
+	TenantBll bll.ITenant
+}
+
+// Query 查询数据
+func (a *Tenant) Query(c *gin.Context) {
+	ctx := c.Request.Context()
+	var params schema.TenantQueryParam
+	if err := ginplus.ParseQuery(c, &params); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	params.Pagination = true
+	result, err := a.TenantBll.Query(ctx, params)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	ginplus.ResPage(c, result.Data, result.PageResult)
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+	item, err := a.TenantBll.Get(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, item)
+}
+
+// Create 创建数据
+func (a *Tenant) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Tenant
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	item.Creator = ginplus.GetUserID(c)
+	result, err := a.TenantBll.Create(ctx, item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, result)
+}
+
+// Update 更新数据
+func (a *Tenant) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.Tenant
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	err := a.TenantBll.Update(ctx, c.Param("id"), item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Delete 删除数据
+func (a *Tenant) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantBll.Delete(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Enable 启用数据
+func (a *Tenant) Enable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantBll.UpdateStatus(ctx, c.Param("id"), 1)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Disable 禁用数据
+func (a *Tenant) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.TenantBll.UpdateStatus(ctx, c.Param("id"), 2)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
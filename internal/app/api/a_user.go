@@ -58,7 +58,7 @@ func (a *User) Create(c *gin.Context) {
 	if err := ginplus.ParseJSON(c, &item); err != nil {
 		ginplus.ResError(c, err)
 		return
-	} else if item.Password == "" {
+	} else if item.Password == "" && item.Type != schema.UserTypeService {
 		ginplus.ResError(c, errors.New400Response("密码不能为空"))
 		return
 	}
@@ -89,6 +89,23 @@ func (a *User) Update(c *gin.Context) {
 	ginplus.ResOK(c)
 }
 
+// DryRunUpdate 模拟更新数据(不保存)
+func (a *User) DryRunUpdate(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.User
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	result, err := a.UserBll.DryRunUpdate(ctx, c.Param("id"), item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, result)
+}
+
 // Delete 删除数据
 func (a *User) Delete(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -121,3 +138,86 @@ func (a *User) Disable(c *gin.Context) {
 	}
 	ginplus.ResOK(c)
 }
+
+// QuerySessions 查询用户的有效会话
+func (a *User) QuerySessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	sessions, err := a.UserBll.QuerySessions(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, sessions)
+}
+
+// RevokeSession 撤销用户的指定会话
+func (a *User) RevokeSession(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.UserBll.RevokeSession(ctx, c.Param("id"), c.Param("sid"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// RevokeSessions 撤销用户的全部会话
+func (a *User) RevokeSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.UserBll.RevokeSessions(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// Impersonate 模拟登录
+func (a *User) Impersonate(c *gin.Context) {
+	ctx := c.Request.Context()
+	var item schema.ImpersonateParam
+	if err := ginplus.ParseJSON(c, &item); err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	tokenInfo, err := a.UserBll.Impersonate(ctx, ginplus.GetUserID(c), c.Param("id"), item)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResSuccess(c, tokenInfo)
+}
+
+// ResetMFA 重置多因素认证
+func (a *User) ResetMFA(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.UserBll.ResetMFA(ctx, c.Param("id"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
+
+// QueryLoginLocks 查询登录锁定列表
+func (a *User) QueryLoginLocks(c *gin.Context) {
+	ctx := c.Request.Context()
+	locks, err := a.UserBll.QueryLoginLocks(ctx)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResList(c, locks)
+}
+
+// UnlockLogin 解除登录锁定
+func (a *User) UnlockLogin(c *gin.Context) {
+	ctx := c.Request.Context()
+	err := a.UserBll.UnlockLogin(ctx, c.Param("type"), c.Param("name"))
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+	ginplus.ResOK(c)
+}
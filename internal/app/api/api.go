@@ -4,9 +4,14 @@ import "github.com/google/wire"
 
 // APISet 注入api
 var APISet = wire.NewSet(
+	APITokenSet,
 	DemoSet,
+	JWKSSet,
 	LoginSet,
 	MenuSet,
+	OrgSet,
+	PermissionSet,
 	RoleSet,
+	TenantSet,
 	UserSet,
 )
@@ -4,9 +4,14 @@ import "github.com/google/wire"
 
 // MockSet 注入mock
 var MockSet = wire.NewSet(
+	APITokenSet,
 	DemoSet,
+	JWKSSet,
 	LoginSet,
 	MenuSet,
+	OrgSet,
+	PermissionSet,
 	RoleSet,
+	TenantSet,
 	UserSet,
 )
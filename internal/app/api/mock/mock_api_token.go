@@ -0,0 +1,97 @@
+package mock
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// APITokenSet 注入APIToken
+var APITokenSet = wire.NewSet(wire.Struct(new(APIToken), "*"))
+
+// APIToken 访问令牌管理
+type APIToken struct {
+}
+
+// Query 查询用户的访问令牌
+// @Tags 访问令牌管理
+// @Summary 查询用户(或服务账号)的访问令牌
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "用户ID"
+// @Description 不能查询root用户的令牌，用户必须在当前用户的数据范围内并且属于当前租户
+// @Success 200 {array} schema.APIToken "查询结果：{list:令牌列表}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:不能管理root用户的访问令牌}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/tokens [get]
+func (a *APIToken) Query(c *gin.Context) {
+}
+
+// Create 为用户创建访问令牌
+// @Tags 访问令牌管理
+// @Summary 为用户(或服务账号)创建访问令牌
+// @Description 令牌明文仅在创建时返回，授权动作必须是用户已授权的菜单动作；不能为root用户创建令牌，为普通用户创建令牌时当前用户必须拥有该用户的模拟登录权限，否则只能为服务账号创建令牌
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "用户ID"
+// @Param body body schema.APITokenCreateParam true "请求参数"
+// @Success 200 {object} schema.APITokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的令牌授权动作}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/tokens [post]
+func (a *APIToken) Create(c *gin.Context) {
+}
+
+// Delete 删除用户的访问令牌
+// @Tags 访问令牌管理
+// @Summary 删除用户(或服务账号)的访问令牌
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "用户ID"
+// @Param tid path string true "令牌ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:不能管理root用户的访问令牌}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/tokens/{tid} [delete]
+func (a *APIToken) Delete(c *gin.Context) {
+}
+
+// QueryCurrent 查询当前用户的访问令牌
+// @Tags 访问令牌管理
+// @Summary 查询当前用户的访问令牌
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Success 200 {array} schema.APIToken "查询结果：{list:令牌列表}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/tokens [get]
+func (a *APIToken) QueryCurrent(c *gin.Context) {
+}
+
+// CreateCurrent 为当前用户创建访问令牌
+// @Tags 访问令牌管理
+// @Summary 为当前用户创建访问令牌
+// @Description 令牌明文仅在创建时返回，授权动作必须是当前用户已授权的菜单动作
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.APITokenCreateParam true "请求参数"
+// @Success 200 {object} schema.APITokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的令牌授权动作}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/tokens [post]
+func (a *APIToken) CreateCurrent(c *gin.Context) {
+}
+
+// DeleteCurrent 删除当前用户的访问令牌
+// @Tags 访问令牌管理
+// @Summary 删除当前用户的访问令牌
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param tid path string true "令牌ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/tokens/{tid} [delete]
+func (a *APIToken) DeleteCurrent(c *gin.Context) {
+}
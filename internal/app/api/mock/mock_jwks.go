@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// JWKSSet 注入JWKS
+var JWKSSet = wire.NewSet(wire.Struct(new(JWKS), "*"))
+
+// JWKS 令牌校验公钥
+type JWKS struct {
+}
+
+// Get 查询令牌校验公钥
+// @Tags 登录管理
+// @Summary 查询令牌校验公钥(JSON Web Key Set)
+// @Success 200 "{keys:[{kty,kid,use,alg,n,e,crv,x,y}]}"
+// @Router /.well-known/jwks.json [get]
+func (a *JWKS) Get(c *gin.Context) {
+}
@@ -36,14 +36,70 @@ func (a *Login) ResCaptcha(c *gin.Context) {
 // Login 用户登录
 // @Tags 登录管理
 // @Summary 用户登录
+// @Description 需要多因素认证时返回schema.LoginMFAChallenge，需继续调用 /api/v1/pub/login/mfa 完成登录
 // @Param body body schema.LoginParam true "请求参数"
 // @Success 200 {object} schema.LoginTokenInfo
 // @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 429 {object} schema.ErrorResult "{error:{code:9997,message:登录失败次数过多，已被临时锁定}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
 // @Router /api/v1/pub/login [post]
 func (a *Login) Login(c *gin.Context) {
 }
 
+// LoginMFA 多因素认证登录
+// @Tags 登录管理
+// @Summary 提交动态验证码(或恢复码)完成登录
+// @Description 挑战令牌只能提交一次，验证失败需要重新登录；每个动态验证码只能使用一次，验证失败计入登录失败次数
+// @Param body body schema.LoginMFAParam true "请求参数"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:9999,message:令牌失效}}"
+// @Failure 429 {object} schema.ErrorResult "{error:{code:9997,message:登录失败次数过多，已被临时锁定}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/mfa [post]
+func (a *Login) LoginMFA(c *gin.Context) {
+}
+
+// LoginMFAEnroll 登录时绑定认证器
+// @Tags 登录管理
+// @Summary 登录时绑定认证器(角色要求多因素认证但用户未启用)
+// @Param body body schema.LoginMFAEnrollParam true "请求参数"
+// @Success 200 {object} schema.MFAEnrollInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:9999,message:令牌失效}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/mfa/enroll [post]
+func (a *Login) LoginMFAEnroll(c *gin.Context) {
+}
+
+// LoginOIDC 发起OIDC登录
+// @Tags 登录管理
+// @Summary 发起OIDC登录(授权码模式+PKCE)
+// @Description 指定redirect参数时直接重定向到身份提供方的授权地址
+// @Param redirect query string false "是否重定向(任意非空值)"
+// @Param view query string false "登录完成后签发令牌的视图(为空时使用默认视图)"
+// @Success 200 {object} schema.LoginOIDCAuthorize
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:未启用OIDC登录}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/oidc [get]
+func (a *Login) LoginOIDC(c *gin.Context) {
+}
+
+// LoginOIDCCallback OIDC登录回调
+// @Tags 登录管理
+// @Summary OIDC授权回调(校验ID令牌并签发令牌)
+// @Description 用户需要进行多因素认证时返回挑战信息
+// @Param code query string false "授权码"
+// @Param state query string true "授权请求的state"
+// @Param error query string false "身份提供方返回的错误码"
+// @Param error_description query string false "身份提供方返回的错误描述"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的OIDC授权请求}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/login/oidc/callback [get]
+func (a *Login) LoginOIDCCallback(c *gin.Context) {
+}
+
 // Logout 用户登出
 // @Tags 登录管理
 // @Summary 用户登出
@@ -52,10 +108,46 @@ func (a *Login) Login(c *gin.Context) {
 func (a *Login) Logout(c *gin.Context) {
 }
 
+// EndImpersonation 结束模拟登录
+// @Tags 登录管理
+// @Summary 结束模拟登录(销毁当前的模拟登录令牌)
+// @Param Authorization header string false "Bearer 模拟登录令牌"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:当前不是模拟登录}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/impersonation [delete]
+func (a *Login) EndImpersonation(c *gin.Context) {
+}
+
+// QueryTenants 查询当前用户可以切换的租户
+// @Tags 登录管理
+// @Summary 查询当前用户可以切换的租户(不包括默认租户)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Success 200 {array} schema.Tenant "查询结果：{list:租户列表}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/tenants [get]
+func (a *Login) QueryTenants(c *gin.Context) {
+}
+
+// SwitchTenant 切换租户
+// @Tags 登录管理
+// @Summary 切换租户(签发指定租户的新令牌，并销毁当前令牌)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.TenantSwitchParam true "请求参数"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:租户不存在或已停用}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/tenant [put]
+func (a *Login) SwitchTenant(c *gin.Context) {
+}
+
 // RefreshToken 刷新令牌
 // @Tags 登录管理
 // @Summary 刷新令牌
-// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.RefreshTokenParam true "请求参数"
 // @Success 200 {object} schema.LoginTokenInfo
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
@@ -97,3 +189,99 @@ func (a *Login) QueryUserMenuTree(c *gin.Context) {
 // @Router /api/v1/pub/current/password [put]
 func (a *Login) UpdatePassword(c *gin.Context) {
 }
+
+// ForgotPassword 找回密码
+// @Tags 登录管理
+// @Summary 找回密码(向用户邮箱发送一次性的重置链接，用户不存在时同样返回成功)
+// @Param body body schema.ForgotPasswordParam true "请求参数"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/password/forgot [post]
+func (a *Login) ForgotPassword(c *gin.Context) {
+}
+
+// ResetPassword 重置密码
+// @Tags 登录管理
+// @Summary 重置密码(重置成功后撤销用户的全部会话)
+// @Param body body schema.ResetPasswordParam true "请求参数"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:重置链接无效或已过期}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/password/reset [post]
+func (a *Login) ResetPassword(c *gin.Context) {
+}
+
+// QuerySessions 查询当前用户的有效会话
+// @Tags 登录管理
+// @Summary 查询当前用户的有效会话
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Success 200 {array} schema.UserSession "查询结果：{list:会话列表}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/sessions [get]
+func (a *Login) QuerySessions(c *gin.Context) {
+}
+
+// RevokeSession 撤销当前用户的指定会话
+// @Tags 登录管理
+// @Summary 撤销当前用户的指定会话
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param sid path string true "会话ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/sessions/{sid} [delete]
+func (a *Login) RevokeSession(c *gin.Context) {
+}
+
+// EnrollMFA 绑定认证器
+// @Tags 登录管理
+// @Summary 绑定认证器(生成待激活的密钥)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Success 200 {object} schema.MFAEnrollInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa [post]
+func (a *Login) EnrollMFA(c *gin.Context) {
+}
+
+// ActivateMFA 启用多因素认证
+// @Tags 登录管理
+// @Summary 启用多因素认证
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.MFACodeParam true "请求参数"
+// @Success 200 {object} schema.MFARecoveryCodes
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa [put]
+func (a *Login) ActivateMFA(c *gin.Context) {
+}
+
+// DisableMFA 停用多因素认证
+// @Tags 登录管理
+// @Summary 停用多因素认证
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.MFACodeParam true "请求参数"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa [delete]
+func (a *Login) DisableMFA(c *gin.Context) {
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+// @Tags 登录管理
+// @Summary 重新生成恢复码(原有恢复码全部作废)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.MFACodeParam true "请求参数"
+// @Success 200 {object} schema.MFARecoveryCodes
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/mfa/recovery-codes [post]
+func (a *Login) RegenerateRecoveryCodes(c *gin.Context) {
+}
@@ -0,0 +1,131 @@
+package mock
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// OrgSet 注入Org
+var OrgSet = wire.NewSet(wire.Struct(new(Org), "*"))
+
+// Org 部门管理
+type Org struct{}
+
+// Query 查询数据
+// @Tags 部门管理
+// @Summary 查询数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值"
+// @Param status query int false "状态(1:启用 2:禁用)"
+// @Param parentID query string false "父级ID"
+// @Success 200 {array} schema.Org "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs [get]
+func (a *Org) Query(c *gin.Context) {
+}
+
+// QueryTree 查询部门树
+// @Tags 部门管理
+// @Summary 查询部门树
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param status query int false "状态(1:启用 2:禁用)"
+// @Param parentID query string false "父级ID"
+// @Success 200 {array} schema.OrgTree "查询结果：{list:列表数据}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs.tree [get]
+func (a *Org) QueryTree(c *gin.Context) {
+}
+
+// Get 查询指定数据
+// @Tags 部门管理
+// @Summary 查询指定数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.Org
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs/{id} [get]
+func (a *Org) Get(c *gin.Context) {
+}
+
+// Create 创建数据
+// @Tags 部门管理
+// @Summary 创建数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.Org true "创建数据"
+// @Success 200 {object} schema.RecordIDResult
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs [post]
+func (a *Org) Create(c *gin.Context) {
+}
+
+// Update 更新数据
+// @Tags 部门管理
+// @Summary 更新数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Param body body schema.Org true "更新数据"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs/{id} [put]
+func (a *Org) Update(c *gin.Context) {
+}
+
+// Move 移动部门
+// @Tags 部门管理
+// @Summary 移动部门
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Param body body schema.OrgMoveParam true "移动参数"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs/{id}/move [put]
+func (a *Org) Move(c *gin.Context) {
+}
+
+// Delete 删除数据
+// @Tags 部门管理
+// @Summary 删除数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs/{id} [delete]
+func (a *Org) Delete(c *gin.Context) {
+}
+
+// Enable 启用数据
+// @Tags 部门管理
+// @Summary 启用数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs/{id}/enable [patch]
+func (a *Org) Enable(c *gin.Context) {
+}
+
+// Disable 禁用数据
+// @Tags 部门管理
+// @Summary 禁用数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/orgs/{id}/disable [patch]
+func (a *Org) Disable(c *gin.Context) {
+}
@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// PermissionSet 注入Permission
+var PermissionSet = wire.NewSet(wire.Struct(new(Permission), "*"))
+
+// Permission 权限诊断
+type Permission struct{}
+
+// Explain 解释用户对指定请求的访问权限
+// @Tags 权限诊断
+// @Summary 解释用户在当前租户下对指定请求的访问权限(结果与权限校验一致，返回授权链，拒绝时返回最接近的候选动作)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param userID query string true "用户ID"
+// @Param method query string true "请求方法"
+// @Param path query string true "请求路径"
+// @Param view query string false "令牌视图(为空时为admin)"
+// @Param ip query string false "客户端IP(用于判定访问条件)"
+// @Param time query string false "请求时间(格式为2006-01-02 15:04:05，为空时为当前时间)"
+// @Param attrs query string false "请求属性(格式为attrs[header.X-Env]=prod)"
+// @Success 200 {object} schema.PermissionExplain
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/permissions/explain [get]
+func (a *Permission) Explain(c *gin.Context) {
+}
+
+// Check 批量检查用户对请求的访问权限
+// @Tags 权限诊断
+// @Summary 批量检查用户在当前租户下对请求的访问权限(结果与权限校验一致，用户ID为空时为当前用户)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.PermissionCheckParam true "检查参数"
+// @Success 200 {array} schema.PermissionCheckItem "查询结果：{list:检查结果}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/permissions/check [post]
+func (a *Permission) Check(c *gin.Context) {
+}
+
+// CheckCurrent 批量检查当前用户对请求的访问权限
+// @Tags 权限诊断
+// @Summary 批量检查当前用户对请求的访问权限(用于前端路由守卫)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.PermissionCheckParam true "检查参数(忽略用户ID、令牌视图、客户端IP及请求属性)"
+// @Success 200 {array} schema.PermissionCheckItem "查询结果：{list:检查结果}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/pub/current/permissions/check [post]
+func (a *Permission) CheckCurrent(c *gin.Context) {
+}
@@ -81,6 +81,21 @@ func (a *Role) Create(c *gin.Context) {
 func (a *Role) Update(c *gin.Context) {
 }
 
+// DryRunUpdate 模拟更新数据
+// @Tags 角色管理
+// @Summary 模拟更新数据(不保存)，返回受影响用户获得及失去的菜单、动作及接口
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Param body body schema.Role true "更新数据"
+// @Success 200 {object} schema.PermissionDiff
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/roles/{id}/dry-run [put]
+func (a *Role) DryRunUpdate(c *gin.Context) {
+}
+
 // Delete 删除数据
 // @Tags 角色管理
 // @Summary 删除数据
@@ -0,0 +1,104 @@
+package mock
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"))
+
+// Tenant 租户管理
+type Tenant struct {
+}
+
+// Query 查询数据
+// @Tags 租户管理
+// @Summary 查询数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param current query int true "分页索引" default(1)
+// @Param pageSize query int true "分页大小" default(10)
+// @Param queryValue query string false "查询值"
+// @Param status query int false "状态(1:启用 2:禁用)"
+// @Success 200 {array} schema.Tenant "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants [get]
+func (a *Tenant) Query(c *gin.Context) {
+}
+
+// Get 查询指定数据
+// @Tags 租户管理
+// @Summary 查询指定数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.Tenant
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id} [get]
+func (a *Tenant) Get(c *gin.Context) {
+}
+
+// Create 创建数据
+// @Tags 租户管理
+// @Summary 创建数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param body body schema.Tenant true "创建数据"
+// @Success 200 {object} schema.RecordIDResult
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants [post]
+func (a *Tenant) Create(c *gin.Context) {
+}
+
+// Update 更新数据
+// @Tags 租户管理
+// @Summary 更新数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Param body body schema.Tenant true "更新数据"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id} [put]
+func (a *Tenant) Update(c *gin.Context) {
+}
+
+// Delete 删除数据
+// @Tags 租户管理
+// @Summary 删除数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id} [delete]
+func (a *Tenant) Delete(c *gin.Context) {
+}
+
+// Enable 启用数据
+// @Tags 租户管理
+// @Summary 启用数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id}/enable [patch]
+func (a *Tenant) Enable(c *gin.Context) {
+}
+
+// Disable 禁用数据
+// @Tags 租户管理
+// @Summary 禁用数据
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/tenants/{id}/disable [patch]
+func (a *Tenant) Disable(c *gin.Context) {
+}
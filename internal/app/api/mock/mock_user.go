@@ -21,6 +21,7 @@ type User struct {
 // @Param queryValue query string false "查询值"
 // @Param roleIDs query string false "角色ID(多个以英文逗号分隔)"
 // @Param status query int false "状态(1:启用 2:停用)"
+// @Param type query int false "类型(1:普通用户 2:服务账号)"
 // @Success 200 {array} schema.UserShow "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}"
 // @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
 // @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
@@ -69,6 +70,21 @@ func (a *User) Create(c *gin.Context) {
 func (a *User) Update(c *gin.Context) {
 }
 
+// DryRunUpdate 模拟更新数据
+// @Tags 用户管理
+// @Summary 模拟更新数据(不保存)，返回用户获得及失去的菜单、动作及接口
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Param body body schema.User true "更新数据"
+// @Success 200 {object} schema.PermissionDiff
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/dry-run [put]
+func (a *User) DryRunUpdate(c *gin.Context) {
+}
+
 // Delete 删除数据
 // @Tags 用户管理
 // @Summary 删除数据
@@ -104,3 +120,97 @@ func (a *User) Enable(c *gin.Context) {
 // @Router /api/v1/users/{id}/disable [patch]
 func (a *User) Disable(c *gin.Context) {
 }
+
+// QuerySessions 查询用户的有效会话
+// @Tags 用户管理
+// @Summary 查询用户的有效会话
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {array} schema.UserSession "查询结果：{list:会话列表}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/sessions [get]
+func (a *User) QuerySessions(c *gin.Context) {
+}
+
+// RevokeSessions 撤销用户的全部会话
+// @Tags 用户管理
+// @Summary 撤销用户的全部会话
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/sessions [delete]
+func (a *User) RevokeSessions(c *gin.Context) {
+}
+
+// RevokeSession 撤销用户的指定会话
+// @Tags 用户管理
+// @Summary 撤销用户的指定会话
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Param sid path string true "会话ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/sessions/{sid} [delete]
+func (a *User) RevokeSession(c *gin.Context) {
+}
+
+// Impersonate 模拟登录
+// @Tags 用户管理
+// @Summary 模拟登录(以指定用户的身份访问，只签发访问令牌，到期后不能刷新)
+// @Description 被模拟的用户必须在当前用户的数据范围内并且属于当前租户，非root用户只能模拟角色不超出自己的用户；模拟登录期间的日志同时记录操作者ID
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Param body body schema.ImpersonateParam true "请求参数"
+// @Success 200 {object} schema.LoginTokenInfo
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的请求参数}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/impersonate [post]
+func (a *User) Impersonate(c *gin.Context) {
+}
+
+// ResetMFA 重置多因素认证
+// @Tags 用户管理
+// @Summary 重置多因素认证(用户丢失认证器时使用)
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param id path string true "记录ID"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 404 {object} schema.ErrorResult "{error:{code:0,message:资源不存在}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users/{id}/mfa [delete]
+func (a *User) ResetMFA(c *gin.Context) {
+}
+
+// QueryLoginLocks 查询登录锁定列表
+// @Tags 用户管理
+// @Summary 查询因登录失败次数过多而被锁定的用户名及IP
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Success 200 {array} schema.LoginLock "查询结果：{list:锁定列表}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users.locks [get]
+func (a *User) QueryLoginLocks(c *gin.Context) {
+}
+
+// UnlockLogin 解除登录锁定
+// @Tags 用户管理
+// @Summary 解除登录锁定
+// @Param Authorization header string false "Bearer 用户令牌"
+// @Param type path string true "锁定类型(user:用户名 ip:客户端IP)"
+// @Param name path string true "用户名或IP"
+// @Success 200 {object} schema.StatusResult "{status:OK}"
+// @Failure 400 {object} schema.ErrorResult "{error:{code:0,message:无效的锁定类型}}"
+// @Failure 401 {object} schema.ErrorResult "{error:{code:0,message:未授权}}"
+// @Failure 500 {object} schema.ErrorResult "{error:{code:0,message:服务器错误}}"
+// @Router /api/v1/users.locks/{type}/{name} [delete]
+func (a *User) UnlockLogin(c *gin.Context) {
+}
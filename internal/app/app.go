@@ -127,17 +127,18 @@ func Init(ctx context.Context, opts ...Option) (func(), error) {
 	// 初始化服务运行监控服务 gops，配置来自 config.C.Monitor
 	initialize.InitMonitor(ctx)
 
-	// 初始化图形验证码服务, 配置来自 config.C.Captcha
-	// 验证码服务来自Redis, 配置来自 config.C.Redis
-	// 【内网应用环境，去除验证码功能】
-	//initialize.InitCaptcha()
-
-	// 初始化依赖注入器
+	// 初始化依赖注入器(包括图形验证码服务，配置来自 config.C.Captcha)
 	injector, injectorCleanFunc, err := initialize.BuildInjector()
 	if err != nil {
 		return nil, err
 	}
 
+	// 监听其他实例的权限策略变更，配置来自 config.C.Casbin
+	err = injector.CasbinPolicy.Watch()
+	if err != nil {
+		return nil, err
+	}
+
 	// 初始化菜单数据
 	err = injector.Menu.Load()
 	if err != nil {
@@ -0,0 +1,26 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+)
+
+// IAPIToken 访问令牌管理业务逻辑接口
+type IAPIToken interface {
+	// 查询用户的访问令牌
+	Query(ctx context.Context, userID string) (schema.APITokens, error)
+	// 为用户创建访问令牌
+	Create(ctx context.Context, userID string, params schema.APITokenCreateParam) (*schema.APITokenInfo, error)
+	// 删除用户的访问令牌
+	Delete(ctx context.Context, userID, recordID string) error
+	// 查询其他用户的访问令牌
+	QueryUser(ctx context.Context, userID string) (schema.APITokens, error)
+	// 为其他用户(或服务账号)创建访问令牌
+	CreateUser(ctx context.Context, userID string, params schema.APITokenCreateParam, env *abac.Env) (*schema.APITokenInfo, error)
+	// 删除其他用户的访问令牌
+	DeleteUser(ctx context.Context, userID, recordID string) error
+	// 校验访问令牌
+	Verify(ctx context.Context, token string) (*schema.APITokenAuth, error)
+}
@@ -2,7 +2,7 @@ package bll
 
 import (
 	"context"
-//	"net/http"
+	"net/http"
 
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 )
@@ -10,19 +10,55 @@ import (
 // ILogin 登录业务逻辑接口
 type ILogin interface {
 	// 获取图形验证码信息
-	//GetCaptcha(ctx context.Context, length int) (*schema.LoginCaptcha, error)
+	GetCaptcha(ctx context.Context, length int) (*schema.LoginCaptcha, error)
 	// 生成并响应图形验证码
-	//ResCaptcha(ctx context.Context, w http.ResponseWriter, captchaID string, width, height int) error
-	// 登录验证
-	Verify(ctx context.Context, userName, password string) (*schema.User, error)
-	// 生成令牌
-	GenerateToken(ctx context.Context, userID string) (*schema.LoginTokenInfo, error)
+	ResCaptcha(ctx context.Context, w http.ResponseWriter, captchaID string, width, height int) error
+	// 校验登录验证码(未启用或未达到登录失败次数时不校验)
+	VerifyCaptcha(ctx context.Context, captchaID, captchaCode string) error
+	// 登录验证(需要多因素认证时返回挑战信息)
+	Verify(ctx context.Context, userName, password string) (*schema.User, *schema.LoginMFAChallenge, error)
+	// 多因素认证登录验证(登录时完成认证器绑定则返回恢复码)
+	VerifyMFA(ctx context.Context, params schema.LoginMFAParam) (*schema.User, []string, error)
+	// 发起OIDC登录(生成授权请求地址，并记录登录完成后签发令牌的视图)
+	BeginOIDC(ctx context.Context, view string) (*schema.LoginOIDCAuthorize, error)
+	// OIDC登录验证(返回发起登录时指定的视图，需要多因素认证时返回挑战信息)
+	VerifyOIDC(ctx context.Context, params schema.LoginOIDCCallbackParam) (*schema.User, string, *schema.LoginMFAChallenge, error)
+	// 登录时生成认证器绑定信息
+	BeginMFAEnroll(ctx context.Context, mfaToken string) (*schema.MFAEnrollInfo, error)
+	// 校验令牌视图(为空时返回默认视图)
+	CheckView(view string) (string, error)
+	// 生成指定视图的令牌
+	GenerateToken(ctx context.Context, userID, view string) (*schema.LoginTokenInfo, error)
+	// 查询用户可以切换的租户
+	QueryTenants(ctx context.Context, userID string) (schema.Tenants, error)
+	// 切换租户(生成指定租户的令牌，并销毁当前令牌)
+	SwitchTenant(ctx context.Context, userID, tenantID, tokenString string) (*schema.LoginTokenInfo, error)
+	// 刷新令牌(刷新令牌只能使用一次)
+	RefreshToken(ctx context.Context, refreshToken string) (*schema.LoginTokenInfo, error)
 	// 销毁令牌
 	DestroyToken(ctx context.Context, tokenString string) error
+	// 结束模拟登录(销毁模拟登录令牌)
+	EndImpersonation(ctx context.Context, tokenString string) error
 	// 获取用户登录信息
 	GetLoginInfo(ctx context.Context, userID string) (*schema.UserLoginInfo, error)
 	// 查询用户的权限菜单树
 	QueryUserMenuTree(ctx context.Context, userID string) (schema.MenuTrees, error)
 	// 更新用户登录密码
 	UpdatePassword(ctx context.Context, userID string, params schema.UpdatePasswordParam) error
+	// 找回密码(向用户邮箱发送一次性的重置链接)
+	ForgotPassword(ctx context.Context, params schema.ForgotPasswordParam) error
+	// 使用重置令牌设置新密码
+	ResetPassword(ctx context.Context, params schema.ResetPasswordParam) error
+	// 生成当前用户的认证器绑定信息
+	EnrollMFA(ctx context.Context, userID string) (*schema.MFAEnrollInfo, error)
+	// 启用当前用户的多因素认证
+	ActivateMFA(ctx context.Context, userID, code string) (*schema.MFARecoveryCodes, error)
+	// 停用当前用户的多因素认证
+	DisableMFA(ctx context.Context, userID, code string) error
+	// 重新生成当前用户的恢复码
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*schema.MFARecoveryCodes, error)
+	// 查询当前用户的有效会话
+	QuerySessions(ctx context.Context, userID string) (schema.UserSessions, error)
+	// 撤销当前用户的指定会话
+	RevokeSession(ctx context.Context, userID, sessionID string) error
 }
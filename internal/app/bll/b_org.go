@@ -0,0 +1,25 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+)
+
+// IOrg 部门管理业务逻辑接口
+type IOrg interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.OrgQueryParam, opts ...schema.OrgQueryOptions) (*schema.OrgQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, recordID string, opts ...schema.OrgQueryOptions) (*schema.Org, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.Org) (*schema.RecordIDResult, error)
+	// 更新数据
+	Update(ctx context.Context, recordID string, item schema.Org) error
+	// 移动到新的父级部门
+	Move(ctx context.Context, recordID, parentID string) error
+	// 删除数据
+	Delete(ctx context.Context, recordID string) error
+	// 更新状态
+	UpdateStatus(ctx context.Context, recordID string, status int) error
+}
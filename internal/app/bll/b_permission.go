@@ -0,0 +1,15 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+)
+
+// IPermission 权限诊断业务逻辑接口
+type IPermission interface {
+	// 解释用户在当前租户下对指定请求的访问权限
+	Explain(ctx context.Context, params schema.PermissionExplainParam) (*schema.PermissionExplain, error)
+	// 批量检查用户在当前租户下对请求的访问权限
+	Check(ctx context.Context, params schema.PermissionCheckParam) (schema.PermissionCheckItems, error)
+}
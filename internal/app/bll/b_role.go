@@ -16,6 +16,8 @@ type IRole interface {
 	Create(ctx context.Context, item schema.Role) (*schema.RecordIDResult, error)
 	// 更新数据
 	Update(ctx context.Context, recordID string, item schema.Role) error
+	// 模拟更新数据(不保存)，返回受影响用户的权限变更
+	DryRunUpdate(ctx context.Context, recordID string, item schema.Role) (*schema.PermissionDiff, error)
 	// 删除数据
 	Delete(ctx context.Context, recordID string) error
 	// 更新状态
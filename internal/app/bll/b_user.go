@@ -18,8 +18,24 @@ type IUser interface {
 	Create(ctx context.Context, item schema.User) (*schema.RecordIDResult, error)
 	// 更新数据
 	Update(ctx context.Context, recordID string, item schema.User) error
+	// 模拟更新数据(不保存)，返回用户的权限变更
+	DryRunUpdate(ctx context.Context, recordID string, item schema.User) (*schema.PermissionDiff, error)
 	// 删除数据
 	Delete(ctx context.Context, recordID string) error
 	// 更新状态
 	UpdateStatus(ctx context.Context, recordID string, status int) error
+	// 查询用户的有效会话
+	QuerySessions(ctx context.Context, recordID string) (schema.UserSessions, error)
+	// 撤销用户的指定会话
+	RevokeSession(ctx context.Context, recordID, sessionID string) error
+	// 撤销用户的全部会话
+	RevokeSessions(ctx context.Context, recordID string) error
+	// 模拟登录(生成以操作者身份模拟指定用户的令牌)
+	Impersonate(ctx context.Context, actorID, recordID string, params schema.ImpersonateParam) (*schema.LoginTokenInfo, error)
+	// 重置用户的多因素认证
+	ResetMFA(ctx context.Context, recordID string) error
+	// 查询登录锁定列表
+	QueryLoginLocks(ctx context.Context) (schema.LoginLocks, error)
+	// 解除登录锁定
+	UnlockLogin(ctx context.Context, lockType, name string) error
 }
@@ -0,0 +1,417 @@
+package bll
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+var _ bll.IAPIToken = (*APIToken)(nil)
+
+// APITokenSet 注入APIToken
+var APITokenSet = wire.NewSet(wire.Struct(new(APIToken), "*"), wire.Bind(new(bll.IAPIToken), new(*APIToken)))
+
+// 定义错误
+var (
+	ErrInvalidTokenAction = errors.New400Response("无效的令牌授权动作")
+)
+
+// 最后使用时间的最小更新间隔(避免每次请求都写入)
+const apiTokenTouchInterval = time.Minute
+
+// APIToken 访问令牌管理
+type APIToken struct {
+	CasbinPolicy            *CasbinPolicy
+	DataScope               *DataScope
+	APITokenModel           model.IAPIToken
+	UserModel               model.IUser
+	UserRoleModel           model.IUserRole
+	RoleModel               model.IRole
+	RoleMenuModel           model.IRoleMenu
+	MenuActionModel         model.IMenuAction
+	MenuActionResourceModel model.IMenuActionResource
+}
+
+// 计算令牌的哈希值(令牌为高熵随机串，使用SHA-256即可)
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// 生成访问令牌
+func newAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return schema.APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// 检查令牌所属用户是否有效
+func (a *APIToken) checkUser(ctx context.Context, userID string) error {
+	if CheckIsRootUser(ctx, userID) {
+		return nil
+	}
+
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return err
+	} else if user == nil {
+		return errors.ErrNotFound
+	} else if user.Status != 1 {
+		return errors.ErrUserDisable
+	}
+	return nil
+}
+
+// 查询用户已授权的菜单动作(root用户返回nil，表示拥有全部动作)
+func (a *APIToken) queryUserActionIDs(ctx context.Context, userID string) (map[string]struct{}, error) {
+	if CheckIsRootUser(ctx, userID) {
+		return nil, nil
+	}
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var roleIDs []string
+	for _, item := range roleResult.Data {
+		if item.Status == 1 {
+			roleIDs = append(roleIDs, item.RecordID)
+		}
+	}
+
+	m := make(map[string]struct{})
+	if len(roleIDs) == 0 {
+		return m, nil
+	}
+
+	// 包含角色继承的上级角色动作
+	enabledRoleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
+		RoleIDs: enabledRoleResult.Data.ExpandInheritIDs(roleIDs),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range roleMenuResult.Data {
+		m[item.ActionID] = struct{}{}
+	}
+	return m, nil
+}
+
+// Query 查询用户的访问令牌
+func (a *APIToken) Query(ctx context.Context, userID string) (schema.APITokens, error) {
+	result, err := a.APITokenModel.Query(ctx, schema.APITokenQueryParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// Create 为用户创建访问令牌(授权动作必须是用户已授权的菜单动作)
+func (a *APIToken) Create(ctx context.Context, userID string, params schema.APITokenCreateParam) (*schema.APITokenInfo, error) {
+	err := a.checkUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var actionIDs []string
+	mActionIDs := make(map[string]struct{})
+	for _, actionID := range params.ActionIDs {
+		if _, ok := mActionIDs[actionID]; !ok {
+			mActionIDs[actionID] = struct{}{}
+			actionIDs = append(actionIDs, actionID)
+		}
+	}
+
+	actionResult, err := a.MenuActionModel.Query(ctx, schema.MenuActionQueryParam{
+		RecordIDs: actionIDs,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(actionResult.Data) != len(actionIDs) {
+		return nil, ErrInvalidTokenAction
+	}
+
+	userActionIDs, err := a.queryUserActionIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if userActionIDs != nil {
+		for _, actionID := range actionIDs {
+			if _, ok := userActionIDs[actionID]; !ok {
+				return nil, ErrInvalidTokenAction
+			}
+		}
+	}
+
+	token, err := newAPIToken()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	item := &schema.APIToken{
+		RecordID:  util.NewRecordID(),
+		UserID:    userID,
+		Name:      params.Name,
+		Prefix:    token[:len(schema.APITokenPrefix)+8],
+		TokenHash: hashAPIToken(token),
+		ActionIDs: actionIDs,
+		Creator:   params.Creator,
+		CreatedAt: time.Now(),
+	}
+	if params.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(params.ExpiresIn) * time.Second)
+		item.ExpiresAt = &expiresAt
+	}
+
+	err = a.APITokenModel.Create(ctx, *item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.APITokenInfo{
+		APIToken: item,
+		Token:    token,
+	}, nil
+}
+
+// Delete 删除用户的访问令牌
+func (a *APIToken) Delete(ctx context.Context, userID, recordID string) error {
+	oldItem, err := a.APITokenModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil || oldItem.UserID != userID {
+		return errors.ErrNotFound
+	}
+
+	return a.APITokenModel.Delete(ctx, recordID)
+}
+
+// 获取当前用户可以管理访问令牌的其他用户(本人的令牌通过当前用户的接口管理)
+// 不能管理root用户的令牌，目标用户必须在当前用户的数据范围内并且属于当前租户
+func (a *APIToken) getTargetUser(ctx context.Context, userID string) (*schema.User, error) {
+	if CheckIsRootUser(ctx, userID) {
+		return nil, errors.New400Response("不能管理root用户的访问令牌")
+	}
+
+	scopeCtx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.UserModel.Get(scopeCtx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrNotFound
+	}
+
+	ok, err := checkTenantMember(ctx, a.UserRoleModel, userID)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.ErrNotFound
+	}
+	return user, nil
+}
+
+// 检查操作者是否可以为指定用户创建访问令牌
+// 服务账号不受限制；为普通用户创建令牌等同于模拟该用户，操作者必须拥有该用户的模拟登录权限
+func (a *APIToken) checkCreateUser(ctx context.Context, actorID string, user *schema.User, env *abac.Env) error {
+	if user.IsService() {
+		return nil
+	} else if !config.C.Impersonation.Enable {
+		return errors.New400Response("只能为服务账号创建访问令牌")
+	} else if _, ok := icontext.FromActorID(ctx); ok {
+		return errors.New400Response("模拟登录期间不能为其他用户创建访问令牌")
+	}
+
+	tenantID, _ := icontext.FromTenantID(ctx)
+	view, _ := icontext.FromView(ctx)
+	ok, err := a.CasbinPolicy.Enforce(actorID, tenantID, fmt.Sprintf("/api/v1/users/%s/impersonate", user.RecordID), http.MethodPost, view, env)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errors.New400Response("只能为服务账号创建访问令牌")
+	}
+	return checkImpersonateRoles(ctx, a.UserRoleModel, actorID, user.RecordID)
+}
+
+// QueryUser 查询其他用户的访问令牌
+func (a *APIToken) QueryUser(ctx context.Context, userID string) (schema.APITokens, error) {
+	_, err := a.getTargetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return a.Query(ctx, userID)
+}
+
+// CreateUser 为其他用户(或服务账号)创建访问令牌
+func (a *APIToken) CreateUser(ctx context.Context, userID string, params schema.APITokenCreateParam, env *abac.Env) (*schema.APITokenInfo, error) {
+	user, err := a.getTargetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.checkCreateUser(ctx, params.Creator, user, env)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := a.Create(ctx, userID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	span := logger.StartSpan(ctx, logger.SetSpanTitle("访问令牌"), logger.SetSpanFuncName("CreateUser")).
+		WithField("impersonated_user_id", userID)
+	if user.IsService() {
+		span.Infof("为服务账号%s[%s]创建访问令牌%s", user.UserName, userID, info.Prefix)
+	} else {
+		span.Warnf("为用户%s[%s]创建访问令牌%s，授权动作%d个", user.UserName, userID, info.Prefix, len(info.ActionIDs))
+	}
+	return info, nil
+}
+
+// DeleteUser 删除其他用户的访问令牌
+func (a *APIToken) DeleteUser(ctx context.Context, userID, recordID string) error {
+	_, err := a.getTargetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return a.Delete(ctx, userID, recordID)
+}
+
+// Verify 校验访问令牌，返回令牌所属用户及可访问的资源
+// 令牌的授权动作与用户当前已授权的动作取交集，用户失去的权限令牌同样失去
+func (a *APIToken) Verify(ctx context.Context, token string) (*schema.APITokenAuth, error) {
+	result, err := a.APITokenModel.Query(ctx, schema.APITokenQueryParam{
+		TokenHash: hashAPIToken(token),
+	})
+	if err != nil {
+		return nil, err
+	} else if len(result.Data) == 0 {
+		return nil, errors.ErrInvalidToken
+	}
+
+	item := result.Data[0]
+	if item.IsExpired() {
+		return nil, errors.ErrInvalidToken
+	}
+	// 令牌只能访问其所属租户的数据
+	ctx = icontext.NewTenantID(ctx, item.TenantID)
+
+	err = a.checkUser(ctx, item.UserID)
+	if err != nil {
+		if err == errors.ErrNotFound || err == errors.ErrUserDisable {
+			return nil, errors.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	userActionIDs, err := a.queryUserActionIDs(ctx, item.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	mActionIDs := make(map[string]struct{})
+	for _, actionID := range item.ActionIDs {
+		if _, ok := userActionIDs[actionID]; ok || userActionIDs == nil {
+			mActionIDs[actionID] = struct{}{}
+		}
+	}
+
+	resources, err := a.queryActionResources(ctx, mActionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	a.touch(ctx, item)
+	return &schema.APITokenAuth{
+		TokenID:   item.RecordID,
+		UserID:    item.UserID,
+		TenantID:  item.TenantID,
+		Resources: resources,
+	}, nil
+}
+
+// 查询菜单动作对应的资源
+func (a *APIToken) queryActionResources(ctx context.Context, mActionIDs map[string]struct{}) (schema.MenuActionResources, error) {
+	if len(mActionIDs) == 0 {
+		return nil, nil
+	}
+
+	actionIDs := make([]string, 0, len(mActionIDs))
+	for actionID := range mActionIDs {
+		actionIDs = append(actionIDs, actionID)
+	}
+
+	actionResult, err := a.MenuActionModel.Query(ctx, schema.MenuActionQueryParam{
+		RecordIDs: actionIDs,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(actionResult.Data) == 0 {
+		return nil, nil
+	}
+
+	var menuIDs []string
+	for menuID := range actionResult.Data.ToMenuIDMap() {
+		menuIDs = append(menuIDs, menuID)
+	}
+
+	resourceResult, err := a.MenuActionResourceModel.Query(ctx, schema.MenuActionResourceQueryParam{
+		MenuIDs: menuIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources schema.MenuActionResources
+	for _, item := range resourceResult.Data {
+		if _, ok := mActionIDs[item.ActionID]; ok {
+			resources = append(resources, item)
+		}
+	}
+	return resources, nil
+}
+
+// 更新令牌的最后使用时间
+func (a *APIToken) touch(ctx context.Context, item *schema.APIToken) {
+	now := time.Now()
+	if item.LastUsedAt != nil && now.Sub(*item.LastUsedAt) < apiTokenTouchInterval {
+		return
+	}
+
+	if err := a.APITokenModel.UpdateLastUsed(ctx, item.RecordID, now); err != nil {
+		logger.Errorf(ctx, "更新访问令牌最后使用时间发生错误：%s", err.Error())
+	}
+}
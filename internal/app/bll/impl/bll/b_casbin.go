@@ -2,44 +2,368 @@ package bll
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/wangwei518/gin-admin/internal/app/config"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/module/adapter"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/wangwei518/gin-admin/pkg/errors"
 	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"github.com/wangwei518/gin-admin/pkg/watcher"
 	"github.com/casbin/casbin/v2"
 )
 
-var chCasbinPolicy chan *chCasbinPolicyItem
+// 全量加载失败后的重试间隔
+const (
+	minCasbinReloadBackoff = time.Second
+	maxCasbinReloadBackoff = time.Minute
+)
+
+// 策略变更消息的前缀(消息内容为逗号分隔的ID列表，其他内容表示需要全量加载)
+const (
+	casbinRolesMessage = "roles:"
+	casbinUsersMessage = "users:"
+)
+
+// CasbinPolicy casbin权限策略同步
+// 角色或用户变更后，根据业务数据计算策略差异并增量更新到Enforcer；
+// 无法确定影响范围或增量更新失败时，退回到延迟合并的全量加载。
+// 配置了Watcher时，变更同时通知其他实例，其他实例按相同的方式更新策略
+type CasbinPolicy struct {
+	Enforcer *casbin.SyncedEnforcer
+	Adapter  *adapter.CasbinAdapter
+	Watcher  watcher.Watcher
+
+	// 串行执行策略的增量更新及全量加载，避免基于旧数据计算的差异覆盖新的策略
+	lock sync.Mutex
+	// 等待执行的全量加载请求(容量为1，重复的请求合并为一次加载)
+	chReload chan context.Context
+	chStop   chan struct{}
+	chDone   chan struct{}
+}
+
+// Start 启动全量加载策略的后台任务
+func (a *CasbinPolicy) Start() {
+	a.chReload = make(chan context.Context, 1)
+	a.chStop = make(chan struct{})
+	a.chDone = make(chan struct{})
+	go a.run()
+}
+
+// Stop 停止全量加载策略的后台任务(等待中的加载请求被丢弃)
+func (a *CasbinPolicy) Stop() {
+	close(a.chStop)
+	<-a.chDone
+}
+
+func (a *CasbinPolicy) run() {
+	defer close(a.chDone)
+
+	// 定期自动加载策略
+	var chAutoLoad <-chan time.Time
+	if cfg := config.C.Casbin; cfg.AutoLoad && cfg.AutoLoadInternal > 0 {
+		ticker := time.NewTicker(time.Duration(cfg.AutoLoadInternal) * time.Second)
+		defer ticker.Stop()
+		chAutoLoad = ticker.C
+	}
+
+	for {
+		select {
+		case <-a.chStop:
+			return
+		case <-chAutoLoad:
+			if a.Enabled() {
+				a.reloadPolicy(logger.NewTraceIDContext(context.Background(), util.NewTraceID()))
+			}
+		case ctx := <-a.chReload:
+			// 等待一段时间，合并期间内的其他加载请求
+			if v := config.C.Casbin.ReloadDelay; v > 0 && !a.wait(time.Duration(v)*time.Millisecond) {
+				return
+			}
+			select {
+			case <-a.chReload:
+			default:
+			}
+			a.reloadPolicy(ctx)
+		}
+	}
+}
+
+// 等待指定的时间，后台任务停止时返回false
+func (a *CasbinPolicy) wait(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-a.chStop:
+		return false
+	case <-t.C:
+		return true
+	}
+}
 
-type chCasbinPolicyItem struct {
-	ctx context.Context
-	e   *casbin.SyncedEnforcer
+// 全量加载策略并重建角色关系(Enforcer关闭了自动重建角色关系)
+func (a *CasbinPolicy) loadPolicy() error {
+	err := a.Enforcer.LoadPolicy()
+	if err != nil {
+		return err
+	}
+	return a.Enforcer.BuildRoleLinks()
 }
 
-func init() {
-	chCasbinPolicy = make(chan *chCasbinPolicyItem, 1)
-	go func() {
-		for item := range chCasbinPolicy {
-			err := item.e.LoadPolicy()
-			if err != nil {
-				logger.Errorf(item.ctx, "The load casbin policy error: %s", err.Error())
+// 全量加载策略，失败时按退避间隔重试直到成功或后台任务停止
+func (a *CasbinPolicy) reloadPolicy(ctx context.Context) {
+	backoff := minCasbinReloadBackoff
+	for i := 1; ; i++ {
+		a.lock.Lock()
+		err := a.loadPolicy()
+		a.lock.Unlock()
+		if err == nil {
+			if i > 1 {
+				logger.Infof(ctx, "The load casbin policy succeeded after %d attempts", i)
 			}
+			return
 		}
-	}()
+
+		logger.StartSpan(ctx, logger.SetSpanTitle("加载权限策略"), logger.SetSpanFuncName("reloadPolicy")).
+			Errorf("The load casbin policy error (attempt %d, retry in %s): %s", i, backoff, err.Error())
+		if !a.wait(backoff) {
+			return
+		}
+		if backoff *= 2; backoff > maxCasbinReloadBackoff {
+			backoff = maxCasbinReloadBackoff
+		}
+	}
 }
 
-// LoadCasbinPolicy 异步加载casbin权限策略
-func LoadCasbinPolicy(ctx context.Context, e *casbin.SyncedEnforcer) {
-	if !config.C.Casbin.Enable {
+// Enabled 是否启用了权限校验
+func (a *CasbinPolicy) Enabled() bool {
+	return config.C.Casbin.Enable && a.Enforcer.Enforcer != nil
+}
+
+// Enforce 检查用户在指定租户及视图下是否可以访问资源(与权限中间件的校验一致，未启用权限校验时不受限制)
+func (a *CasbinPolicy) Enforce(userID, tenantID, path, method, view string, env *abac.Env) (bool, error) {
+	if !a.Enabled() {
+		return true, nil
+	}
+
+	ok, err := a.Enforcer.Enforce(userID, tenantID, path, method, view, env)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return ok, nil
+}
+
+// Watch 监听其他实例的策略变更消息
+func (a *CasbinPolicy) Watch() error {
+	if a.Watcher == nil {
+		return nil
+	}
+	return a.Watcher.SetUpdateCallback(a.handleMessage)
+}
+
+// 处理其他实例的策略变更消息(只更新本实例的策略，不再转发)
+func (a *CasbinPolicy) handleMessage(payload string) {
+	if !a.Enabled() {
 		return
 	}
 
-	if len(chCasbinPolicy) > 0 {
-		logger.Infof(ctx, "The load casbin policy is already in the wait queue")
+	ctx := logger.NewTraceIDContext(context.Background(), util.NewTraceID())
+	switch {
+	case strings.HasPrefix(payload, casbinRolesMessage):
+		a.applyRoles(ctx, strings.Split(strings.TrimPrefix(payload, casbinRolesMessage), ","))
+	case strings.HasPrefix(payload, casbinUsersMessage):
+		a.applyUsers(ctx, strings.Split(strings.TrimPrefix(payload, casbinUsersMessage), ","))
+	default:
+		a.reload(ctx)
+	}
+}
+
+// 通知其他实例，发送失败时其他实例只能通过定期自动加载更新策略
+func (a *CasbinPolicy) publish(ctx context.Context, payload string) {
+	if a.Watcher == nil {
 		return
 	}
 
-	chCasbinPolicy <- &chCasbinPolicyItem{
-		ctx: ctx,
-		e:   e,
+	err := a.Watcher.Publish(payload)
+	if err != nil {
+		logger.StartSpan(ctx, logger.SetSpanTitle("通知权限策略变更"), logger.SetSpanFuncName("publish")).
+			Errorf("The publish casbin policy change error: %s", err.Error())
+	}
+}
+
+// SyncRoles 增量更新角色策略(p,role_id,tenant_id,path,method,view,eft,cond)及角色继承规则(g,role_id,parent_id,tenant_id)，已删除或停用的角色移除全部策略
+func (a *CasbinPolicy) SyncRoles(ctx context.Context, roleIDs ...string) {
+	if !a.Enabled() || len(roleIDs) == 0 {
+		return
+	}
+
+	a.applyRoles(ctx, roleIDs)
+	a.publish(ctx, casbinRolesMessage+strings.Join(roleIDs, ","))
+}
+
+func (a *CasbinPolicy) applyRoles(ctx context.Context, roleIDs []string) {
+	a.lock.Lock()
+	err := a.syncRoles(ctx, roleIDs)
+	a.lock.Unlock()
+	if err != nil {
+		logger.StartSpan(ctx, logger.SetSpanTitle("更新权限策略"), logger.SetSpanFuncName("SyncRoles")).
+			Errorf("The sync casbin role policy error: %s", err.Error())
+		a.reload(ctx)
+	}
+}
+
+func (a *CasbinPolicy) syncRoles(ctx context.Context, roleIDs []string) error {
+	policies, err := a.Adapter.QueryRolePolicies(ctx, roleIDs...)
+	if err != nil {
+		return err
+	}
+
+	var current [][]string
+	for _, roleID := range roleIDs {
+		current = append(current, a.Enforcer.GetFilteredPolicy(0, roleID)...)
+	}
+
+	addList, delList := compareCasbinRules(current, policies)
+	for _, rule := range delList {
+		if _, err := a.Enforcer.RemovePolicy(rule); err != nil {
+			return err
+		}
+	}
+	for _, rule := range addList {
+		if _, err := a.Enforcer.AddPolicy(rule); err != nil {
+			return err
+		}
+	}
+
+	return a.syncRoleInherits(ctx, roleIDs)
+}
+
+// 角色继承规则的数量较少，每次全量对比(上级角色停用时同样会影响下级角色的继承规则)
+func (a *CasbinPolicy) syncRoleInherits(ctx context.Context, roleIDs []string) error {
+	policies, err := a.Adapter.QueryRoleInheritPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	roleResult, err := a.Adapter.RoleModel.Query(icontext.NewNoTenant(ctx), schema.RoleQueryParam{})
+	if err != nil {
+		return err
+	}
+
+	// 用户的角色分配规则同样使用g，只对比以角色开头的规则(包括已删除的角色)
+	mRoles := roleResult.Data.ToMap()
+	for _, roleID := range roleIDs {
+		mRoles[roleID] = nil
+	}
+
+	var current [][]string
+	for _, rule := range a.Enforcer.GetGroupingPolicy() {
+		if _, ok := mRoles[rule[0]]; ok {
+			current = append(current, rule)
+		}
+	}
+
+	addList, delList := compareCasbinRules(current, policies)
+	return a.updateGroupingPolicies(addList, delList)
+}
+
+// SyncUsers 增量更新用户的角色分配(g,user_id,role_id,tenant_id)，已删除或停用的用户移除全部角色分配
+func (a *CasbinPolicy) SyncUsers(ctx context.Context, userIDs ...string) {
+	if !a.Enabled() || len(userIDs) == 0 {
+		return
+	}
+
+	a.applyUsers(ctx, userIDs)
+	a.publish(ctx, casbinUsersMessage+strings.Join(userIDs, ","))
+}
+
+func (a *CasbinPolicy) applyUsers(ctx context.Context, userIDs []string) {
+	a.lock.Lock()
+	err := a.syncUsers(ctx, userIDs)
+	a.lock.Unlock()
+	if err != nil {
+		logger.StartSpan(ctx, logger.SetSpanTitle("更新权限策略"), logger.SetSpanFuncName("SyncUsers")).
+			Errorf("The sync casbin user policy error: %s", err.Error())
+		a.reload(ctx)
+	}
+}
+
+func (a *CasbinPolicy) syncUsers(ctx context.Context, userIDs []string) error {
+	policies, err := a.Adapter.QueryUserPolicies(ctx, userIDs...)
+	if err != nil {
+		return err
+	}
+
+	var current [][]string
+	for _, userID := range userIDs {
+		current = append(current, a.Enforcer.GetFilteredGroupingPolicy(0, userID)...)
+	}
+
+	addList, delList := compareCasbinRules(current, policies)
+	return a.updateGroupingPolicies(addList, delList)
+}
+
+// Reload 异步全量加载策略，并通知其他实例全量加载
+// 延迟时间内的多次请求合并为一次加载，加载失败时记录错误日志并按退避间隔重试
+func (a *CasbinPolicy) Reload(ctx context.Context) {
+	if !a.Enabled() {
+		return
+	}
+
+	a.reload(ctx)
+	a.publish(ctx, "")
+}
+
+func (a *CasbinPolicy) reload(ctx context.Context) {
+	select {
+	case a.chReload <- ctx:
+	default:
+		logger.Infof(ctx, "The load casbin policy is already in the wait queue")
+	}
+}
+
+// 更新角色继承及角色分配规则，全部变更完成后只重建一次角色关系(Enforcer关闭了自动重建角色关系)
+func (a *CasbinPolicy) updateGroupingPolicies(addList, delList [][]string) error {
+	if len(addList) == 0 && len(delList) == 0 {
+		return nil
+	}
+
+	for _, rule := range delList {
+		if _, err := a.Enforcer.RemoveGroupingPolicy(rule); err != nil {
+			return err
+		}
+	}
+	for _, rule := range addList {
+		if _, err := a.Enforcer.AddGroupingPolicy(rule); err != nil {
+			return err
+		}
+	}
+	return a.Enforcer.BuildRoleLinks()
+}
+
+// 对比策略规则列表
+func compareCasbinRules(oldRules, newRules [][]string) (addList, delList [][]string) {
+	mOldRules := make(map[string][]string)
+	for _, rule := range oldRules {
+		mOldRules[strings.Join(rule, ",")] = rule
+	}
+
+	for _, rule := range newRules {
+		key := strings.Join(rule, ",")
+		if _, ok := mOldRules[key]; ok {
+			delete(mOldRules, key)
+			continue
+		}
+		addList = append(addList, rule)
+	}
+
+	for _, rule := range mOldRules {
+		delList = append(delList, rule)
 	}
+	return
 }
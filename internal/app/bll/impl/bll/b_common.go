@@ -11,13 +11,18 @@ import (
 // GetRootUser 获取root用户
 func GetRootUser() *schema.User {
 	user := config.C.Root
-	return &schema.User{
+	item := &schema.User{
 		RecordID: user.UserName,
 		UserName: user.UserName,
 		RealName: user.RealName,
 		//Password: util.MD5HashString(user.Password),
 		Password: user.Password,
 	}
+	if user.MFASecret != "" {
+		item.MFAEnabled = 1
+		item.MFASecret = user.MFASecret
+	}
+	return item
 }
 
 // CheckIsRootUser 检查是否是root用户
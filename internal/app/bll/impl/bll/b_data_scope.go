@@ -0,0 +1,97 @@
+package bll
+
+import (
+	"context"
+
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/google/wire"
+)
+
+// DataScopeSet 注入DataScope
+var DataScopeSet = wire.NewSet(wire.Struct(new(DataScope), "*"))
+
+// DataScope 数据范围(按当前用户的角色限制可访问的数据)
+type DataScope struct {
+	UserModel    model.IUser
+	UserOrgModel model.IUserOrg
+	RoleModel    model.IRole
+}
+
+// NewContext 创建带有当前用户数据范围的上下文(包含角色继承的上级角色的数据范围)
+// root用户、未登录的调用以及拥有全部数据范围角色的用户不受限制，未设置数据范围的角色(升级前创建的角色)视为全部数据
+func (a *DataScope) NewContext(ctx context.Context) (context.Context, error) {
+	userID, ok := icontext.FromUserID(ctx)
+	if !ok || CheckIsRootUser(ctx, userID) {
+		return ctx, nil
+	} else if _, ok := icontext.FromDataScope(ctx); ok {
+		return ctx, nil
+	}
+
+	result, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		UserID: userID,
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var roleIDs []string
+	for _, item := range result.Data {
+		roleIDs = append(roleIDs, item.RecordID)
+	}
+
+	var roles schema.Roles
+	if len(roleIDs) > 0 {
+		enabledRoleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+			Status: 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		mRoles := enabledRoleResult.Data.ToMap()
+		for _, roleID := range enabledRoleResult.Data.ExpandInheritIDs(roleIDs) {
+			if item, ok := mRoles[roleID]; ok {
+				roles = append(roles, item)
+			}
+		}
+	}
+
+	var (
+		orgIDs []string
+		ownOrg bool
+	)
+	for _, item := range roles {
+		switch item.DataScope {
+		case schema.DataScopeOwn:
+		case schema.DataScopeOrg:
+			ownOrg = true
+		case schema.DataScopeCustom:
+			orgIDs = append(orgIDs, item.DataOrgIDs...)
+		default:
+			return ctx, nil
+		}
+	}
+
+	if ownOrg {
+		user, err := a.UserModel.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		} else if user != nil && user.OrgID != "" {
+			orgIDs = append(orgIDs, user.OrgID)
+		}
+
+		// 兼任部门同样属于本部门范围
+		userOrgResult, err := a.UserOrgModel.Query(ctx, schema.UserOrgQueryParam{
+			UserID: userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		orgIDs = append(orgIDs, userOrgResult.Data.ToOrgIDs()...)
+	}
+
+	return icontext.NewDataScope(ctx, &schema.DataScope{OrgIDs: orgIDs}), nil
+}
@@ -18,16 +18,26 @@ var DemoSet = wire.NewSet(wire.Struct(new(Demo), "*"), wire.Bind(new(bll.IDemo),
 
 // Demo 示例程序
 type Demo struct {
+	DataScope *DataScope
 	DemoModel model.IDemo
 }
 
 // Query 查询数据
 func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...schema.DemoQueryOptions) (*schema.DemoQueryResult, error) {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	return a.DemoModel.Query(ctx, params, opts...)
 }
 
 // Get 查询指定数据
 func (a *Demo) Get(ctx context.Context, recordID string, opts ...schema.DemoQueryOptions) (*schema.Demo, error) {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	item, err := a.DemoModel.Get(ctx, recordID, opts...)
 	if err != nil {
 		return nil, err
@@ -72,7 +82,12 @@ func (a *Demo) Create(ctx context.Context, item schema.Demo) (*schema.RecordIDRe
 
 // Update 更新数据
 func (a *Demo) Update(ctx context.Context, recordID string, item schema.Demo) error {
-	oldItem, err := a.DemoModel.Get(ctx, recordID)
+	scopeCtx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldItem, err := a.DemoModel.Get(scopeCtx, recordID)
 	if err != nil {
 		return err
 	} else if oldItem == nil {
@@ -83,14 +98,20 @@ func (a *Demo) Update(ctx context.Context, recordID string, item schema.Demo) er
 		}
 	}
 	item.RecordID = oldItem.RecordID
+	item.TenantID = oldItem.TenantID
 	item.Creator = oldItem.Creator
 	item.CreatedAt = oldItem.CreatedAt
 
-	return a.DemoModel.Update(ctx, recordID, item)
+	return a.DemoModel.Update(scopeCtx, recordID, item)
 }
 
 // Delete 删除数据
 func (a *Demo) Delete(ctx context.Context, recordID string) error {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	oldItem, err := a.DemoModel.Get(ctx, recordID)
 	if err != nil {
 		return err
@@ -103,6 +124,11 @@ func (a *Demo) Delete(ctx context.Context, recordID string) error {
 
 // UpdateStatus 更新状态
 func (a *Demo) UpdateStatus(ctx context.Context, recordID string, status int) error {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	oldItem, err := a.DemoModel.Get(ctx, recordID)
 	if err != nil {
 		return err
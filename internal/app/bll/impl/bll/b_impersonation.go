@@ -0,0 +1,129 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/auth"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/logger"
+)
+
+// 定义错误
+var (
+	ErrImpersonationDisabled = errors.New400Response("未启用模拟登录")
+	ErrNotImpersonating      = errors.New400Response("当前不是模拟登录")
+)
+
+// 检查操作者是否拥有被模拟用户的全部角色(root用户不受限制)
+func checkImpersonateRoles(ctx context.Context, userRoleModel model.IUserRole, actorID, recordID string) error {
+	if CheckIsRootUser(ctx, actorID) {
+		return nil
+	}
+
+	actorRoles, err := userRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID: actorID,
+	})
+	if err != nil {
+		return err
+	}
+	mRoleIDs := make(map[string]struct{})
+	for _, item := range actorRoles.Data {
+		mRoleIDs[item.RoleID] = struct{}{}
+	}
+
+	userRoles, err := userRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID: recordID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, item := range userRoles.Data {
+		if _, ok := mRoleIDs[item.RoleID]; !ok {
+			return errors.New400Response("不能模拟拥有其他角色的用户")
+		}
+	}
+	return nil
+}
+
+// Impersonate 模拟登录(生成以操作者身份模拟指定用户的令牌)
+// 模拟登录令牌携带操作者声明，期间的日志同时记录操作者ID，到期后不能刷新
+func (a *User) Impersonate(ctx context.Context, actorID, recordID string, params schema.ImpersonateParam) (*schema.LoginTokenInfo, error) {
+	cfg := config.C.Impersonation
+	if !cfg.Enable {
+		return nil, ErrImpersonationDisabled
+	}
+
+	if actorID == recordID {
+		return nil, errors.New400Response("不能模拟自己")
+	} else if CheckIsRootUser(ctx, recordID) {
+		return nil, errors.New400Response("不能模拟root用户")
+	}
+
+	view := params.View
+	if view == "" {
+		view = config.C.JWTAuth.DefaultView
+	} else if !config.C.JWTAuth.HasView(view) {
+		return nil, errors.New400Response("无效的令牌视图")
+	}
+
+	// 被模拟的用户必须在操作者的数据范围内
+	user, err := a.Get(ctx, recordID)
+	if err != nil {
+		return nil, err
+	} else if user.Status != 1 {
+		return nil, errors.ErrUserDisable
+	}
+
+	// 被模拟的用户必须属于操作者当前的租户(没有角色的用户同样不能模拟)
+	ok, err := checkTenantMember(ctx, a.UserRoleModel, recordID)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New400Response("不能模拟不属于当前租户的用户")
+	}
+
+	err = checkImpersonateRoles(ctx, a.UserRoleModel, actorID, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	expired := cfg.Expired
+	if v := params.ExpiresIn; v > 0 && (expired <= 0 || v < expired) {
+		expired = v
+	}
+
+	// 模拟登录令牌属于操作者当前的租户
+	tenantID, _ := icontext.FromTenantID(ctx)
+	tokenInfo, err := a.Auth.GenerateImpersonationToken(auth.NewTenantContext(ctx, tenantID), actorID, recordID, view, expired)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	logger.StartSpan(ctx, logger.SetSpanTitle("模拟登录"), logger.SetSpanFuncName("Impersonate")).
+		WithField("impersonated_user_id", recordID).
+		Warnf("开始模拟登录用户%s[%s]，有效期至%d，原因：%s", user.UserName, recordID, tokenInfo.GetExpiresAt(), params.Reason)
+
+	return toLoginTokenInfo(tokenInfo), nil
+}
+
+// EndImpersonation 结束模拟登录(销毁模拟登录令牌)
+func (a *Login) EndImpersonation(ctx context.Context, tokenString string) error {
+	actorID, ok := icontext.FromActorID(ctx)
+	if !ok {
+		return ErrNotImpersonating
+	}
+
+	err := a.Auth.DestroyToken(ctx, tokenString)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	userID, _ := icontext.FromUserID(ctx)
+	logger.StartSpan(ctx, logger.SetSpanTitle("模拟登录"), logger.SetSpanFuncName("EndImpersonation")).
+		Warnf("操作者%s结束模拟登录用户%s", actorID, userID)
+	return nil
+}
@@ -4,19 +4,29 @@ import (
 	"context"
 	//"crypto/tls"
 	//"log"
-	// "net/http"
+	"net/http"
 	"sort"
-	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LyricTian/captcha"
 
 	"github.com/wangwei518/gin-admin/internal/app/bll"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
 	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/module/authenticator"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"github.com/wangwei518/gin-admin/pkg/auth"
+	"github.com/wangwei518/gin-admin/pkg/counter"
 	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/lockout"
+	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/wangwei518/gin-admin/pkg/mail"
+	"github.com/wangwei518/gin-admin/pkg/oidc"
+	"github.com/wangwei518/gin-admin/pkg/password"
 	"github.com/wangwei518/gin-admin/pkg/util"
 	"github.com/google/wire"
-	"github.com/go-ldap/ldap/v3"
-	"github.com/wangwei518/gin-admin/internal/app/config"
 )
 
 var _ bll.ILogin = (*Login)(nil)
@@ -26,26 +36,38 @@ var LoginSet = wire.NewSet(wire.Struct(new(Login), "*"), wire.Bind(new(bll.ILogi
 
 // Login 登录管理
 type Login struct {
-	Auth            auth.Auther
-	UserModel       model.IUser
-	UserRoleModel   model.IUserRole
-	RoleModel       model.IRole
-	RoleMenuModel   model.IRoleMenu
-	MenuModel       model.IMenu
-	MenuActionModel model.IMenuAction
+	CasbinPolicy       *CasbinPolicy
+	Auth               auth.Auther
+	Authenticator      *authenticator.Chain
+	TransModel         model.ITrans
+	UserModel          model.IUser
+	UserRoleModel      model.IUserRole
+	RoleModel          model.IRole
+	RoleMenuModel      model.IRoleMenu
+	TenantModel        model.ITenant
+	MenuModel          model.IMenu
+	MenuActionModel    model.IMenuAction
+	PasswordManager    *password.Manager
+	PasswordPolicy     *PasswordPolicy
+	PasswordResetModel model.IPasswordReset
+	MailSender         mail.Sender
+	FailureCounter     counter.Counter
+	LoginLocker        *lockout.Locker
+	OIDCClient         *oidc.Client
+	OIDCStore          oidc.SessionStore
 }
 
 // GetCaptcha 获取图形验证码信息
-/*func (a *Login) GetCaptcha(ctx context.Context, length int) (*schema.LoginCaptcha, error) {
+func (a *Login) GetCaptcha(ctx context.Context, length int) (*schema.LoginCaptcha, error) {
 	captchaID := captcha.NewLen(length)
 	item := &schema.LoginCaptcha{
 		CaptchaID: captchaID,
 	}
 	return item, nil
-}*/
+}
 
 // ResCaptcha 生成并响应图形验证码
-/*func (a *Login) ResCaptcha(ctx context.Context, w http.ResponseWriter, captchaID string, width, height int) error {
+func (a *Login) ResCaptcha(ctx context.Context, w http.ResponseWriter, captchaID string, width, height int) error {
 	err := captcha.WriteImage(w, captchaID, width, height)
 	if err != nil {
 		if err == captcha.ErrNotFound {
@@ -59,56 +81,486 @@ type Login struct {
 	w.Header().Set("Expires", "0")
 	w.Header().Set("Content-Type", "image/png")
 	return nil
-}*/
+}
+
+// 登录失败次数的计数键(按客户端IP统计)
+func loginFailureKey(ctx context.Context) string {
+	return "login_ip:" + auth.FromClientContext(ctx).IP
+}
+
+// VerifyCaptcha 校验登录验证码
+func (a *Login) VerifyCaptcha(ctx context.Context, captchaID, captchaCode string) error {
+	cfg := config.C.Captcha
+	if !cfg.Enable {
+		return nil
+	}
+
+	if cfg.FailureThreshold > 0 {
+		n, err := a.FailureCounter.Get(ctx, loginFailureKey(ctx))
+		if err != nil {
+			return errors.WithStack(err)
+		} else if n < int64(cfg.FailureThreshold) {
+			return nil
+		}
+	}
+
+	if captchaID == "" || !captcha.VerifyString(captchaID, captchaCode) {
+		return errors.ErrInvalidCaptcha
+	}
+	return nil
+}
+
+// 记录登录失败次数(仅在启用验证码时统计)
+func (a *Login) incrLoginFailure(ctx context.Context) {
+	cfg := config.C.Captcha
+	if !cfg.Enable || cfg.FailureThreshold <= 0 {
+		return
+	}
+
+	expiration := time.Duration(cfg.FailureExpired) * time.Second
+	if _, err := a.FailureCounter.Incr(ctx, loginFailureKey(ctx), expiration); err != nil {
+		logger.Errorf(ctx, "记录登录失败次数发生错误：%s", err.Error())
+	}
+}
+
+// 登录成功后清除登录失败次数
+func (a *Login) resetLoginFailure(ctx context.Context) {
+	cfg := config.C.Captcha
+	if !cfg.Enable || cfg.FailureThreshold <= 0 {
+		return
+	}
+
+	if err := a.FailureCounter.Reset(ctx, loginFailureKey(ctx)); err != nil {
+		logger.Errorf(ctx, "清除登录失败次数发生错误：%s", err.Error())
+	}
+}
+
+// 检查用户名及客户端IP是否被锁定
+func (a *Login) checkLoginLock(ctx context.Context, userName string) error {
+	if !config.C.LoginLock.Enable {
+		return nil
+	}
+
+	lock, err := a.LoginLocker.Check(ctx, userName, auth.FromClientContext(ctx).IP)
+	if err != nil {
+		return errors.WithStack(err)
+	} else if lock != nil {
+		return errors.ErrLoginLocked
+	}
+	return nil
+}
+
+// 记录登录失败次数，按失败次数延迟响应，达到阈值时锁定
+func (a *Login) failLogin(ctx context.Context, userName string) error {
+	if !config.C.LoginLock.Enable {
+		return nil
+	}
+
+	delay, lock, err := a.LoginLocker.Fail(ctx, userName, auth.FromClientContext(ctx).IP)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if lock != nil {
+		logger.StartSpan(ctx, logger.SetSpanTitle("登录锁定"), logger.SetSpanFuncName("Verify")).
+			Warnf("登录失败次数过多，锁定%s[%s]", lock.Type, lock.Name)
+	}
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	if lock != nil {
+		return errors.ErrLoginLocked
+	}
+	return nil
+}
+
+// 登录成功后清除用户名的失败次数
+func (a *Login) succeedLogin(ctx context.Context, userName string) {
+	if !config.C.LoginLock.Enable {
+		return
+	}
+
+	if err := a.LoginLocker.Succeed(ctx, userName); err != nil {
+		logger.Errorf(ctx, "清除登录失败次数发生错误：%s", err.Error())
+	}
+}
+
+// Verify 登录验证(依次执行认证器链)
+// 用户需要进行多因素认证时同时返回挑战信息，此时不能直接生成令牌
+func (a *Login) Verify(ctx context.Context, userName, password string) (*schema.User, *schema.LoginMFAChallenge, error) {
+	err := a.checkLoginLock(ctx, userName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := a.Authenticator.Authenticate(ctx, userName, password)
+	if err != nil {
+		if err == errors.ErrInvalidUserName || err == errors.ErrInvalidPassword {
+			a.incrLoginFailure(ctx)
+			if lerr := a.failLogin(ctx, userName); lerr != nil {
+				return nil, nil, lerr
+			}
+		}
+		return nil, nil, err
+	}
+
+	logger.StartSpan(ctx, logger.SetSpanTitle("登录验证"), logger.SetSpanFuncName("Verify")).
+		Infof("用户[%s]通过[%s]认证", userName, result.Provider)
+
+	user := result.User
+	if result.Provider == authenticator.LDAPName {
+		user, err = a.syncExternalUser(ctx, result)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	user, challenge, err := a.loginChallenge(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	} else if challenge == nil {
+		// 需要多因素认证时，完成认证后才清除失败次数
+		a.resetLoginFailure(ctx)
+		a.succeedLogin(ctx, userName)
+	}
+	return user, challenge, nil
+}
 
-// Verify 登录验证
-func (a *Login) Verify(ctx context.Context, userName, password string) (*schema.User, error) {
-	
-	// Verify username/pasword if it's admin
-	root := GetRootUser()
-	if userName == root.UserName && root.Password == password {
-		return root, nil
+// 检查用户是否需要多因素认证，需要时返回挑战信息
+func (a *Login) loginChallenge(ctx context.Context, user *schema.User) (*schema.User, *schema.LoginMFAChallenge, error) {
+	required, enroll, err := a.checkMFA(ctx, user)
+	if err != nil {
+		return nil, nil, err
+	} else if !required {
+		return user, nil, nil
+	}
+
+	challenge, err := a.newMFAChallenge(ctx, user.RecordID, enroll)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, challenge, nil
+}
+
+// 同步外部认证(LDAP/OIDC)的用户信息及角色授权
+// 用户按外部身份(认证来源及用户标识)关联，不会按用户名关联到本地用户或其他来源创建的用户
+func (a *Login) syncExternalUser(ctx context.Context, result *authenticator.Result) (*schema.User, error) {
+	extUser := result.User
+	subject := result.Subject
+	if subject == "" {
+		subject = extUser.UserName
+	}
+
+	queryResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		Provider: result.Provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var user *schema.User
+	if len(queryResult.Data) > 0 {
+		user = queryResult.Data[0]
+	} else {
+		queryResult, err = a.UserModel.Query(ctx, schema.UserQueryParam{
+			UserName: extUser.UserName,
+		})
+		if err != nil {
+			return nil, err
+		} else if len(queryResult.Data) > 0 {
+			user = queryResult.Data[0]
+			if !canBindExternalUser(user, result.Provider) {
+				logger.StartSpan(ctx, logger.SetSpanTitle("登录验证"), logger.SetSpanFuncName("syncExternalUser")).
+					Warnf("%s用户[%s]与已存在的用户同名，不允许自动关联", result.Provider, extUser.UserName)
+				return nil, errors.New400Response("用户名已被其他账号使用，请联系管理员")
+			}
+		}
 	}
 
-	// ---------------------------------------------------------------
-	// Verify username/password with LDAP server
-	// ---------------------------------------------------------------
-	ldapC := config.C.LDAP
-	
-	conn, err := ldap.DialURL(ldapC.Addr)
+	if user != nil {
+		if user.IsService() {
+			return nil, errors.ErrInvalidUser
+		} else if user.Status != 1 {
+			return nil, errors.ErrUserDisable
+		}
+	}
+
+	roleIDs, managedRoleIDs, err := a.mapGroupRoles(ctx, result.Provider, result.Groups)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	defer conn.Close()
 
-	userDN := fmt.Sprintf("cn=%s,%s", userName, ldapC.DN)
-	err = conn.Bind(userDN, password)
+	var changed bool
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		if user == nil {
+			user = &schema.User{
+				RecordID: util.NewRecordID(),
+				UserName: extUser.UserName,
+				RealName: extUser.RealName,
+				Phone:    extUser.Phone,
+				Email:    extUser.Email,
+				Status:   1,
+				Provider: result.Provider,
+				Subject:  subject,
+				Creator:  result.Provider,
+			}
+			err := a.UserModel.Create(ctx, *user)
+			if err != nil {
+				return err
+			}
+		} else if user.RealName != extUser.RealName || user.Phone != extUser.Phone || user.Email != extUser.Email ||
+			user.Provider != result.Provider || user.Subject != subject {
+			user.Provider = result.Provider
+			user.Subject = subject
+			user.RealName = extUser.RealName
+			user.Phone = extUser.Phone
+			user.Email = extUser.Email
+			err := a.UserModel.Update(ctx, user.RecordID, *user)
+			if err != nil {
+				return err
+			}
+		}
+
+		ok, err := a.syncUserRoles(ctx, user.RecordID, roleIDs, managedRoleIDs)
+		if err != nil {
+			return err
+		}
+		changed = ok
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &schema.User{
-		RecordID: userName,
-		UserName: userName,
-		RealName: userName,
-	}, nil
+	// 仅在用户的角色分配发生变化时更新该用户的权限策略
+	if changed {
+		a.CasbinPolicy.SyncUsers(ctx, user.RecordID)
+	}
+	return user, nil
 }
 
-// GenerateToken 生成令牌
-func (a *Login) GenerateToken(ctx context.Context, userID string) (*schema.LoginTokenInfo, error) {
-	tokenInfo, err := a.Auth.GenerateToken(ctx, userID, "plt")
+// 检查外部身份能否关联到已存在的同名用户(尚未关联外部身份时)
+// 只有升级前由LDAP创建且未设置本地密码的用户可以按用户名关联(LDAP的用户名由目录统一管理)；
+// OIDC的用户名声明可能由用户在身份提供方自行设置，不按用户名关联
+func canBindExternalUser(user *schema.User, provider string) bool {
+	return provider == authenticator.LDAPName &&
+		user.Provider == "" &&
+		user.Password == "" &&
+		user.Creator == provider
+}
+
+// 外部用户组与角色的映射
+type groupRole struct {
+	Group    string
+	RoleName string
+}
+
+// 获取认证器配置的用户组与角色映射表
+func externalGroupRoles(provider string) []groupRole {
+	var list []groupRole
+	switch provider {
+	case authenticator.LDAPName:
+		for _, item := range config.C.LDAP.GroupRoles {
+			list = append(list, groupRole{Group: item.GroupDN, RoleName: item.RoleName})
+		}
+	case authenticator.OIDCName:
+		for _, item := range config.C.OIDC.GroupRoles {
+			list = append(list, groupRole{Group: item.Group, RoleName: item.RoleName})
+		}
+	}
+	return list
+}
+
+// 根据组与角色的映射表获取用户应授权的角色，以及映射表管理的全部角色
+func (a *Login) mapGroupRoles(ctx context.Context, provider string, groups []string) (roleIDs, managedRoleIDs []string, err error) {
+	mGroups := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		mGroups[strings.ToLower(group)] = struct{}{}
+	}
+
+	for _, item := range externalGroupRoles(provider) {
+		roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+			Name: item.RoleName,
+		})
+		if err != nil {
+			return nil, nil, err
+		} else if len(roleResult.Data) == 0 {
+			logger.StartSpan(ctx, logger.SetSpanTitle("登录验证"), logger.SetSpanFuncName("mapGroupRoles")).
+				Warnf("%s组[%s]映射的角色[%s]不存在", provider, item.Group, item.RoleName)
+			continue
+		}
+
+		roleID := roleResult.Data[0].RecordID
+		managedRoleIDs = append(managedRoleIDs, roleID)
+		if _, ok := mGroups[strings.ToLower(item.Group)]; ok {
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+	return
+}
+
+// 同步用户角色(仅增删映射表管理的角色)，返回角色分配是否发生变化
+func (a *Login) syncUserRoles(ctx context.Context, userID string, roleIDs, managedRoleIDs []string) (bool, error) {
+	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID: userID,
+	})
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return false, err
+	}
+
+	mNewRoles := make(map[string]struct{}, len(roleIDs))
+	for _, roleID := range roleIDs {
+		mNewRoles[roleID] = struct{}{}
+	}
+
+	var changed bool
+	mManagedRoles := make(map[string]struct{}, len(managedRoleIDs))
+	for _, roleID := range managedRoleIDs {
+		mManagedRoles[roleID] = struct{}{}
+	}
+
+	for _, item := range userRoleResult.Data {
+		if _, ok := mNewRoles[item.RoleID]; ok {
+			delete(mNewRoles, item.RoleID)
+			continue
+		}
+
+		if _, ok := mManagedRoles[item.RoleID]; ok {
+			err := a.UserRoleModel.Delete(ctx, item.RecordID)
+			if err != nil {
+				return false, err
+			}
+			changed = true
+		}
+	}
+
+	if len(mNewRoles) == 0 {
+		return changed, nil
+	}
+
+	// 用户角色属于角色所在的租户
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		RecordIDs: roleIDs,
+	})
+	if err != nil {
+		return false, err
+	}
+	mRoles := roleResult.Data.ToMap()
+
+	for _, roleID := range roleIDs {
+		if _, ok := mNewRoles[roleID]; !ok {
+			continue
+		}
+		delete(mNewRoles, roleID)
+
+		var tenantID string
+		if role, ok := mRoles[roleID]; ok {
+			tenantID = role.TenantID
+		}
+
+		err := a.UserRoleModel.Create(ctx, schema.UserRole{
+			RecordID: util.NewRecordID(),
+			UserID:   userID,
+			RoleID:   roleID,
+			TenantID: tenantID,
+		})
+		if err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// CheckView 校验令牌视图(为空时返回默认视图)
+func (a *Login) CheckView(view string) (string, error) {
+	cfg := config.C.JWTAuth
+	if view == "" {
+		view = cfg.DefaultView
+	}
+
+	if !cfg.HasView(view) {
+		return "", errors.New400Response("无效的令牌视图")
+	}
+	return view, nil
+}
+
+// GenerateToken 生成指定视图的令牌(令牌属于用户的默认租户)
+// 用户被要求修改密码或密码已过期时，只生成修改密码视图的受限令牌
+func (a *Login) GenerateToken(ctx context.Context, userID, view string) (*schema.LoginTokenInfo, error) {
+	tenantID, err := a.defaultTenantID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return a.generateToken(ctx, userID, view, tenantID)
+}
+
+// 生成指定租户及视图的令牌
+func (a *Login) generateToken(ctx context.Context, userID, view, tenantID string) (*schema.LoginTokenInfo, error) {
+	var passwordChangeRequired bool
+	if !CheckIsRootUser(ctx, userID) {
+		user, err := a.UserModel.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		} else if user != nil && a.PasswordPolicy.MustChange(user) {
+			passwordChangeRequired = true
+			view = schema.ViewPasswordChange
+		}
 	}
 
-	item := &schema.LoginTokenInfo{
-		AccessToken: tokenInfo.GetAccessToken(),
-		TokenType:   tokenInfo.GetTokenType(),
-		ExpiresAt:   tokenInfo.GetExpiresAt(),
+	tokenInfo, err := a.Auth.GenerateToken(auth.NewTenantContext(ctx, tenantID), userID, view)
+	if err != nil {
+		return nil, errors.WithStack(err)
 	}
+
+	item := toLoginTokenInfo(tokenInfo)
+	item.PasswordChangeRequired = passwordChangeRequired
 	return item, nil
 }
 
+func toLoginTokenInfo(tokenInfo auth.TokenInfo) *schema.LoginTokenInfo {
+	return &schema.LoginTokenInfo{
+		AccessToken:      tokenInfo.GetAccessToken(),
+		TokenType:        tokenInfo.GetTokenType(),
+		ExpiresAt:        tokenInfo.GetExpiresAt(),
+		RefreshToken:     tokenInfo.GetRefreshToken(),
+		RefreshExpiresAt: tokenInfo.GetRefreshExpiresAt(),
+	}
+}
+
+// RefreshToken 刷新令牌
+func (a *Login) RefreshToken(ctx context.Context, refreshToken string) (*schema.LoginTokenInfo, error) {
+	tokenInfo, err := a.Auth.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		if err == auth.ErrTokenReused {
+			logger.StartSpan(ctx, logger.SetSpanTitle("刷新令牌"), logger.SetSpanFuncName("RefreshToken")).
+				Warnf("刷新令牌被重复使用，已撤销该会话")
+		}
+		return nil, errors.ErrInvalidToken
+	}
+
+	userID, _, err := a.Auth.ParseUserID(ctx, tokenInfo.GetAccessToken())
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if !CheckIsRootUser(ctx, userID) {
+		_, err := a.checkAndGetUser(ctx, userID)
+		if err != nil {
+			_ = a.Auth.DestroyToken(ctx, tokenInfo.GetAccessToken())
+			return nil, err
+		}
+	}
+
+	return toLoginTokenInfo(tokenInfo), nil
+}
+
 // DestroyToken 销毁令牌
 func (a *Login) DestroyToken(ctx context.Context, tokenString string) error {
 	err := a.Auth.DestroyToken(ctx, tokenString)
@@ -203,8 +655,16 @@ func (a *Login) QueryUserMenuTree(ctx context.Context, userID string) (schema.Me
 		return nil, errors.ErrNoPerm
 	}
 
+	// 合并角色继承的上级角色菜单
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
-		RoleIDs: userRoleResult.Data.ToRoleIDs(),
+		RoleIDs: roleResult.Data.ExpandInheritIDs(userRoleResult.Data.ToRoleIDs()),
 	})
 	if err != nil {
 		return nil, err
@@ -259,10 +719,201 @@ func (a *Login) UpdatePassword(ctx context.Context, userID string, params schema
 	user, err := a.checkAndGetUser(ctx, userID)
 	if err != nil {
 		return err
-	} else if util.SHA1HashString(params.OldPassword) != user.Password {
+	} else if user.Password == "" {
+		return errors.New400Response("外部认证用户不允许更新密码")
+	}
+
+	ok, _, err := a.PasswordManager.Verify(user.Password, params.OldPassword)
+	if err != nil {
+		return errors.WithStack(err)
+	} else if !ok {
 		return errors.New400Response("旧密码不正确")
 	}
 
-	params.NewPassword = util.SHA1HashString(params.NewPassword)
-	return a.UserModel.UpdatePassword(ctx, userID, params.NewPassword)
+	encoded, err := a.PasswordPolicy.Hash(ctx, userID, user.Password, params.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	// 修改密码后解除强制修改密码的限制
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.PasswordPolicy.Save(ctx, userID, encoded)
+		if err != nil {
+			return err
+		}
+
+		return a.UserModel.ChangePassword(ctx, userID, schema.UserPassword{
+			Password:   encoded,
+			ChangedAt:  time.Now(),
+			MustChange: 2,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// 密码变更后撤销用户的全部会话
+	err = a.Auth.RevokeSessions(ctx, userID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// QuerySessions 查询当前用户的有效会话
+func (a *Login) QuerySessions(ctx context.Context, userID string) (schema.UserSessions, error) {
+	sessions, err := a.Auth.QuerySessions(ctx, userID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return toUserSessions(sessions), nil
+}
+
+// RevokeSession 撤销当前用户的指定会话
+func (a *Login) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	err := a.Auth.RevokeSession(ctx, userID, sessionID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// 查询用户所属的租户ID列表(用户在租户中分配了角色即属于该租户，不包括默认租户)
+func queryUserTenantIDs(ctx context.Context, userRoleModel model.IUserRole, userID string) ([]string, bool, error) {
+	result, err := userRoleModel.Query(icontext.NewNoTenant(ctx), schema.UserRoleQueryParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var (
+		tenantIDs  []string
+		hasDefault bool
+	)
+	m := make(map[string]struct{})
+	for _, item := range result.Data {
+		if item.TenantID == "" {
+			hasDefault = true
+			continue
+		} else if _, ok := m[item.TenantID]; ok {
+			continue
+		}
+		m[item.TenantID] = struct{}{}
+		tenantIDs = append(tenantIDs, item.TenantID)
+	}
+	return tenantIDs, hasDefault, nil
+}
+
+// 检查用户是否属于上下文中的当前租户(在当前租户中分配了角色)
+func checkTenantMember(ctx context.Context, userRoleModel model.IUserRole, userID string) (bool, error) {
+	tenantIDs, hasDefault, err := queryUserTenantIDs(ctx, userRoleModel, userID)
+	if err != nil {
+		return false, err
+	}
+
+	tenantID, _ := icontext.FromTenantID(ctx)
+	if tenantID == "" {
+		return hasDefault, nil
+	}
+	for _, id := range tenantIDs {
+		if id == tenantID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 获取用户登录时的默认租户
+// 用户在默认租户中分配了角色(或没有分配任何角色)时为默认租户，否则为其所属的第一个启用的租户，root用户为默认租户
+func (a *Login) defaultTenantID(ctx context.Context, userID string) (string, error) {
+	if CheckIsRootUser(ctx, userID) {
+		return "", nil
+	}
+
+	tenantIDs, hasDefault, err := queryUserTenantIDs(ctx, a.UserRoleModel, userID)
+	if err != nil {
+		return "", err
+	} else if hasDefault || len(tenantIDs) == 0 {
+		return "", nil
+	}
+
+	result, err := a.TenantModel.Query(ctx, schema.TenantQueryParam{
+		RecordIDs: tenantIDs,
+		Status:    1,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	mTenants := result.Data.ToMap()
+	for _, tenantID := range tenantIDs {
+		if _, ok := mTenants[tenantID]; ok {
+			return tenantID, nil
+		}
+	}
+	return "", nil
+}
+
+// QueryTenants 查询用户可以切换的租户(启用的租户，root用户可以切换到全部启用的租户，默认租户不在列表中)
+func (a *Login) QueryTenants(ctx context.Context, userID string) (schema.Tenants, error) {
+	params := schema.TenantQueryParam{
+		Status: 1,
+	}
+	if !CheckIsRootUser(ctx, userID) {
+		tenantIDs, _, err := queryUserTenantIDs(ctx, a.UserRoleModel, userID)
+		if err != nil {
+			return nil, err
+		} else if len(tenantIDs) == 0 {
+			return schema.Tenants{}, nil
+		}
+		params.RecordIDs = tenantIDs
+	}
+
+	result, err := a.TenantModel.Query(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return result.Data, nil
+}
+
+// SwitchTenant 切换租户(生成当前视图下指定租户的令牌，并销毁当前令牌)
+// 租户ID为空时切换到默认租户(普通用户需要在默认租户中分配了角色，root用户切换到默认租户时可以访问全部租户的数据)
+func (a *Login) SwitchTenant(ctx context.Context, userID, tenantID, tokenString string) (*schema.LoginTokenInfo, error) {
+	if tenantID != "" {
+		tenant, err := a.TenantModel.Get(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		} else if tenant == nil || tenant.Status != 1 {
+			return nil, errors.New400Response("租户不存在或已停用")
+		}
+	}
+
+	// 普通用户只能切换到分配了角色的租户(包括默认租户)
+	if !CheckIsRootUser(ctx, userID) {
+		ok, err := checkTenantMember(icontext.NewTenantID(ctx, tenantID), a.UserRoleModel, userID)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, errors.New400Response("用户不属于该租户")
+		}
+	}
+
+	view, _ := icontext.FromView(ctx)
+	tokenInfo, err := a.generateToken(ctx, userID, view, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 切换后原令牌失效(未启用认证时不存在原令牌)
+	if tokenString != "" {
+		err = a.Auth.DestroyToken(ctx, tokenString)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	logger.StartSpan(ctx, logger.SetSpanTitle("切换租户"), logger.SetSpanFuncName("SwitchTenant")).
+		Infof("切换到租户[%s]", tenantID)
+	return tokenInfo, nil
 }
@@ -2,10 +2,13 @@ package bll
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/wangwei518/gin-admin/internal/app/bll"
 	"github.com/wangwei518/gin-admin/internal/app/model"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
 	"github.com/wangwei518/gin-admin/pkg/errors"
 	"github.com/wangwei518/gin-admin/pkg/util"
 	"github.com/google/wire"
@@ -18,6 +21,7 @@ var MenuSet = wire.NewSet(wire.Struct(new(Menu), "*"), wire.Bind(new(bll.IMenu),
 
 // Menu 菜单管理
 type Menu struct {
+	CasbinPolicy            *CasbinPolicy
 	TransModel              model.ITrans
 	MenuModel               model.IMenu
 	MenuActionModel         model.IMenuAction
@@ -97,12 +101,33 @@ func (a *Menu) checkName(ctx context.Context, item schema.Menu) error {
 	return nil
 }
 
+// 检查资源的访问条件
+func (a *Menu) checkResources(items schema.MenuActions) error {
+	for _, item := range items {
+		for _, ritem := range item.Resources {
+			_, err := abac.Parse(ritem.IPRanges, ritem.TimeWindow, ritem.Attributes)
+			if err != nil {
+				return errors.New400Response(fmt.Sprintf("资源(%s %s)的访问条件无效：%s", ritem.Method, ritem.Path, err.Error()))
+			}
+			// 请求属性由客户端提供，省略即可绕过拒绝规则
+			if ritem.GetEffect() == schema.ResourceEffectDeny && strings.TrimSpace(ritem.Attributes) != "" {
+				return errors.New400Response(fmt.Sprintf("资源(%s %s)的拒绝规则不能使用请求属性条件", ritem.Method, ritem.Path))
+			}
+		}
+	}
+	return nil
+}
+
 // Create 创建数据
 func (a *Menu) Create(ctx context.Context, item schema.Menu) (*schema.RecordIDResult, error) {
 	if err := a.checkName(ctx, item); err != nil {
 		return nil, err
 	}
 
+	if err := a.checkResources(item.Actions); err != nil {
+		return nil, err
+	}
+
 	parentPath, err := a.getParentPath(ctx, item.ParentID)
 	if err != nil {
 		return nil, err
@@ -188,6 +213,10 @@ func (a *Menu) Update(ctx context.Context, recordID string, item schema.Menu) er
 		}
 	}
 
+	if err := a.checkResources(item.Actions); err != nil {
+		return err
+	}
+
 	if oldItem.ParentID != item.ParentID {
 		parentPath, err := a.getParentPath(ctx, item.ParentID)
 		if err != nil {
@@ -201,7 +230,7 @@ func (a *Menu) Update(ctx context.Context, recordID string, item schema.Menu) er
 	item.RecordID = oldItem.RecordID
 	item.Creator = oldItem.Creator
 	item.CreatedAt = oldItem.CreatedAt
-	return ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
 		err := a.updateActions(ctx, recordID, oldItem.Actions, item.Actions)
 		if err != nil {
 			return err
@@ -214,6 +243,13 @@ func (a *Menu) Update(ctx context.Context, recordID string, item schema.Menu) er
 
 		return a.MenuModel.Update(ctx, recordID, item)
 	})
+	if err != nil {
+		return err
+	}
+
+	// 菜单资源变更会影响所有授权了该菜单的角色，使用全量加载更新策略
+	a.CasbinPolicy.Reload(ctx)
+	return nil
 }
 
 // 更新动作数据
@@ -267,7 +303,9 @@ func (a *Menu) updateActions(ctx context.Context, menuID string, oldItems, newIt
 		mOldResources := oitem.Resources.ToMap()
 		for _, uitem := range updateResources {
 			uoitem := mOldResources[uitem.RecordID]
-			if uoitem.Method == uitem.Method && uoitem.Path == uitem.Path {
+			if uoitem.Method == uitem.Method && uoitem.Path == uitem.Path && uoitem.View == uitem.View &&
+				uoitem.Effect == uitem.Effect && uoitem.IPRanges == uitem.IPRanges &&
+				uoitem.TimeWindow == uitem.TimeWindow && uoitem.Attributes == uitem.Attributes {
 				continue
 			}
 
@@ -364,19 +402,25 @@ func (a *Menu) Delete(ctx context.Context, recordID string) error {
 		return errors.ErrNotAllowDeleteWithChild
 	}
 
-	return ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		err = a.MenuActionResourceModel.DeleteByMenuID(ctx, recordID)
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.MenuActionResourceModel.DeleteByMenuID(ctx, recordID)
 		if err != nil {
 			return err
 		}
 
-		err := a.MenuActionModel.DeleteByMenuID(ctx, recordID)
+		err = a.MenuActionModel.DeleteByMenuID(ctx, recordID)
 		if err != nil {
 			return err
 		}
 
 		return a.MenuModel.Delete(ctx, recordID)
 	})
+	if err != nil {
+		return err
+	}
+
+	a.CasbinPolicy.Reload(ctx)
+	return nil
 }
 
 // UpdateStatus 更新状态
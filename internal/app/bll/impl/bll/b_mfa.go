@@ -0,0 +1,352 @@
+package bll
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/wangwei518/gin-admin/pkg/totp"
+)
+
+// 默认的恢复码数量
+const defaultRecoveryCodes = 10
+
+// 检查用户是否需要进行多因素认证
+// 返回是否需要认证，以及是否需要先绑定认证器(角色要求但用户未启用)
+func (a *Login) checkMFA(ctx context.Context, user *schema.User) (required, enroll bool, err error) {
+	if user.MFAEnabled == 1 {
+		return true, false, nil
+	} else if CheckIsRootUser(ctx, user.RecordID) {
+		return false, false, nil
+	}
+
+	required, err = a.checkRoleRequireMFA(ctx, user.RecordID)
+	if err != nil {
+		return false, false, err
+	}
+	return required, required, nil
+}
+
+// 检查用户的角色是否要求多因素认证
+func (a *Login) checkRoleRequireMFA(ctx context.Context, userID string) (bool, error) {
+	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	} else if len(userRoleResult.Data) == 0 {
+		return false, nil
+	}
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		RecordIDs: userRoleResult.Data.ToRoleIDs(),
+		Status:    1,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, item := range roleResult.Data {
+		if item.RequireMFA == 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 创建多因素认证挑战
+func (a *Login) newMFAChallenge(ctx context.Context, userID string, enroll bool) (*schema.LoginMFAChallenge, error) {
+	token, expiresAt, err := a.Auth.GenerateChallengeToken(ctx, userID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &schema.LoginMFAChallenge{
+		MFARequired:    true,
+		MFAToken:       token,
+		ExpiresAt:      expiresAt,
+		EnrollRequired: enroll,
+	}, nil
+}
+
+// 获取挑战令牌对应的用户
+func (a *Login) getChallengeUser(ctx context.Context, mfaToken string, consume bool) (*schema.User, error) {
+	userID, err := a.Auth.ParseChallengeToken(ctx, mfaToken, consume)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if CheckIsRootUser(ctx, userID) {
+		return GetRootUser(), nil
+	}
+	return a.checkAndGetUser(ctx, userID)
+}
+
+// VerifyMFA 使用挑战令牌及动态验证码(或恢复码)完成登录验证
+// 挑战令牌只能提交一次，验证失败需要重新登录
+func (a *Login) VerifyMFA(ctx context.Context, params schema.LoginMFAParam) (*schema.User, []string, error) {
+	user, err := a.getChallengeUser(ctx, params.MFAToken, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = a.checkLoginLock(ctx, user.UserName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	span := logger.StartSpan(ctx, logger.SetSpanTitle("多因素认证"), logger.SetSpanFuncName("VerifyMFA"))
+
+	// 登录时绑定认证器
+	if user.MFAEnabled != 1 {
+		codes, err := a.activateMFA(ctx, user, params.Code)
+		if err == errors.ErrInvalidMFACode {
+			span.Warnf("用户[%s]绑定认证器失败", user.UserName)
+			return nil, nil, a.failMFA(ctx, user.UserName)
+		} else if err != nil {
+			return nil, nil, err
+		}
+		a.succeedMFA(ctx, user.UserName)
+		span.Infof("用户[%s]完成认证器绑定", user.UserName)
+		return user, codes.RecoveryCodes, nil
+	}
+
+	ok, err := a.verifyMFACode(ctx, user, params.Code, true)
+	if err != nil {
+		return nil, nil, err
+	} else if !ok {
+		span.Warnf("用户[%s]多因素认证失败", user.UserName)
+		return nil, nil, a.failMFA(ctx, user.UserName)
+	}
+	a.succeedMFA(ctx, user.UserName)
+	return user, nil, nil
+}
+
+// 多因素认证失败时与密码错误一样计入登录失败次数
+func (a *Login) failMFA(ctx context.Context, userName string) error {
+	a.incrLoginFailure(ctx)
+	if err := a.failLogin(ctx, userName); err != nil {
+		return err
+	}
+	return errors.ErrInvalidMFACode
+}
+
+// 多因素认证成功(登录完成)后清除登录失败次数
+func (a *Login) succeedMFA(ctx context.Context, userName string) {
+	a.resetLoginFailure(ctx)
+	a.succeedLogin(ctx, userName)
+}
+
+// BeginMFAEnroll 登录时为角色要求多因素认证的用户生成认证器绑定信息
+func (a *Login) BeginMFAEnroll(ctx context.Context, mfaToken string) (*schema.MFAEnrollInfo, error) {
+	user, err := a.getChallengeUser(ctx, mfaToken, false)
+	if err != nil {
+		return nil, err
+	} else if user.MFAEnabled == 1 {
+		return nil, errors.New400Response("已启用多因素认证")
+	}
+	return a.enrollMFA(ctx, user)
+}
+
+// EnrollMFA 为当前用户生成认证器绑定信息(需要激活后生效)
+func (a *Login) EnrollMFA(ctx context.Context, userID string) (*schema.MFAEnrollInfo, error) {
+	user, err := a.getMFAUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user.MFAEnabled == 1 {
+		return nil, errors.New400Response("已启用多因素认证，请先停用")
+	}
+	return a.enrollMFA(ctx, user)
+}
+
+// ActivateMFA 校验动态验证码并启用当前用户的多因素认证
+func (a *Login) ActivateMFA(ctx context.Context, userID, code string) (*schema.MFARecoveryCodes, error) {
+	user, err := a.getMFAUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user.MFAEnabled == 1 {
+		return nil, errors.New400Response("已启用多因素认证")
+	}
+	return a.activateMFA(ctx, user, code)
+}
+
+// DisableMFA 校验动态验证码并停用当前用户的多因素认证
+func (a *Login) DisableMFA(ctx context.Context, userID, code string) error {
+	user, err := a.getMFAUser(ctx, userID)
+	if err != nil {
+		return err
+	} else if user.MFAEnabled != 1 {
+		return errors.New400Response("未启用多因素认证")
+	}
+
+	required, err := a.checkRoleRequireMFA(ctx, userID)
+	if err != nil {
+		return err
+	} else if required {
+		return errors.New400Response("用户角色要求启用多因素认证，不允许停用")
+	}
+
+	ok, err := a.verifyMFACode(ctx, user, code, false)
+	if err != nil {
+		return err
+	} else if !ok {
+		return errors.ErrInvalidMFACode
+	}
+
+	return a.UserModel.UpdateMFA(ctx, userID, schema.UserMFA{Enabled: 2})
+}
+
+// RegenerateRecoveryCodes 校验动态验证码并重新生成当前用户的恢复码
+func (a *Login) RegenerateRecoveryCodes(ctx context.Context, userID, code string) (*schema.MFARecoveryCodes, error) {
+	user, err := a.getMFAUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user.MFAEnabled != 1 {
+		return nil, errors.New400Response("未启用多因素认证")
+	}
+
+	ok, err := a.validateTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.ErrInvalidMFACode
+	}
+	return a.saveMFA(ctx, user)
+}
+
+// 获取可以管理多因素认证的当前用户
+func (a *Login) getMFAUser(ctx context.Context, userID string) (*schema.User, error) {
+	if CheckIsRootUser(ctx, userID) {
+		return nil, errors.New400Response("root用户请通过配置文件设置多因素认证")
+	}
+	return a.checkAndGetUser(ctx, userID)
+}
+
+// 生成待激活的密钥
+func (a *Login) enrollMFA(ctx context.Context, user *schema.User) (*schema.MFAEnrollInfo, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	err = a.UserModel.UpdateMFA(ctx, user.RecordID, schema.UserMFA{
+		Enabled: 2,
+		Secret:  secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := config.C.MFA.Issuer
+	if issuer == "" {
+		issuer = "gin-admin"
+	}
+
+	return &schema.MFAEnrollInfo{
+		Secret: secret,
+		URI:    totp.URI(issuer, user.UserName, secret),
+	}, nil
+}
+
+// 使用待激活的密钥校验动态验证码，启用多因素认证并生成恢复码
+func (a *Login) activateMFA(ctx context.Context, user *schema.User, code string) (*schema.MFARecoveryCodes, error) {
+	if user.MFASecret == "" {
+		return nil, errors.New400Response("请先绑定认证器")
+	}
+
+	ok, err := a.validateTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.ErrInvalidMFACode
+	}
+	return a.saveMFA(ctx, user)
+}
+
+// 启用多因素认证并生成新的恢复码
+func (a *Login) saveMFA(ctx context.Context, user *schema.User) (*schema.MFARecoveryCodes, error) {
+	n := config.C.MFA.RecoveryCodes
+	if n <= 0 {
+		n = defaultRecoveryCodes
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(n)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = totp.HashRecoveryCode(code)
+	}
+
+	err = a.UserModel.UpdateMFA(ctx, user.RecordID, schema.UserMFA{
+		Enabled:       1,
+		Secret:        user.MFASecret,
+		RecoveryCodes: strings.Join(hashes, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// 校验动态验证码，allowRecovery为true时也接受恢复码(使用后作废)
+func (a *Login) verifyMFACode(ctx context.Context, user *schema.User, code string, allowRecovery bool) (bool, error) {
+	ok, err := a.validateTOTP(ctx, user, code)
+	if err != nil || ok {
+		return ok, err
+	} else if !allowRecovery || user.MFARecoveryCodes == "" {
+		return false, nil
+	}
+
+	hashes := strings.Split(user.MFARecoveryCodes, ",")
+	i := totp.MatchRecoveryCode(hashes, code)
+	if i < 0 {
+		return false, nil
+	}
+
+	hashes = append(hashes[:i], hashes[i+1:]...)
+	err = a.UserModel.UpdateMFA(ctx, user.RecordID, schema.UserMFA{
+		Enabled:       1,
+		Secret:        user.MFASecret,
+		RecoveryCodes: strings.Join(hashes, ","),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	logger.StartSpan(ctx, logger.SetSpanTitle("多因素认证"), logger.SetSpanFuncName("verifyMFACode")).
+		Warnf("用户[%s]使用了恢复码，剩余%d个", user.UserName, len(hashes))
+	return true, nil
+}
+
+// 校验动态验证码，每个时间步长的验证码只能使用一次(不允许使用早于最后一次验证通过的验证码)
+func (a *Login) validateTOTP(ctx context.Context, user *schema.User, code string) (bool, error) {
+	step, ok := totp.ValidateStep(code, user.MFASecret, time.Now(), config.C.MFA.Skew)
+	if !ok {
+		return false, nil
+	}
+
+	// root用户不存在于数据库中，已使用的时间步长记录在令牌存储中(多个实例共享)，保留到验证码失效
+	if CheckIsRootUser(ctx, user.RecordID) {
+		expiration := time.Duration(2*config.C.MFA.Skew+1) * totp.DefaultPeriod * time.Second
+		ok, err := a.Auth.UseOnce(ctx, fmt.Sprintf("mfa:%s:%d", user.RecordID, step), expiration)
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		return ok, nil
+	}
+
+	ok, err := a.UserModel.UpdateMFAStep(ctx, user.RecordID, step)
+	if err != nil {
+		return false, err
+	}
+	return ok, nil
+}
@@ -0,0 +1,221 @@
+package bll
+
+import (
+	"context"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/module/authenticator"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/wangwei518/gin-admin/pkg/oidc"
+)
+
+// 定义错误
+var (
+	ErrOIDCDisabled     = errors.New400Response("未启用OIDC登录")
+	ErrInvalidOIDCState = errors.New400Response("无效的OIDC授权请求")
+	ErrOIDCAuthorize    = errors.New400Response("OIDC授权失败")
+)
+
+// 默认的授权请求有效期
+const defaultOIDCStateExpired = 300
+
+// 授权请求会话中记录令牌视图的键
+const oidcViewKey = "view"
+
+// BeginOIDC 发起OIDC登录(生成state、nonce及PKCE校验码，返回授权请求地址)
+func (a *Login) BeginOIDC(ctx context.Context, view string) (*schema.LoginOIDCAuthorize, error) {
+	cfg := config.C.OIDC
+	if !cfg.Enable {
+		return nil, ErrOIDCDisabled
+	}
+
+	view, err := a.CheckView(view)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := oidc.GenerateState()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	nonce, err := oidc.GenerateState()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	codeVerifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	authURL, err := a.OIDCClient.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	expired := cfg.StateExpired
+	if expired <= 0 {
+		expired = defaultOIDCStateExpired
+	}
+	err = a.OIDCStore.Set(ctx, state, &oidc.Session{
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		Extra:        map[string]string{oidcViewKey: view},
+	}, time.Duration(expired)*time.Second)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &schema.LoginOIDCAuthorize{
+		AuthURL: authURL,
+		State:   state,
+	}, nil
+}
+
+// VerifyOIDC OIDC登录验证(授权码换取并校验ID令牌，同步用户信息及角色授权)，返回发起登录时指定的令牌视图
+// 用户需要进行多因素认证时同时返回挑战信息，此时不能直接生成令牌
+func (a *Login) VerifyOIDC(ctx context.Context, params schema.LoginOIDCCallbackParam) (*schema.User, string, *schema.LoginMFAChallenge, error) {
+	if !config.C.OIDC.Enable {
+		return nil, "", nil, ErrOIDCDisabled
+	}
+
+	span := logger.StartSpan(ctx, logger.SetSpanTitle("OIDC登录"), logger.SetSpanFuncName("VerifyOIDC"))
+
+	if params.State == "" {
+		return nil, "", nil, ErrInvalidOIDCState
+	}
+
+	// 无论授权是否成功，state均只能使用一次
+	session, err := a.OIDCStore.Take(ctx, params.State)
+	if err != nil {
+		return nil, "", nil, errors.WithStack(err)
+	} else if session == nil {
+		return nil, "", nil, ErrInvalidOIDCState
+	}
+
+	if params.Error != "" {
+		span.Warnf("身份提供方拒绝授权：%s %s", params.Error, params.ErrorDescription)
+		return nil, "", nil, ErrOIDCAuthorize
+	} else if params.Code == "" {
+		return nil, "", nil, ErrOIDCAuthorize
+	}
+
+	token, err := a.OIDCClient.Exchange(ctx, params.Code, session.CodeVerifier)
+	if err != nil {
+		span.Warnf("授权码换取令牌失败：%s", err.Error())
+		return nil, "", nil, ErrOIDCAuthorize
+	}
+
+	claims, err := a.OIDCClient.VerifyIDToken(ctx, token.IDToken, session.Nonce)
+	if err != nil {
+		span.Warnf("ID令牌校验失败：%s", err.Error())
+		return nil, "", nil, ErrOIDCAuthorize
+	}
+
+	claims = a.mergeOIDCUserInfo(ctx, claims, token.AccessToken)
+
+	identity, err := oidcIdentity(claims)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	span.Infof("用户[%s]通过[%s]认证", identity.User.UserName, authenticator.OIDCName)
+
+	user, err := a.syncExternalUser(ctx, &authenticator.Result{
+		Identity: *identity,
+		Provider: authenticator.OIDCName,
+	})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	view, err := a.CheckView(session.Extra[oidcViewKey])
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	user, challenge, err := a.loginChallenge(ctx, user)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	return user, view, challenge, nil
+}
+
+// ID令牌缺少映射的声明时，从用户信息接口补充(仅当sub一致时)
+func (a *Login) mergeOIDCUserInfo(ctx context.Context, claims oidc.Claims, accessToken string) oidc.Claims {
+	cfg := config.C.OIDC.Claims
+	var missing bool
+	for _, name := range []string{cfg.UserName, cfg.RealName, cfg.Email, cfg.Phone, cfg.Groups} {
+		if _, ok := claims[name]; name != "" && !ok {
+			missing = true
+			break
+		}
+	}
+	if !missing || accessToken == "" {
+		return claims
+	}
+
+	userInfo, err := a.OIDCClient.UserInfo(ctx, accessToken)
+	if err != nil {
+		logger.Warnf(ctx, "获取OIDC用户信息发生错误：%s", err.Error())
+		return claims
+	} else if userInfo == nil || userInfo.String("sub") != claims.String("sub") {
+		return claims
+	}
+
+	for k, v := range userInfo {
+		if _, ok := claims[k]; !ok {
+			claims[k] = v
+		}
+	}
+	return claims
+}
+
+// 将OIDC声明映射为身份信息(未配置用户名声明或声明为空时使用sub)
+// 用户通过sub关联，用户名声明只用于首次登录时创建用户
+func oidcIdentity(claims oidc.Claims) (*authenticator.Identity, error) {
+	cfg := config.C.OIDC.Claims
+
+	subject := claims.String("sub")
+	if subject == "" {
+		return nil, errors.ErrInvalidUserName
+	}
+
+	var userName string
+	if cfg.UserName != "" {
+		userName = claims.String(cfg.UserName)
+	}
+	if userName == "" {
+		userName = subject
+	}
+
+	// 外部用户不能冒用root用户
+	if userName == GetRootUser().UserName {
+		return nil, errors.ErrInvalidUserName
+	}
+
+	user := &schema.User{
+		UserName: userName,
+		RealName: userName,
+	}
+	if cfg.RealName != "" && claims.String(cfg.RealName) != "" {
+		user.RealName = claims.String(cfg.RealName)
+	}
+	if cfg.Email != "" {
+		user.Email = claims.String(cfg.Email)
+	}
+	if cfg.Phone != "" {
+		user.Phone = claims.String(cfg.Phone)
+	}
+
+	identity := &authenticator.Identity{
+		User:    user,
+		Subject: subject,
+	}
+	if cfg.Groups != "" {
+		identity.Groups = claims.Strings(cfg.Groups)
+	}
+	return identity, nil
+}
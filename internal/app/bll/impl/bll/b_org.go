@@ -0,0 +1,259 @@
+package bll
+
+import (
+	"context"
+	"strings"
+
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"github.com/google/wire"
+)
+
+var _ bll.IOrg = (*Org)(nil)
+
+// OrgSet 注入Org
+var OrgSet = wire.NewSet(wire.Struct(new(Org), "*"), wire.Bind(new(bll.IOrg), new(*Org)))
+
+// Org 部门管理
+type Org struct {
+	TransModel model.ITrans
+	OrgModel   model.IOrg
+	UserModel  model.IUser
+}
+
+// Query 查询数据
+func (a *Org) Query(ctx context.Context, params schema.OrgQueryParam, opts ...schema.OrgQueryOptions) (*schema.OrgQueryResult, error) {
+	return a.OrgModel.Query(ctx, params, opts...)
+}
+
+// Get 查询指定数据
+func (a *Org) Get(ctx context.Context, recordID string, opts ...schema.OrgQueryOptions) (*schema.Org, error) {
+	item, err := a.OrgModel.Get(ctx, recordID, opts...)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errors.ErrNotFound
+	}
+
+	return item, nil
+}
+
+// 同一父级下的部门名称不允许重复
+func (a *Org) checkName(ctx context.Context, item schema.Org) error {
+	result, err := a.OrgModel.Query(ctx, schema.OrgQueryParam{
+		PaginationParam: schema.PaginationParam{
+			OnlyCount: true,
+		},
+		ParentID: &item.ParentID,
+		Name:     item.Name,
+	})
+	if err != nil {
+		return err
+	} else if result.PageResult.Total > 0 {
+		return errors.New400Response("部门名称已经存在")
+	}
+	return nil
+}
+
+// Create 创建数据
+func (a *Org) Create(ctx context.Context, item schema.Org) (*schema.RecordIDResult, error) {
+	if err := a.checkName(ctx, item); err != nil {
+		return nil, err
+	}
+
+	parentPath, err := a.getParentPath(ctx, item.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	item.ParentPath = parentPath
+	item.RecordID = util.NewRecordID()
+
+	err = a.OrgModel.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.NewRecordIDResult(item.RecordID), nil
+}
+
+// 获取父级路径
+func (a *Org) getParentPath(ctx context.Context, parentID string) (string, error) {
+	if parentID == "" {
+		return "", nil
+	}
+
+	pitem, err := a.OrgModel.Get(ctx, parentID)
+	if err != nil {
+		return "", err
+	} else if pitem == nil {
+		return "", errors.ErrInvalidParent
+	}
+
+	return a.joinParentPath(pitem.ParentPath, pitem.RecordID), nil
+}
+
+func (a *Org) joinParentPath(parent, id string) string {
+	if parent != "" {
+		return parent + "/" + id
+	}
+	return id
+}
+
+// 获取新的父级路径(不允许移动到自身或下级部门)
+func (a *Org) getMoveParentPath(ctx context.Context, oldItem schema.Org, parentID string) (string, error) {
+	if parentID == oldItem.RecordID {
+		return "", errors.ErrInvalidParent
+	}
+
+	parentPath, err := a.getParentPath(ctx, parentID)
+	if err != nil {
+		return "", err
+	}
+
+	opath := a.joinParentPath(oldItem.ParentPath, oldItem.RecordID)
+	if parentPath == opath || strings.HasPrefix(parentPath, opath+"/") {
+		return "", errors.ErrInvalidParent
+	}
+	return parentPath, nil
+}
+
+// Update 更新数据
+func (a *Org) Update(ctx context.Context, recordID string, item schema.Org) error {
+	oldItem, err := a.OrgModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	} else if oldItem.Name != item.Name || oldItem.ParentID != item.ParentID {
+		if err := a.checkName(ctx, item); err != nil {
+			return err
+		}
+	}
+
+	if oldItem.ParentID != item.ParentID {
+		parentPath, err := a.getMoveParentPath(ctx, *oldItem, item.ParentID)
+		if err != nil {
+			return err
+		}
+		item.ParentPath = parentPath
+	} else {
+		item.ParentPath = oldItem.ParentPath
+	}
+
+	item.RecordID = oldItem.RecordID
+	item.TenantID = oldItem.TenantID
+	item.Creator = oldItem.Creator
+	item.CreatedAt = oldItem.CreatedAt
+	return ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.updateChildParentPath(ctx, *oldItem, item)
+		if err != nil {
+			return err
+		}
+
+		return a.OrgModel.Update(ctx, recordID, item)
+	})
+}
+
+// Move 移动到新的父级部门(同时更新所有下级部门的父级路径)
+func (a *Org) Move(ctx context.Context, recordID, parentID string) error {
+	oldItem, err := a.OrgModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	} else if oldItem.ParentID == parentID {
+		return nil
+	}
+
+	parentPath, err := a.getMoveParentPath(ctx, *oldItem, parentID)
+	if err != nil {
+		return err
+	}
+
+	item := *oldItem
+	item.ParentID = parentID
+	item.ParentPath = parentPath
+	if err := a.checkName(ctx, item); err != nil {
+		return err
+	}
+
+	return ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		err := a.updateChildParentPath(ctx, *oldItem, item)
+		if err != nil {
+			return err
+		}
+
+		return a.OrgModel.Update(ctx, recordID, item)
+	})
+}
+
+// 检查并更新下级节点的父级路径
+func (a *Org) updateChildParentPath(ctx context.Context, oldItem, newItem schema.Org) error {
+	if oldItem.ParentID == newItem.ParentID {
+		return nil
+	}
+
+	opath := a.joinParentPath(oldItem.ParentPath, oldItem.RecordID)
+	result, err := a.OrgModel.Query(NewNoTrans(ctx), schema.OrgQueryParam{
+		PrefixParentPath: opath,
+	})
+	if err != nil {
+		return err
+	}
+
+	npath := a.joinParentPath(newItem.ParentPath, newItem.RecordID)
+	for _, org := range result.Data {
+		err = a.OrgModel.UpdateParentPath(ctx, org.RecordID, npath+org.ParentPath[len(opath):])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Org) Delete(ctx context.Context, recordID string) error {
+	oldItem, err := a.OrgModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	result, err := a.OrgModel.Query(ctx, schema.OrgQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		ParentID:        &recordID,
+	})
+	if err != nil {
+		return err
+	} else if result.PageResult.Total > 0 {
+		return errors.ErrNotAllowDeleteWithChild
+	}
+
+	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		OrgID:           recordID,
+	})
+	if err != nil {
+		return err
+	} else if userResult.PageResult.Total > 0 {
+		return errors.New400Response("该部门下存在用户，不允许删除")
+	}
+
+	return a.OrgModel.Delete(ctx, recordID)
+}
+
+// UpdateStatus 更新状态
+func (a *Org) UpdateStatus(ctx context.Context, recordID string, status int) error {
+	oldItem, err := a.OrgModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	return a.OrgModel.UpdateStatus(ctx, recordID, status)
+}
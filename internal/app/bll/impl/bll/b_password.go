@@ -0,0 +1,144 @@
+package bll
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/password"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+// PasswordPolicySet 注入PasswordPolicy
+var PasswordPolicySet = wire.NewSet(wire.Struct(new(PasswordPolicy), "*"))
+
+// PasswordPolicy 密码策略(复杂度、历史密码及有效期)
+type PasswordPolicy struct {
+	Policy               *password.Policy
+	PasswordManager      *password.Manager
+	PasswordHistoryModel model.IPasswordHistory
+}
+
+// 将不符合复杂度策略的错误转换为响应错误
+func passwordPolicyError(err error) error {
+	cfg := config.C.Password
+	switch err {
+	case password.ErrTooShort:
+		return errors.New400Response(fmt.Sprintf("密码长度不能少于%d位", cfg.MinLength))
+	case password.ErrMissingUpper:
+		return errors.New400Response("密码必须包含大写字母")
+	case password.ErrMissingLower:
+		return errors.New400Response("密码必须包含小写字母")
+	case password.ErrMissingDigit:
+		return errors.New400Response("密码必须包含数字")
+	case password.ErrMissingSymbol:
+		return errors.New400Response("密码必须包含特殊字符")
+	case password.ErrBannedWord:
+		return errors.New400Response("密码包含不允许使用的词")
+	case password.ErrDigest:
+		return errors.New400Response("请提交明文密码，不能提交密码的摘要")
+	}
+	return err
+}
+
+// Hash 校验新密码是否符合策略，并计算哈希值
+// 指定用户ID时，新密码不能与当前密码及最近使用过的密码相同
+func (a *PasswordPolicy) Hash(ctx context.Context, userID, current, newPassword string) (string, error) {
+	if err := a.Policy.Validate(newPassword); err != nil {
+		return "", passwordPolicyError(err)
+	}
+
+	if n := config.C.Password.HistoryCount; userID != "" && n > 0 {
+		encodeds := []string{current}
+		result, err := a.PasswordHistoryModel.Query(ctx, schema.PasswordHistoryQueryParam{
+			UserID: userID,
+		})
+		if err != nil {
+			return "", err
+		}
+		for i, item := range result.Data {
+			if i >= n {
+				break
+			}
+			encodeds = append(encodeds, item.Password)
+		}
+
+		for _, encoded := range encodeds {
+			if encoded == "" {
+				continue
+			}
+			// 同时比较客户端提交MD5摘要时保存的密码
+			for _, s := range []string{newPassword, util.MD5HashString(newPassword)} {
+				if ok, _, _ := a.PasswordManager.Verify(encoded, s); ok {
+					return "", errors.New400Response(fmt.Sprintf("不能使用最近%d次使用过的密码", n))
+				}
+			}
+		}
+	}
+
+	encoded, err := a.PasswordManager.Hash(newPassword)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return encoded, nil
+}
+
+// Save 记录用户的历史密码(仅保留策略要求的数量)
+func (a *PasswordPolicy) Save(ctx context.Context, userID, encoded string) error {
+	n := config.C.Password.HistoryCount
+	if n <= 0 {
+		return nil
+	}
+
+	err := a.PasswordHistoryModel.Create(ctx, schema.PasswordHistory{
+		RecordID:  util.NewRecordID(),
+		UserID:    userID,
+		Password:  encoded,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := a.PasswordHistoryModel.Query(ctx, schema.PasswordHistoryQueryParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	for i := n; i < len(result.Data); i++ {
+		err := a.PasswordHistoryModel.Delete(ctx, result.Data[i].RecordID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsExpired 用户密码是否已过期(未记录修改时间时以创建时间计算)
+func (a *PasswordPolicy) IsExpired(user *schema.User) bool {
+	days := config.C.Password.ExpireDays
+	if days <= 0 {
+		return false
+	}
+
+	changedAt := user.CreatedAt
+	if user.PasswordChangedAt != nil {
+		changedAt = *user.PasswordChangedAt
+	}
+	return changedAt.AddDate(0, 0, days).Before(time.Now())
+}
+
+// MustChange 用户登录后是否必须先修改密码(被要求修改或密码已过期)
+// 外部认证用户及服务账号没有本地密码，不受限制
+func (a *PasswordPolicy) MustChange(user *schema.User) bool {
+	if user.Password == "" || user.IsService() {
+		return false
+	}
+	return user.MustChangePassword == 1 || a.IsExpired(user)
+}
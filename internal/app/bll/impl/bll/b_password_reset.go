@@ -0,0 +1,186 @@
+package bll
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/wangwei518/gin-admin/pkg/mail"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+// 定义错误
+var (
+	ErrPasswordResetDisabled = errors.New400Response("未启用找回密码")
+	ErrInvalidResetToken     = errors.New400Response("重置链接无效或已过期")
+)
+
+// 默认的重置链接有效期
+const defaultPasswordResetExpired = 1800
+
+// 计算重置令牌的哈希值(令牌为高熵随机串，使用SHA-256即可)
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// 生成重置令牌
+func newResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// ForgotPassword 找回密码(向用户邮箱发送一次性的重置链接)
+// 用户不存在、已停用、未设置邮箱或不是本地用户时不发送邮件，但同样返回成功，避免泄露用户是否存在
+func (a *Login) ForgotPassword(ctx context.Context, params schema.ForgotPasswordParam) error {
+	cfg := config.C.PasswordReset
+	if !cfg.Enable {
+		return ErrPasswordResetDisabled
+	}
+
+	result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		UserName: params.UserName,
+	})
+	if err != nil {
+		return err
+	} else if len(result.Data) == 0 {
+		return nil
+	}
+
+	user := result.Data[0]
+	if user.Status != 1 || user.Email == "" || user.Password == "" || user.IsService() {
+		return nil
+	}
+
+	token, err := newResetToken()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	expired := cfg.Expired
+	if expired <= 0 {
+		expired = defaultPasswordResetExpired
+	}
+
+	now := time.Now()
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		// 只保留最近一次发送的重置链接
+		err := a.PasswordResetModel.DeleteByUserID(ctx, user.RecordID)
+		if err != nil {
+			return err
+		}
+
+		return a.PasswordResetModel.Create(ctx, schema.PasswordReset{
+			RecordID:  util.NewRecordID(),
+			UserID:    user.RecordID,
+			TokenHash: hashResetToken(token),
+			ExpiresAt: now.Add(time.Duration(expired) * time.Second),
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = a.MailSender.Send(ctx, &mail.Message{
+		From:    config.C.Mail.From,
+		To:      []string{user.Email},
+		Subject: cfg.Subject,
+		Body: fmt.Sprintf("%s，您好：\n\n请在%d分钟内打开以下链接重置密码，链接只能使用一次：\n%s\n\n如果不是您本人的操作，请忽略此邮件。\n",
+			user.RealName, (expired+59)/60, fmt.Sprintf(cfg.URL, token)),
+	})
+	if err != nil {
+		// 发送失败时同样返回成功，避免通过响应判断用户是否存在
+		logger.StartSpan(ctx, logger.SetSpanTitle("找回密码"), logger.SetSpanFuncName("ForgotPassword")).
+			Errorf("发送重置密码邮件发生错误：%s", err.Error())
+	}
+	return nil
+}
+
+// ResetPassword 使用重置令牌设置新密码(新密码需符合密码策略)
+// 重置成功后令牌失效，并撤销用户的全部会话
+func (a *Login) ResetPassword(ctx context.Context, params schema.ResetPasswordParam) error {
+	if !config.C.PasswordReset.Enable {
+		return ErrPasswordResetDisabled
+	}
+
+	result, err := a.PasswordResetModel.Query(ctx, schema.PasswordResetQueryParam{
+		TokenHash: hashResetToken(params.Token),
+	})
+	if err != nil {
+		return err
+	} else if len(result.Data) == 0 {
+		return ErrInvalidResetToken
+	}
+
+	item := result.Data[0]
+	if item.IsExpired() {
+		err := a.PasswordResetModel.Delete(ctx, item.RecordID)
+		if err != nil {
+			return err
+		}
+		return ErrInvalidResetToken
+	}
+
+	user, err := a.checkAndGetUser(ctx, item.UserID)
+	if err != nil {
+		if err == errors.ErrInvalidUser || err == errors.ErrUserDisable {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	// 新密码不符合策略时令牌仍然有效，用户可以重新提交
+	encoded, err := a.PasswordPolicy.Hash(ctx, user.RecordID, user.Password, params.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+		// 先删除令牌再修改密码，并发提交同一个令牌时只有一个请求可以删除成功
+		ok, err := a.PasswordResetModel.DeleteByTokenHash(ctx, item.TokenHash)
+		if err != nil {
+			return err
+		} else if !ok {
+			return ErrInvalidResetToken
+		}
+
+		err = a.PasswordResetModel.DeleteByUserID(ctx, user.RecordID)
+		if err != nil {
+			return err
+		}
+
+		err = a.PasswordPolicy.Save(ctx, user.RecordID, encoded)
+		if err != nil {
+			return err
+		}
+
+		return a.UserModel.ChangePassword(ctx, user.RecordID, schema.UserPassword{
+			Password:   encoded,
+			ChangedAt:  time.Now(),
+			MustChange: 2,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// 密码重置后撤销用户的全部会话，并清除用户名的登录失败次数
+	err = a.Auth.RevokeSessions(ctx, user.RecordID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	a.succeedLogin(ctx, user.UserName)
+	return nil
+}
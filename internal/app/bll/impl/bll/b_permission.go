@@ -0,0 +1,462 @@
+package bll
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/casbin/casbin/v2/util"
+	"github.com/google/wire"
+)
+
+var _ bll.IPermission = (*Permission)(nil)
+
+// PermissionSet 注入Permission
+var PermissionSet = wire.NewSet(wire.Struct(new(Permission), "*"), wire.Bind(new(bll.IPermission), new(*Permission)))
+
+// 拒绝访问时返回的最大候选动作数
+const maxPermissionCandidates = 5
+
+// Permission 权限诊断(访问结果由casbin决定，基于角色、菜单动作及资源数据解释原因)
+type Permission struct {
+	CasbinPolicy            *CasbinPolicy
+	UserModel               model.IUser
+	UserRoleModel           model.IUserRole
+	RoleModel               model.IRole
+	RoleMenuModel           model.IRoleMenu
+	MenuModel               model.IMenu
+	MenuActionModel         model.IMenuAction
+	MenuActionResourceModel model.IMenuActionResource
+}
+
+// 用户的权限数据
+type permissionData struct {
+	root      bool                     // 是否超级管理员
+	reason    string                   // 用户未获得任何授权的原因
+	grants    schema.PermissionMatches // 用户已授权的资源(包含授权链)
+	resources schema.PermissionMatches // 全部菜单动作资源
+}
+
+// 权限匹配结果
+type permissionMatchResult struct {
+	path   bool // 请求路径是否匹配
+	method bool // 请求方法是否匹配
+	view   bool // 令牌视图是否匹配
+	cond   bool // 访问条件是否满足
+}
+
+func (r permissionMatchResult) ok() bool {
+	return r.path && r.method && r.view && r.cond
+}
+
+// 按照casbin规则匹配资源(keyMatch2、regexMatch、视图及访问条件)
+func matchPermission(item *schema.PermissionMatch, method, path, view string, env *abac.Env) (permissionMatchResult, error) {
+	var r permissionMatchResult
+	r.path = util.KeyMatch2(path, item.Path)
+	// 资源的请求方式为无效的正则时视为不匹配，避免诊断时出现panic
+	r.method, _ = regexp.MatchString(item.Method, method)
+	r.view = item.View == "*" || item.View == view
+	c, err := abac.Decode(abac.Encode(item.IPRanges, item.TimeWindow, item.Attributes))
+	if err != nil {
+		return r, errors.Wrapf(err, "资源(%s %s)的访问条件无效", item.Method, item.Path)
+	}
+	r.cond = c.Match(env)
+	return r, nil
+}
+
+// 格式化资源的访问条件
+func formatCondition(item *schema.PermissionMatch) string {
+	var conds []string
+	if item.IPRanges != "" {
+		conds = append(conds, "IP范围为"+item.IPRanges)
+	}
+	if item.TimeWindow != "" {
+		conds = append(conds, "时间窗口为"+item.TimeWindow)
+	}
+	if item.Attributes != "" {
+		conds = append(conds, "请求属性为"+item.Attributes)
+	}
+	return strings.Join(conds, "，")
+}
+
+// 计算请求路径与资源路径从头开始相同的段数(n包含资源路径中的参数段，literal仅包含完全相同的段)
+func matchPathSegments(path, pattern string) (n, literal, total int) {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	psegs := strings.Split(strings.Trim(pattern, "/"), "/")
+
+	total = len(segs)
+	for i := 0; i < len(segs) && i < len(psegs); i++ {
+		if segs[i] == psegs[i] {
+			literal++
+		} else if !strings.HasPrefix(psegs[i], ":") && !strings.HasPrefix(psegs[i], "*") {
+			break
+		}
+		n++
+	}
+	return
+}
+
+// 加载用户的权限数据
+func (a *Permission) load(ctx context.Context, userID string) (*permissionData, error) {
+	data := new(permissionData)
+	if CheckIsRootUser(ctx, userID) {
+		data.root = true
+		return data, nil
+	}
+
+	user, err := a.UserModel.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, errors.ErrNotFound
+	}
+
+	catalog, err := a.loadCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data.resources = catalog.resources
+
+	if user.Status != 1 {
+		data.reason = "用户已停用"
+		return data, nil
+	}
+
+	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(userRoleResult.Data) == 0 {
+		data.reason = "用户未分配角色"
+		return data, nil
+	}
+
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	mRoles := roleResult.Data.ToMap()
+
+	var roleIDs []string
+	for _, item := range userRoleResult.Data {
+		if _, ok := mRoles[item.RoleID]; ok {
+			roleIDs = append(roleIDs, item.RoleID)
+		}
+	}
+	if len(roleIDs) == 0 {
+		data.reason = "用户的角色均已停用"
+		return data, nil
+	}
+
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
+		RoleIDs: roleResult.Data.ExpandInheritIDs(roleIDs),
+	})
+	if err != nil {
+		return nil, err
+	}
+	mRoleMenus := roleMenuResult.Data.ToRoleIDMap()
+
+	mResources := make(map[string]schema.PermissionMatches)
+	for _, item := range data.resources {
+		mResources[item.ActionID] = append(mResources[item.ActionID], item)
+	}
+
+	// 按照用户直接拥有的角色展开授权链(包含继承的上级角色)
+	for _, userRoleID := range roleIDs {
+		userRole := mRoles[userRoleID]
+		for _, roleID := range roleResult.Data.ExpandInheritIDs([]string{userRoleID}) {
+			role := mRoles[roleID]
+			for _, actionID := range mRoleMenus[roleID].ToActionIDs() {
+				for _, item := range mResources[actionID] {
+					grant := *item
+					grant.UserRoleID = userRole.RecordID
+					grant.UserRoleName = userRole.Name
+					grant.RoleID = role.RecordID
+					grant.RoleName = role.Name
+					data.grants = append(data.grants, &grant)
+				}
+			}
+		}
+	}
+
+	if len(data.grants) == 0 {
+		data.reason = "用户的角色未授权任何资源"
+	}
+	return data, nil
+}
+
+// 菜单动作及资源目录
+type permissionCatalog struct {
+	menus     map[string]*schema.Menu             // 全部菜单
+	actions   map[string]*schema.PermissionAction // 全部菜单动作(包含资源)
+	resources schema.PermissionMatches            // 全部资源(包含菜单及动作信息)
+}
+
+// 加载全部菜单、动作及资源
+func (a *Permission) loadCatalog(ctx context.Context) (*permissionCatalog, error) {
+	menuResult, err := a.MenuModel.Query(ctx, schema.MenuQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	actionResult, err := a.MenuActionModel.Query(ctx, schema.MenuActionQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	resourceResult, err := a.MenuActionResourceModel.Query(ctx, schema.MenuActionResourceQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &permissionCatalog{
+		menus:   menuResult.Data.ToMap(),
+		actions: make(map[string]*schema.PermissionAction),
+	}
+	for _, item := range actionResult.Data {
+		menu, ok := catalog.menus[item.MenuID]
+		if !ok {
+			continue
+		}
+		catalog.actions[item.RecordID] = &schema.PermissionAction{
+			MenuID:     menu.RecordID,
+			MenuName:   menu.Name,
+			ActionID:   item.RecordID,
+			ActionCode: item.Code,
+			ActionName: item.Name,
+		}
+	}
+
+	for _, item := range resourceResult.Data {
+		if item.Path == "" || item.Method == "" {
+			continue
+		}
+
+		action, ok := catalog.actions[item.ActionID]
+		if !ok {
+			continue
+		}
+
+		view := item.View
+		if view == "" {
+			view = "*"
+		}
+		action.Resources = append(action.Resources, &schema.PermissionResource{
+			Method:     item.Method,
+			Path:       item.Path,
+			View:       view,
+			Effect:     item.GetEffect(),
+			IPRanges:   item.IPRanges,
+			TimeWindow: item.TimeWindow,
+			Attributes: item.Attributes,
+		})
+		catalog.resources = append(catalog.resources, &schema.PermissionMatch{
+			MenuID:     action.MenuID,
+			MenuName:   action.MenuName,
+			ActionID:   action.ActionID,
+			ActionCode: action.ActionCode,
+			ActionName: action.ActionName,
+			ResourceID: item.RecordID,
+			Method:     item.Method,
+			Path:       item.Path,
+			View:       view,
+			Effect:     item.GetEffect(),
+			IPRanges:   item.IPRanges,
+			TimeWindow: item.TimeWindow,
+			Attributes: item.Attributes,
+		})
+	}
+	return catalog, nil
+}
+
+// 查找已授权并与请求匹配的资源(按照授权效果区分允许及拒绝)
+func (a *Permission) match(data *permissionData, method, path, view string, env *abac.Env) (allows, denies schema.PermissionMatches, err error) {
+	for _, item := range data.grants {
+		r, err := matchPermission(item, method, path, view, env)
+		if err != nil {
+			return nil, nil, err
+		} else if !r.ok() {
+			continue
+		}
+
+		if item.Effect == schema.ResourceEffectDeny {
+			denies = append(denies, item)
+		} else {
+			allows = append(allows, item)
+		}
+	}
+	return
+}
+
+// 查找与请求最接近的候选动作(按照匹配程度排序)
+func (a *Permission) findCandidates(data *permissionData, method, path, view string, env *abac.Env) (schema.PermissionMatches, error) {
+	type candidate struct {
+		item  *schema.PermissionMatch
+		score int
+		segs  int
+	}
+
+	var list []*candidate
+	for _, res := range data.resources {
+		// 拒绝规则不能使请求被允许，不作为候选
+		if res.Effect == schema.ResourceEffectDeny {
+			continue
+		}
+		r, err := matchPermission(res, method, path, view, env)
+		if err != nil {
+			return nil, err
+		}
+
+		item := *res
+		n, literal, total := matchPathSegments(path, item.Path)
+		c := &candidate{item: &item, segs: literal}
+		switch {
+		case r.ok():
+			c.score = 3
+			c.item.Hint = "用户未被授权该动作"
+		case r.path && r.method && r.view:
+			c.score = 3
+			c.item.Hint = fmt.Sprintf("访问条件不满足(%s)", formatCondition(&item))
+		case r.path && r.method:
+			c.score = 2
+			c.item.Hint = fmt.Sprintf("令牌视图不匹配(资源允许的视图为%s)", item.View)
+		case r.path:
+			c.score = 1
+			c.item.Hint = fmt.Sprintf("请求方法不匹配(资源的请求方式为%s)", item.Method)
+		default:
+			// 至少需要除最后一段外的路径均相同，且不能仅是公共前缀(如/api/v1)
+			if n < total-1 || n < 3 {
+				continue
+			}
+			c.item.Hint = "请求路径相近"
+		}
+		list = append(list, c)
+	}
+
+	// 匹配程度相同时，路径越具体越靠前
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		return list[i].segs > list[j].segs
+	})
+
+	var result schema.PermissionMatches
+	for _, c := range list {
+		if len(result) == maxPermissionCandidates {
+			break
+		}
+		result = append(result, c.item)
+	}
+	return result, nil
+}
+
+// Explain 解释用户在当前租户下对指定请求的访问权限
+func (a *Permission) Explain(ctx context.Context, params schema.PermissionExplainParam) (*schema.PermissionExplain, error) {
+	if params.View == "" {
+		params.View = schema.ViewAdmin
+	}
+	if params.Time.IsZero() {
+		params.Time = time.Now()
+	}
+
+	// 权限数据按照校验使用的租户加载，未指定租户时为默认租户
+	tenantID, _ := icontext.FromTenantID(ctx)
+	ctx = icontext.NewTenantID(ctx, tenantID)
+
+	data, err := a.load(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	env := abac.NewEnv(params.IP, params.Time, params.Attributes)
+	allowed, err := a.CasbinPolicy.Enforce(params.UserID, tenantID, params.Path, params.Method, params.View, env)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &schema.PermissionExplain{
+		UserID:  params.UserID,
+		Method:  params.Method,
+		Path:    params.Path,
+		View:    params.View,
+		IP:      params.IP,
+		Time:    params.Time,
+		Allowed: allowed,
+	}
+	if !a.CasbinPolicy.Enabled() {
+		result.Reason = "未启用权限校验，全部请求均被允许"
+		return result, nil
+	} else if data.root && allowed {
+		result.Reason = "超级管理员拥有全部权限"
+		return result, nil
+	}
+
+	allows, denies, err := a.match(data, params.Method, params.Path, params.View, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(denies) > 0:
+		result.Matches = denies
+		result.Reason = "用户的角色命中了拒绝规则(拒绝优先于允许)"
+	case len(allows) > 0:
+		result.Matches = allows
+		result.Reason = "用户的角色已授权匹配的菜单动作"
+	default:
+		result.Reason = data.reason
+		if result.Reason == "" {
+			result.Reason = "用户的角色未授权与请求匹配的菜单动作"
+		}
+		result.Candidates, err = a.findCandidates(data, params.Method, params.Path, params.View, env)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 业务数据的计算结果与策略不一致时(如策略尚未同步)，以策略的结果为准
+	if allowed != (len(allows) > 0 && len(denies) == 0) {
+		result.Reason = fmt.Sprintf("权限策略与角色数据不一致(策略可能尚未同步)，按角色数据：%s", result.Reason)
+	}
+	return result, nil
+}
+
+// Check 批量检查用户在当前租户下对请求的访问权限
+func (a *Permission) Check(ctx context.Context, params schema.PermissionCheckParam) (schema.PermissionCheckItems, error) {
+	if params.View == "" {
+		params.View = schema.ViewAdmin
+	}
+
+	tenantID, _ := icontext.FromTenantID(ctx)
+	if !CheckIsRootUser(ctx, params.UserID) {
+		user, err := a.UserModel.Get(icontext.NewTenantID(ctx, tenantID), params.UserID)
+		if err != nil {
+			return nil, err
+		} else if user == nil {
+			return nil, errors.ErrNotFound
+		}
+	}
+
+	env := abac.NewEnv(params.IP, time.Now(), params.Attributes)
+	for _, item := range params.Items {
+		allowed, err := a.CasbinPolicy.Enforce(params.UserID, tenantID, item.Path, item.Method, params.View, env)
+		if err != nil {
+			return nil, err
+		}
+		item.Allowed = allowed
+	}
+	return params.Items, nil
+}
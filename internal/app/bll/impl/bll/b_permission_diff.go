@@ -0,0 +1,325 @@
+package bll
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+)
+
+// 权限计算的数据快照(用于模拟变更前后的权限)
+type permissionSnapshot struct {
+	roles     schema.Roles                // 全部角色
+	roleMenus map[string]schema.RoleMenus // 角色ID与角色菜单的映射
+	users     map[string]*schema.User     // 用户ID与用户的映射
+	userRoles map[string]schema.UserRoles // 用户ID与用户角色的映射
+}
+
+// 计算用户已授权的菜单动作(与casbin规则一致，仅包含启用的用户及角色)
+func (s *permissionSnapshot) userActionIDs(userID string) map[string]struct{} {
+	m := make(map[string]struct{})
+	user, ok := s.users[userID]
+	if !ok || user.Status != 1 {
+		return m
+	}
+
+	var roles schema.Roles
+	for _, item := range s.roles {
+		if item.Status == 1 {
+			roles = append(roles, item)
+		}
+	}
+	mRoles := roles.ToMap()
+
+	var roleIDs []string
+	for _, item := range s.userRoles[userID] {
+		if _, ok := mRoles[item.RoleID]; ok {
+			roleIDs = append(roleIDs, item.RoleID)
+		}
+	}
+
+	for _, roleID := range roles.ExpandInheritIDs(roleIDs) {
+		for _, item := range s.roleMenus[roleID] {
+			m[item.ActionID] = struct{}{}
+		}
+	}
+	return m
+}
+
+// 计算在a中而不在b中的菜单动作
+func (c *permissionCatalog) diffActions(a, b map[string]struct{}) schema.PermissionActions {
+	var list schema.PermissionActions
+	for id := range a {
+		if _, ok := b[id]; ok {
+			continue
+		}
+		if item, ok := c.actions[id]; ok {
+			list = append(list, item)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].MenuName != list[j].MenuName {
+			return list[i].MenuName < list[j].MenuName
+		}
+		return list[i].ActionCode < list[j].ActionCode
+	})
+	return list
+}
+
+// 获取菜单动作所属的菜单(仅包含启用的菜单)
+func (c *permissionCatalog) toMenuIDs(actionIDs map[string]struct{}) map[string]struct{} {
+	m := make(map[string]struct{})
+	for id := range actionIDs {
+		action, ok := c.actions[id]
+		if !ok {
+			continue
+		}
+		if menu, ok := c.menus[action.MenuID]; ok && menu.Status == 1 {
+			m[menu.RecordID] = struct{}{}
+		}
+	}
+	return m
+}
+
+// 计算在a中而不在b中的菜单
+func (c *permissionCatalog) diffMenus(a, b map[string]struct{}) schema.PermissionMenus {
+	ma, mb := c.toMenuIDs(a), c.toMenuIDs(b)
+
+	var list schema.PermissionMenus
+	for id := range ma {
+		if _, ok := mb[id]; ok {
+			continue
+		}
+		list = append(list, &schema.PermissionMenu{
+			MenuID:   id,
+			MenuName: c.menus[id].Name,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].MenuName < list[j].MenuName
+	})
+	return list
+}
+
+// 获取菜单动作的资源(相同的资源只保留一项)
+func (c *permissionCatalog) toResources(actionIDs map[string]struct{}) map[string]*schema.PermissionResource {
+	m := make(map[string]*schema.PermissionResource)
+	for id := range actionIDs {
+		action, ok := c.actions[id]
+		if !ok {
+			continue
+		}
+		for _, item := range action.Resources {
+			m[fmt.Sprintf("%s,%s,%s,%s,%s", item.Path, item.Method, item.View, item.Effect,
+				abac.Encode(item.IPRanges, item.TimeWindow, item.Attributes))] = item
+		}
+	}
+	return m
+}
+
+// 计算在a中而不在b中的资源
+func (c *permissionCatalog) diffResources(a, b map[string]struct{}) schema.PermissionResources {
+	ma, mb := c.toResources(a), c.toResources(b)
+
+	var list schema.PermissionResources
+	for key, item := range ma {
+		if _, ok := mb[key]; ok {
+			continue
+		}
+		list = append(list, item)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Path != list[j].Path {
+			return list[i].Path < list[j].Path
+		}
+		if list[i].Method != list[j].Method {
+			return list[i].Method < list[j].Method
+		}
+		if list[i].View != list[j].View {
+			return list[i].View < list[j].View
+		}
+		return list[i].Effect < list[j].Effect
+	})
+	return list
+}
+
+// 对比变更前后的快照，计算用户的权限变更
+func (a *Permission) diff(ctx context.Context, before, after *permissionSnapshot, userIDs []string) (*schema.PermissionDiff, error) {
+	catalog, err := a.loadCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &schema.PermissionDiff{
+		Users:   schema.PermissionUserDiffs{},
+		Actions: schema.PermissionActionDiffs{},
+	}
+	mActionDiffs := make(map[string]*schema.PermissionActionDiff)
+	getActionDiff := func(item *schema.PermissionAction) *schema.PermissionActionDiff {
+		if v, ok := mActionDiffs[item.ActionID]; ok {
+			return v
+		}
+		v := &schema.PermissionActionDiff{PermissionAction: *item}
+		mActionDiffs[item.ActionID] = v
+		result.Actions = append(result.Actions, v)
+		return v
+	}
+
+	for _, userID := range userIDs {
+		user, ok := after.users[userID]
+		if !ok {
+			user, ok = before.users[userID]
+			if !ok {
+				continue
+			}
+		}
+
+		oldActionIDs := before.userActionIDs(userID)
+		newActionIDs := after.userActionIDs(userID)
+		item := &schema.PermissionUserDiff{
+			UserID:          user.RecordID,
+			UserName:        user.UserName,
+			RealName:        user.RealName,
+			GainedMenus:     catalog.diffMenus(newActionIDs, oldActionIDs),
+			LostMenus:       catalog.diffMenus(oldActionIDs, newActionIDs),
+			GainedActions:   catalog.diffActions(newActionIDs, oldActionIDs),
+			LostActions:     catalog.diffActions(oldActionIDs, newActionIDs),
+			GainedResources: catalog.diffResources(newActionIDs, oldActionIDs),
+			LostResources:   catalog.diffResources(oldActionIDs, newActionIDs),
+		}
+		if len(item.GainedActions) == 0 && len(item.LostActions) == 0 {
+			continue
+		}
+		result.Users = append(result.Users, item)
+
+		pu := &schema.PermissionUser{
+			UserID:   user.RecordID,
+			UserName: user.UserName,
+			RealName: user.RealName,
+		}
+		for _, action := range item.GainedActions {
+			v := getActionDiff(action)
+			v.GainedUsers = append(v.GainedUsers, pu)
+		}
+		for _, action := range item.LostActions {
+			v := getActionDiff(action)
+			v.LostUsers = append(v.LostUsers, pu)
+		}
+	}
+
+	sort.Slice(result.Users, func(i, j int) bool {
+		return result.Users[i].UserName < result.Users[j].UserName
+	})
+	sort.Slice(result.Actions, func(i, j int) bool {
+		if result.Actions[i].MenuName != result.Actions[j].MenuName {
+			return result.Actions[i].MenuName < result.Actions[j].MenuName
+		}
+		return result.Actions[i].ActionCode < result.Actions[j].ActionCode
+	})
+	return result, nil
+}
+
+// 加载全部角色及角色菜单
+func (a *Permission) loadRoleSnapshot(ctx context.Context) (*permissionSnapshot, error) {
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &permissionSnapshot{
+		roles:     roleResult.Data,
+		roleMenus: roleMenuResult.Data.ToRoleIDMap(),
+		users:     make(map[string]*schema.User),
+		userRoles: make(map[string]schema.UserRoles),
+	}, nil
+}
+
+// SimulateRole 模拟角色的变更(不保存)，计算受影响用户的权限变更
+func (a *Permission) SimulateRole(ctx context.Context, item schema.Role) (*schema.PermissionDiff, error) {
+	before, err := a.loadRoleSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	after := &permissionSnapshot{
+		roleMenus: make(map[string]schema.RoleMenus),
+		users:     before.users,
+		userRoles: before.userRoles,
+	}
+	for _, ritem := range before.roles {
+		if ritem.RecordID == item.RecordID {
+			ritem = &item
+		}
+		after.roles = append(after.roles, ritem)
+	}
+	for roleID, rms := range before.roleMenus {
+		after.roleMenus[roleID] = rms
+	}
+	after.roleMenus[item.RecordID] = item.RoleMenus
+
+	// 受影响的角色：变更前或变更后的继承链中包含当前角色
+	var roleIDs []string
+	for _, ritem := range before.roles {
+		ids := append(before.roles.ExpandInheritIDs([]string{ritem.RecordID}),
+			after.roles.ExpandInheritIDs([]string{ritem.RecordID})...)
+		for _, id := range ids {
+			if id == item.RecordID {
+				roleIDs = append(roleIDs, ritem.RecordID)
+				break
+			}
+		}
+	}
+	if len(roleIDs) == 0 {
+		return a.diff(ctx, before, after, nil)
+	}
+
+	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		RoleIDs: roleIDs,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(userResult.Data) == 0 {
+		return a.diff(ctx, before, after, nil)
+	}
+
+	userIDs := userResult.Data.ToRecordIDs()
+	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserIDs: userIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, uitem := range userResult.Data {
+		before.users[uitem.RecordID] = uitem
+	}
+	for userID, urs := range userRoleResult.Data.ToUserIDMap() {
+		before.userRoles[userID] = urs
+	}
+	return a.diff(ctx, before, after, userIDs)
+}
+
+// SimulateUser 模拟用户角色的变更(不保存)，计算用户的权限变更
+func (a *Permission) SimulateUser(ctx context.Context, oldItem, item schema.User) (*schema.PermissionDiff, error) {
+	before, err := a.loadRoleSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	before.users[oldItem.RecordID] = &oldItem
+	before.userRoles[oldItem.RecordID] = oldItem.UserRoles
+
+	after := &permissionSnapshot{
+		roles:     before.roles,
+		roleMenus: before.roleMenus,
+		users:     map[string]*schema.User{item.RecordID: &item},
+		userRoles: map[string]schema.UserRoles{item.RecordID: item.UserRoles},
+	}
+	return a.diff(ctx, before, after, []string{item.RecordID})
+}
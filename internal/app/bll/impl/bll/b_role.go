@@ -2,28 +2,33 @@ package bll
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/wangwei518/gin-admin/internal/app/bll"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
 	"github.com/wangwei518/gin-admin/internal/app/model"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"github.com/wangwei518/gin-admin/pkg/errors"
 	"github.com/wangwei518/gin-admin/pkg/util"
-	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
 )
 
 var _ bll.IRole = (*Role)(nil)
 
+// 角色继承的最大层级(casbin默认的角色管理器最多支持10级关联，用户与角色的关联占用1级)
+const maxRoleInheritLevel = 8
+
 // RoleSet 注入Role
 var RoleSet = wire.NewSet(wire.Struct(new(Role), "*"), wire.Bind(new(bll.IRole), new(*Role)))
 
 // Role 角色管理
 type Role struct {
-	Enforcer      *casbin.SyncedEnforcer
+	CasbinPolicy  *CasbinPolicy
 	TransModel    model.ITrans
 	RoleModel     model.IRole
 	RoleMenuModel model.IRoleMenu
 	UserModel     model.IUser
+	Permission    *Permission
 }
 
 // Query 查询数据
@@ -46,9 +51,48 @@ func (a *Role) Get(ctx context.Context, recordID string, opts ...schema.RoleQuer
 	}
 	item.RoleMenus = roleMenus
 
+	effectiveRoleMenus, err := a.queryEffectiveRoleMenus(ctx, recordID)
+	if err != nil {
+		return nil, err
+	}
+	item.EffectiveRoleMenus = effectiveRoleMenus
+
 	return item, nil
 }
 
+// 查询角色生效的菜单列表(包含继承的上级角色菜单，相同的菜单动作只保留最近的角色)
+func (a *Role) queryEffectiveRoleMenus(ctx context.Context, roleID string) (schema.RoleMenus, error) {
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		Status: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := roleResult.Data.ExpandInheritIDs([]string{roleID})
+	result, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
+		RoleIDs: roleIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var list schema.RoleMenus
+	mRoleMenus := result.Data.ToRoleIDMap()
+	m := make(map[string]struct{})
+	for _, id := range roleIDs {
+		for _, item := range mRoleMenus[id] {
+			key := item.MenuID + "-" + item.ActionID
+			if _, ok := m[key]; ok {
+				continue
+			}
+			m[key] = struct{}{}
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
+
 // QueryRoleMenus 查询角色菜单列表
 func (a *Role) QueryRoleMenus(ctx context.Context, roleID string) (schema.RoleMenus, error) {
 	result, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
@@ -62,16 +106,32 @@ func (a *Role) QueryRoleMenus(ctx context.Context, roleID string) (schema.RoleMe
 
 // Create 创建数据
 func (a *Role) Create(ctx context.Context, item schema.Role) (*schema.RecordIDResult, error) {
+	// 在租户中创建的角色属于当前租户，root用户未切换租户时可以指定角色所属的租户
+	if tenantID, ok := icontext.FromTenantID(ctx); ok {
+		item.TenantID = tenantID
+	}
+
 	err := a.checkName(ctx, item)
 	if err != nil {
 		return nil, err
 	}
 
+	err = a.checkDataScope(&item)
+	if err != nil {
+		return nil, err
+	}
+
 	item.RecordID = util.NewRecordID()
+	err = a.checkParent(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
 	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
 		for _, rmItem := range item.RoleMenus {
 			rmItem.RecordID = util.NewRecordID()
 			rmItem.RoleID = item.RecordID
+			rmItem.TenantID = item.TenantID
 			err := a.RoleMenuModel.Create(ctx, *rmItem)
 			if err != nil {
 				return err
@@ -82,12 +142,13 @@ func (a *Role) Create(ctx context.Context, item schema.Role) (*schema.RecordIDRe
 	if err != nil {
 		return nil, err
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.CasbinPolicy.SyncRoles(ctx, item.RecordID)
 	return schema.NewRecordIDResult(item.RecordID), nil
 }
 
+// 角色名称在租户中唯一
 func (a *Role) checkName(ctx context.Context, item schema.Role) error {
-	result, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+	result, err := a.RoleModel.Query(icontext.NewTenantID(ctx, item.TenantID), schema.RoleQueryParam{
 		PaginationParam: schema.PaginationParam{OnlyCount: true},
 		Name:            item.Name,
 	})
@@ -99,28 +160,135 @@ func (a *Role) checkName(ctx context.Context, item schema.Role) error {
 	return nil
 }
 
-// Update 更新数据
-func (a *Role) Update(ctx context.Context, recordID string, item schema.Role) error {
-	oldItem, err := a.Get(ctx, recordID)
+// 检查数据范围(未指定时为全部数据)
+func (a *Role) checkDataScope(item *schema.Role) error {
+	switch item.DataScope {
+	case 0:
+		item.DataScope = schema.DataScopeAll
+	case schema.DataScopeCustom:
+		if len(item.DataOrgIDs) == 0 {
+			return errors.New400Response("自定义数据范围需要指定部门")
+		}
+	}
+
+	if item.DataScope != schema.DataScopeCustom {
+		item.DataOrgIDs = nil
+	}
+	return nil
+}
+
+// 检查上级角色(上级角色必须存在，继承关系不能形成循环，且不能超过最大继承层级)
+func (a *Role) checkParent(ctx context.Context, item schema.Role) error {
+	if item.ParentID == "" {
+		if len(item.RoleMenus) == 0 {
+			return errors.New400Response("未指定上级角色时需要授权菜单")
+		}
+		return nil
+	} else if item.ParentID == item.RecordID {
+		return errors.ErrInvalidParent
+	}
+
+	// 只能继承同一租户中的角色
+	result, err := a.RoleModel.Query(icontext.NewTenantID(ctx, item.TenantID), schema.RoleQueryParam{})
 	if err != nil {
 		return err
+	}
+
+	mParents := make(map[string]string)
+	mChildren := make(map[string][]string)
+	for _, ritem := range result.Data {
+		if ritem.RecordID == item.RecordID {
+			continue
+		}
+		mParents[ritem.RecordID] = ritem.ParentID
+		if ritem.ParentID != "" {
+			mChildren[ritem.ParentID] = append(mChildren[ritem.ParentID], ritem.RecordID)
+		}
+	}
+
+	if _, ok := mParents[item.ParentID]; !ok {
+		return errors.ErrInvalidParent
+	}
+
+	// 向上查找上级角色，回到当前角色时说明形成了循环
+	level := 1
+	for id := item.ParentID; id != ""; id = mParents[id] {
+		if id == item.RecordID {
+			return errors.New400Response("角色继承关系不能形成循环")
+		}
+		level++
+		if level > maxRoleInheritLevel {
+			break
+		}
+	}
+
+	level += a.getChildLevel(mChildren, item.RecordID, maxRoleInheritLevel)
+	if level > maxRoleInheritLevel {
+		return errors.New400Response(fmt.Sprintf("角色继承层级不能超过%d级", maxRoleInheritLevel))
+	}
+	return nil
+}
+
+// 获取下级角色的最大层级
+func (a *Role) getChildLevel(mChildren map[string][]string, roleID string, maxLevel int) int {
+	if maxLevel <= 0 {
+		return 0
+	}
+
+	level := 0
+	for _, id := range mChildren[roleID] {
+		if l := a.getChildLevel(mChildren, id, maxLevel-1) + 1; l > level {
+			level = l
+		}
+	}
+	return level
+}
+
+// 检查更新的数据，返回更新前的数据
+func (a *Role) checkUpdate(ctx context.Context, recordID string, item *schema.Role) (*schema.Role, error) {
+	oldItem, err := a.Get(ctx, recordID)
+	if err != nil {
+		return nil, err
 	} else if oldItem == nil {
-		return errors.ErrNotFound
-	} else if oldItem.Name != item.Name {
-		err := a.checkName(ctx, item)
+		return nil, errors.ErrNotFound
+	}
+
+	item.TenantID = oldItem.TenantID
+	if oldItem.Name != item.Name {
+		err := a.checkName(ctx, *item)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
+	err = a.checkDataScope(item)
+	if err != nil {
+		return nil, err
+	}
+
 	item.RecordID = oldItem.RecordID
 	item.Creator = oldItem.Creator
 	item.CreatedAt = oldItem.CreatedAt
+	err = a.checkParent(ctx, *item)
+	if err != nil {
+		return nil, err
+	}
+	return oldItem, nil
+}
+
+// Update 更新数据
+func (a *Role) Update(ctx context.Context, recordID string, item schema.Role) error {
+	oldItem, err := a.checkUpdate(ctx, recordID, &item)
+	if err != nil {
+		return err
+	}
+
 	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
 		addRoleMenus, delRoleMenus := a.compareRoleMenus(ctx, oldItem.RoleMenus, item.RoleMenus)
 		for _, rmitem := range addRoleMenus {
 			rmitem.RecordID = util.NewRecordID()
 			rmitem.RoleID = recordID
+			rmitem.TenantID = item.TenantID
 			err := a.RoleMenuModel.Create(ctx, *rmitem)
 			if err != nil {
 				return err
@@ -139,10 +307,19 @@ func (a *Role) Update(ctx context.Context, recordID string, item schema.Role) er
 	if err != nil {
 		return err
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.CasbinPolicy.SyncRoles(ctx, recordID)
 	return nil
 }
 
+// DryRunUpdate 模拟更新数据(不保存)，返回受影响用户的权限变更
+func (a *Role) DryRunUpdate(ctx context.Context, recordID string, item schema.Role) (*schema.PermissionDiff, error) {
+	_, err := a.checkUpdate(ctx, recordID, &item)
+	if err != nil {
+		return nil, err
+	}
+	return a.Permission.SimulateRole(ctx, item)
+}
+
 func (a *Role) compareRoleMenus(ctx context.Context, oldRoleMenus, newRoleMenus schema.RoleMenus) (addList, delList schema.RoleMenus) {
 	mOldRoleMenus := oldRoleMenus.ToMap()
 	mNewRoleMenus := newRoleMenus.ToMap()
@@ -180,6 +357,16 @@ func (a *Role) Delete(ctx context.Context, recordID string) error {
 		return errors.New400Response("该角色已被赋予用户，不允许删除")
 	}
 
+	childResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		ParentID:        &recordID,
+	})
+	if err != nil {
+		return err
+	} else if childResult.PageResult.Total > 0 {
+		return errors.ErrNotAllowDeleteWithChild
+	}
+
 	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
 		err := a.RoleMenuModel.DeleteByRoleID(ctx, recordID)
 		if err != nil {
@@ -192,7 +379,7 @@ func (a *Role) Delete(ctx context.Context, recordID string) error {
 		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.CasbinPolicy.SyncRoles(ctx, recordID)
 	return nil
 }
 
@@ -209,6 +396,6 @@ func (a *Role) UpdateStatus(ctx context.Context, recordID string, status int) er
 	if err != nil {
 		return err
 	}
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.CasbinPolicy.SyncRoles(ctx, recordID)
 	return nil
 }
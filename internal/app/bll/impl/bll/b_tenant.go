@@ -0,0 +1,125 @@
+package bll
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/bll"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"github.com/google/wire"
+)
+
+var _ bll.ITenant = (*Tenant)(nil)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"), wire.Bind(new(bll.ITenant), new(*Tenant)))
+
+// Tenant 租户管理
+type Tenant struct {
+	TenantModel model.ITenant
+	RoleModel   model.IRole
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	return a.TenantModel.Query(ctx, params, opts...)
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(ctx context.Context, recordID string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error) {
+	item, err := a.TenantModel.Get(ctx, recordID, opts...)
+	if err != nil {
+		return nil, err
+	} else if item == nil {
+		return nil, errors.ErrNotFound
+	}
+
+	return item, nil
+}
+
+func (a *Tenant) checkCode(ctx context.Context, code string) error {
+	result, err := a.TenantModel.Query(ctx, schema.TenantQueryParam{
+		PaginationParam: schema.PaginationParam{
+			OnlyCount: true,
+		},
+		Code: code,
+	})
+	if err != nil {
+		return err
+	} else if result.PageResult.Total > 0 {
+		return errors.New400Response("编号已经存在")
+	}
+
+	return nil
+}
+
+// Create 创建数据
+func (a *Tenant) Create(ctx context.Context, item schema.Tenant) (*schema.RecordIDResult, error) {
+	err := a.checkCode(ctx, item.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	item.RecordID = util.NewRecordID()
+	err = a.TenantModel.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.NewRecordIDResult(item.RecordID), nil
+}
+
+// Update 更新数据
+func (a *Tenant) Update(ctx context.Context, recordID string, item schema.Tenant) error {
+	oldItem, err := a.TenantModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	} else if oldItem.Code != item.Code {
+		if err := a.checkCode(ctx, item.Code); err != nil {
+			return err
+		}
+	}
+	item.RecordID = oldItem.RecordID
+	item.Creator = oldItem.Creator
+	item.CreatedAt = oldItem.CreatedAt
+
+	return a.TenantModel.Update(ctx, recordID, item)
+}
+
+// Delete 删除数据
+func (a *Tenant) Delete(ctx context.Context, recordID string) error {
+	oldItem, err := a.TenantModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	roleResult, err := a.RoleModel.Query(icontext.NewTenantID(ctx, recordID), schema.RoleQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+	})
+	if err != nil {
+		return err
+	} else if roleResult.PageResult.Total > 0 {
+		return errors.New400Response("该租户下存在角色，不允许删除")
+	}
+
+	return a.TenantModel.Delete(ctx, recordID)
+}
+
+// UpdateStatus 更新状态
+func (a *Tenant) UpdateStatus(ctx context.Context, recordID string, status int) error {
+	oldItem, err := a.TenantModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if oldItem == nil {
+		return errors.ErrNotFound
+	}
+
+	return a.TenantModel.UpdateStatus(ctx, recordID, status)
+}
@@ -2,13 +2,16 @@ package bll
 
 import (
 	"context"
+	"time"
 
 	"github.com/wangwei518/gin-admin/internal/app/bll"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
 	"github.com/wangwei518/gin-admin/internal/app/model"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/auth"
 	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/lockout"
 	"github.com/wangwei518/gin-admin/pkg/util"
-	"github.com/casbin/casbin/v2"
 	"github.com/google/wire"
 )
 
@@ -19,20 +22,39 @@ var UserSet = wire.NewSet(wire.Struct(new(User), "*"), wire.Bind(new(bll.IUser),
 
 // User 用户管理
 type User struct {
-	Enforcer      *casbin.SyncedEnforcer
-	TransModel    model.ITrans
-	UserModel     model.IUser
-	UserRoleModel model.IUserRole
-	RoleModel     model.IRole
+	CasbinPolicy         *CasbinPolicy
+	DataScope            *DataScope
+	Auth                 auth.Auther
+	TransModel           model.ITrans
+	UserModel            model.IUser
+	UserRoleModel        model.IUserRole
+	UserOrgModel         model.IUserOrg
+	OrgModel             model.IOrg
+	RoleModel            model.IRole
+	APITokenModel        model.IAPIToken
+	PasswordHistoryModel model.IPasswordHistory
+	PasswordResetModel   model.IPasswordReset
+	LoginLocker          *lockout.Locker
+	PasswordPolicy       *PasswordPolicy
+	Permission           *Permission
 }
 
 // Query 查询数据
 func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...schema.UserQueryOptions) (*schema.UserQueryResult, error) {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	return a.UserModel.Query(ctx, params, opts...)
 }
 
 // QueryShow 查询显示项数据
 func (a *User) QueryShow(ctx context.Context, params schema.UserQueryParam, opts ...schema.UserQueryOptions) (*schema.UserShowQueryResult, error) {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := a.UserModel.Query(ctx, params, opts...)
 	if err != nil {
 		return nil, err
@@ -59,6 +81,11 @@ func (a *User) QueryShow(ctx context.Context, params schema.UserQueryParam, opts
 
 // Get 查询指定数据
 func (a *User) Get(ctx context.Context, recordID string, opts ...schema.UserQueryOptions) (*schema.User, error) {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	item, err := a.UserModel.Get(ctx, recordID, opts...)
 	if err != nil {
 		return nil, err
@@ -74,6 +101,14 @@ func (a *User) Get(ctx context.Context, recordID string, opts ...schema.UserQuer
 	}
 	item.UserRoles = userRoleResult.Data
 
+	userOrgResult, err := a.UserOrgModel.Query(ctx, schema.UserOrgQueryParam{
+		UserID: recordID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	item.UserOrgs = userOrgResult.Data
+
 	return item, nil
 }
 
@@ -84,8 +119,42 @@ func (a *User) Create(ctx context.Context, item schema.User) (*schema.RecordIDRe
 		return nil, err
 	}
 
-	item.Password = util.SHA1HashString(item.Password)
+	item.UserOrgs, err = a.checkUserOrgs(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Type == 0 {
+		item.Type = schema.UserTypeNormal
+	}
+
+	// 服务账号不能登录，不设置密码
+	if item.IsService() {
+		item.Password = ""
+	} else {
+		item.Password, err = a.PasswordPolicy.Hash(ctx, "", "", item.Password)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		item.PasswordChangedAt = &now
+	}
+
+	if item.MustChangePassword == 0 {
+		item.MustChangePassword = 2
+	}
+
 	item.RecordID = util.NewRecordID()
+	item.Provider = ""
+	item.Subject = ""
+	item.MFAEnabled = 2
+	item.MFASecret = ""
+	item.MFARecoveryCodes = ""
+	err = a.fillUserRoleTenants(ctx, item.UserRoles)
+	if err != nil {
+		return nil, err
+	}
+
 	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
 		for _, urItem := range item.UserRoles {
 			urItem.RecordID = util.NewRecordID()
@@ -96,22 +165,39 @@ func (a *User) Create(ctx context.Context, item schema.User) (*schema.RecordIDRe
 			}
 		}
 
+		for _, uoItem := range item.UserOrgs {
+			uoItem.RecordID = util.NewRecordID()
+			uoItem.UserID = item.RecordID
+			err := a.UserOrgModel.Create(ctx, *uoItem)
+			if err != nil {
+				return err
+			}
+		}
+
+		if item.Password != "" {
+			err := a.PasswordPolicy.Save(ctx, item.RecordID, item.Password)
+			if err != nil {
+				return err
+			}
+		}
+
 		return a.UserModel.Create(ctx, item)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	a.CasbinPolicy.SyncUsers(ctx, item.RecordID)
 	return schema.NewRecordIDResult(item.RecordID), nil
 }
 
+// 用户名在全部租户中唯一
 func (a *User) checkUserName(ctx context.Context, item schema.User) error {
 	if item.UserName == GetRootUser().UserName {
 		return errors.New400Response("用户名不合法")
 	}
 
-	result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+	result, err := a.UserModel.Query(icontext.NewNoTenant(ctx), schema.UserQueryParam{
 		PaginationParam: schema.PaginationParam{OnlyCount: true},
 		UserName:        item.UserName,
 	})
@@ -123,9 +209,79 @@ func (a *User) checkUserName(ctx context.Context, item schema.User) error {
 	return nil
 }
 
+// 用户角色属于角色所在的租户(在租户中分配角色时，存储层同样会使用上下文中的租户)
+func (a *User) fillUserRoleTenants(ctx context.Context, userRoles schema.UserRoles) error {
+	if len(userRoles) == 0 {
+		return nil
+	}
+
+	roleIDs := make([]string, len(userRoles))
+	for i, urItem := range userRoles {
+		roleIDs[i] = urItem.RoleID
+	}
+
+	result, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
+		RecordIDs: roleIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	mRoles := result.Data.ToMap()
+	for _, urItem := range userRoles {
+		if role, ok := mRoles[urItem.RoleID]; ok {
+			urItem.TenantID = role.TenantID
+		}
+	}
+	return nil
+}
+
+// 检查所属部门及兼任部门是否存在，并去除与所属部门重复的兼任部门
+func (a *User) checkUserOrgs(ctx context.Context, item schema.User) (schema.UserOrgs, error) {
+	var (
+		orgIDs   []string
+		userOrgs schema.UserOrgs
+	)
+	if item.OrgID != "" {
+		orgIDs = append(orgIDs, item.OrgID)
+	}
+
+	mOrgIDs := make(map[string]struct{})
+	for _, uoItem := range item.UserOrgs {
+		if uoItem.OrgID == "" || uoItem.OrgID == item.OrgID {
+			continue
+		} else if _, ok := mOrgIDs[uoItem.OrgID]; ok {
+			continue
+		}
+		mOrgIDs[uoItem.OrgID] = struct{}{}
+		orgIDs = append(orgIDs, uoItem.OrgID)
+		userOrgs = append(userOrgs, uoItem)
+	}
+
+	if len(orgIDs) == 0 {
+		return userOrgs, nil
+	}
+
+	result, err := a.OrgModel.Query(ctx, schema.OrgQueryParam{
+		PaginationParam: schema.PaginationParam{OnlyCount: true},
+		RecordIDs:       orgIDs,
+	})
+	if err != nil {
+		return nil, err
+	} else if result.PageResult.Total != len(orgIDs) {
+		return nil, errors.New400Response("部门不存在")
+	}
+	return userOrgs, nil
+}
+
 // Update 更新数据
 func (a *User) Update(ctx context.Context, recordID string, item schema.User) error {
-	oldItem, err := a.Get(ctx, recordID)
+	scopeCtx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldItem, err := a.Get(scopeCtx, recordID)
 	if err != nil {
 		return err
 	} else if oldItem == nil {
@@ -137,16 +293,43 @@ func (a *User) Update(ctx context.Context, recordID string, item schema.User) er
 		}
 	}
 
-	if item.Password != "" {
-		item.Password = util.SHA1HashString(item.Password)
+	item.UserOrgs, err = a.checkUserOrgs(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	item.Type = oldItem.Type
+	passwordChanged := item.Password != "" && !item.IsService()
+	if passwordChanged {
+		item.Password, err = a.PasswordPolicy.Hash(ctx, recordID, oldItem.Password, item.Password)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		item.PasswordChangedAt = &now
 	} else {
 		item.Password = oldItem.Password
+		item.PasswordChangedAt = oldItem.PasswordChangedAt
+	}
+
+	if item.MustChangePassword == 0 {
+		item.MustChangePassword = oldItem.MustChangePassword
 	}
 
 	item.RecordID = oldItem.RecordID
 	item.Creator = oldItem.Creator
 	item.CreatedAt = oldItem.CreatedAt
-	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
+	item.Provider = oldItem.Provider
+	item.Subject = oldItem.Subject
+	item.MFAEnabled = oldItem.MFAEnabled
+	item.MFASecret = oldItem.MFASecret
+	item.MFARecoveryCodes = oldItem.MFARecoveryCodes
+	err = a.fillUserRoleTenants(ctx, item.UserRoles)
+	if err != nil {
+		return err
+	}
+
+	err = ExecTrans(scopeCtx, a.TransModel, func(ctx context.Context) error {
 		addUserRoles, delUserRoles := a.compareUserRoles(ctx, oldItem.UserRoles, item.UserRoles)
 		for _, rmitem := range addUserRoles {
 			rmitem.RecordID = util.NewRecordID()
@@ -164,16 +347,71 @@ func (a *User) Update(ctx context.Context, recordID string, item schema.User) er
 			}
 		}
 
+		addUserOrgs, delUserOrgs := a.compareUserOrgs(ctx, oldItem.UserOrgs, item.UserOrgs)
+		for _, uoitem := range addUserOrgs {
+			uoitem.RecordID = util.NewRecordID()
+			uoitem.UserID = recordID
+			err := a.UserOrgModel.Create(ctx, *uoitem)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, uoitem := range delUserOrgs {
+			err := a.UserOrgModel.Delete(ctx, uoitem.RecordID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if passwordChanged {
+			err := a.PasswordPolicy.Save(ctx, recordID, item.Password)
+			if err != nil {
+				return err
+			}
+		}
+
 		return a.UserModel.Update(ctx, recordID, item)
 	})
 	if err != nil {
 		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	// 密码变更或用户停用后，撤销用户的全部会话
+	if passwordChanged || (item.Status == 2 && oldItem.Status != 2) {
+		err := a.revokeSessions(ctx, recordID)
+		if err != nil {
+			return err
+		}
+	}
+
+	a.CasbinPolicy.SyncUsers(ctx, recordID)
 	return nil
 }
 
+// DryRunUpdate 模拟更新用户的角色及状态(不保存)，返回用户的权限变更
+func (a *User) DryRunUpdate(ctx context.Context, recordID string, item schema.User) (*schema.PermissionDiff, error) {
+	scopeCtx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	oldItem, err := a.Get(scopeCtx, recordID)
+	if err != nil {
+		return nil, err
+	} else if oldItem == nil {
+		return nil, errors.ErrNotFound
+	} else if oldItem.UserName != item.UserName {
+		err := a.checkUserName(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	item.RecordID = oldItem.RecordID
+	return a.Permission.SimulateUser(ctx, *oldItem, item)
+}
+
 func (a *User) compareUserRoles(ctx context.Context, oldUserRoles, newUserRoles schema.UserRoles) (addList, delList schema.UserRoles) {
 	mOldUserRoles := oldUserRoles.ToMap()
 	mNewUserRoles := newUserRoles.ToMap()
@@ -192,17 +430,64 @@ func (a *User) compareUserRoles(ctx context.Context, oldUserRoles, newUserRoles
 	return
 }
 
+func (a *User) compareUserOrgs(ctx context.Context, oldUserOrgs, newUserOrgs schema.UserOrgs) (addList, delList schema.UserOrgs) {
+	mOldUserOrgs := oldUserOrgs.ToMap()
+	mNewUserOrgs := newUserOrgs.ToMap()
+
+	for k, item := range mNewUserOrgs {
+		if _, ok := mOldUserOrgs[k]; ok {
+			delete(mOldUserOrgs, k)
+			continue
+		}
+		addList = append(addList, item)
+	}
+
+	for _, item := range mOldUserOrgs {
+		delList = append(delList, item)
+	}
+	return
+}
+
 // Delete 删除数据
 func (a *User) Delete(ctx context.Context, recordID string) error {
-	oldItem, err := a.UserModel.Get(ctx, recordID)
+	scopeCtx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldItem, err := a.UserModel.Get(scopeCtx, recordID)
 	if err != nil {
 		return err
 	} else if oldItem == nil {
 		return errors.ErrNotFound
 	}
 
-	err = ExecTrans(ctx, a.TransModel, func(ctx context.Context) error {
-		err := a.UserRoleModel.DeleteByUserID(ctx, recordID)
+	// 在其他租户中删除用户时只移除用户在该租户中的角色、部门及访问令牌
+	// (全部用户均属于默认租户，在默认租户中删除时删除用户及其在全部租户中的数据)
+	if tenantID, ok := icontext.FromTenantID(ctx); ok && tenantID != "" {
+		err = ExecTrans(scopeCtx, a.TransModel, func(ctx context.Context) error {
+			return a.deleteUserMembers(ctx, recordID)
+		})
+		if err != nil {
+			return err
+		}
+
+		a.CasbinPolicy.SyncUsers(ctx, recordID)
+		return nil
+	}
+
+	err = ExecTrans(scopeCtx, a.TransModel, func(ctx context.Context) error {
+		err := a.deleteUserMembers(icontext.NewNoTenant(ctx), recordID)
+		if err != nil {
+			return err
+		}
+
+		err = a.PasswordHistoryModel.DeleteByUserID(ctx, recordID)
+		if err != nil {
+			return err
+		}
+
+		err = a.PasswordResetModel.DeleteByUserID(ctx, recordID)
 		if err != nil {
 			return err
 		}
@@ -213,13 +498,38 @@ func (a *User) Delete(ctx context.Context, recordID string) error {
 		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	err = a.revokeSessions(ctx, recordID)
+	if err != nil {
+		return err
+	}
+
+	a.CasbinPolicy.SyncUsers(ctx, recordID)
 	return nil
 }
 
+// 删除用户的角色、部门及访问令牌(按照上下文中的租户过滤)
+func (a *User) deleteUserMembers(ctx context.Context, recordID string) error {
+	err := a.UserRoleModel.DeleteByUserID(ctx, recordID)
+	if err != nil {
+		return err
+	}
+
+	err = a.UserOrgModel.DeleteByUserID(ctx, recordID)
+	if err != nil {
+		return err
+	}
+
+	return a.APITokenModel.DeleteByUserID(ctx, recordID)
+}
+
 // UpdateStatus 更新状态
 func (a *User) UpdateStatus(ctx context.Context, recordID string, status int) error {
-	oldItem, err := a.UserModel.Get(ctx, recordID)
+	scopeCtx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldItem, err := a.UserModel.Get(scopeCtx, recordID)
 	if err != nil {
 		return err
 	} else if oldItem == nil {
@@ -227,11 +537,139 @@ func (a *User) UpdateStatus(ctx context.Context, recordID string, status int) er
 	}
 	oldItem.Status = status
 
-	err = a.UserModel.UpdateStatus(ctx, recordID, status)
+	err = a.UserModel.UpdateStatus(scopeCtx, recordID, status)
 	if err != nil {
 		return err
 	}
 
-	LoadCasbinPolicy(ctx, a.Enforcer)
+	// 停用用户时撤销其全部会话
+	if status == 2 {
+		err := a.revokeSessions(ctx, recordID)
+		if err != nil {
+			return err
+		}
+	}
+
+	a.CasbinPolicy.SyncUsers(ctx, recordID)
+	return nil
+}
+
+// QuerySessions 查询用户的有效会话
+func (a *User) QuerySessions(ctx context.Context, recordID string) (schema.UserSessions, error) {
+	err := a.checkUserScope(ctx, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := a.Auth.QuerySessions(ctx, recordID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return toUserSessions(sessions), nil
+}
+
+func toUserSessions(sessions []*auth.Session) schema.UserSessions {
+	list := make(schema.UserSessions, len(sessions))
+	for i, item := range sessions {
+		list[i] = &schema.UserSession{
+			ID:        item.ID,
+			ActorID:   item.ActorID,
+			IP:        item.IP,
+			UserAgent: item.UserAgent,
+			IssuedAt:  item.IssuedAt,
+			ExpiresAt: item.ExpiresAt,
+		}
+	}
+	return list
+}
+
+// RevokeSession 撤销用户的指定会话
+func (a *User) RevokeSession(ctx context.Context, recordID, sessionID string) error {
+	err := a.checkUserScope(ctx, recordID)
+	if err != nil {
+		return err
+	}
+
+	err = a.Auth.RevokeSession(ctx, recordID, sessionID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// RevokeSessions 撤销用户的全部会话
+func (a *User) RevokeSessions(ctx context.Context, recordID string) error {
+	err := a.checkUserScope(ctx, recordID)
+	if err != nil {
+		return err
+	}
+
+	return a.revokeSessions(ctx, recordID)
+}
+
+func (a *User) revokeSessions(ctx context.Context, recordID string) error {
+	err := a.Auth.RevokeSessions(ctx, recordID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// checkUserScope 检查用户是否存在于当前的数据权限范围内
+func (a *User) checkUserScope(ctx context.Context, recordID string) error {
+	ctx, err := a.DataScope.NewContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	item, err := a.UserModel.Get(ctx, recordID)
+	if err != nil {
+		return err
+	} else if item == nil {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// ResetMFA 重置用户的多因素认证(用户丢失认证器时由管理员操作)
+func (a *User) ResetMFA(ctx context.Context, recordID string) error {
+	err := a.checkUserScope(ctx, recordID)
+	if err != nil {
+		return err
+	}
+
+	return a.UserModel.UpdateMFA(ctx, recordID, schema.UserMFA{Enabled: 2})
+}
+
+// QueryLoginLocks 查询登录锁定列表
+func (a *User) QueryLoginLocks(ctx context.Context) (schema.LoginLocks, error) {
+	locks, err := a.LoginLocker.Query(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	list := make(schema.LoginLocks, len(locks))
+	for i, item := range locks {
+		list[i] = &schema.LoginLock{
+			Type: item.Type,
+			Name: item.Name,
+		}
+		if !item.ExpiresAt.IsZero() {
+			list[i].ExpiresAt = item.ExpiresAt.Unix()
+		}
+	}
+	return list, nil
+}
+
+// UnlockLogin 解除登录锁定
+func (a *User) UnlockLogin(ctx context.Context, lockType, name string) error {
+	if lockType != lockout.TypeUser && lockType != lockout.TypeIP {
+		return errors.New400Response("无效的锁定类型")
+	}
+
+	err := a.LoginLocker.Unlock(ctx, lockType, name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
 	return nil
 }
@@ -4,9 +4,15 @@ import "github.com/google/wire"
 
 // BllSet bll注入
 var BllSet = wire.NewSet(
+	APITokenSet,
+	DataScopeSet,
 	DemoSet,
 	LoginSet,
 	MenuSet,
+	OrgSet,
+	PasswordPolicySet,
+	PermissionSet,
 	RoleSet,
+	TenantSet,
 	UserSet,
 )
@@ -70,7 +70,16 @@ type Config struct {
 	LogGormHook   LogGormHook
 	LogMongoHook  LogMongoHook
 	Root          Root
+	Authenticator Authenticator
+	Password      Password
+	PasswordReset PasswordReset
+	MFA           MFA
+	Captcha       Captcha
+	LoginLock     LoginLock
+	Impersonation Impersonation
 	LDAP          LDAP
+	OIDC          OIDC
+	Mail          Mail
 	JWTAuth       JWTAuth
 	Monitor       Monitor
 	RateLimiter   RateLimiter
@@ -102,6 +111,11 @@ type Casbin struct {
 	Model            string
 	AutoLoad         bool
 	AutoLoadInternal int
+	ReloadDelay      int
+	Watcher          string
+	WatcherFilePath  string
+	WatcherInterval  int
+	WatcherChannel   string
 }
 
 // LogHook 日志钩子
@@ -145,29 +159,196 @@ type LogMongoHook struct {
 
 // Root root用户
 type Root struct {
+	UserName  string
+	Password  string
+	RealName  string
+	MFASecret string
+}
+
+// Authenticator 登录认证器配置
+type Authenticator struct {
+	Chain []string
+}
+
+// Password 密码哈希配置
+type Password struct {
+	Algorithm         string
+	BcryptCost        int
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	MinLength         int
+	RequireUpper      bool
+	RequireLower      bool
+	RequireDigit      bool
+	RequireSymbol     bool
+	BannedWords       []string
+	HistoryCount      int
+	ExpireDays        int
+}
+
+// PasswordReset 找回密码配置
+type PasswordReset struct {
+	Enable  bool
+	Expired int
+	URL     string
+	Subject string
+}
+
+// MFA 多因素认证配置
+type MFA struct {
+	Issuer           string
+	ChallengeExpired int
+	Skew             int
+	RecoveryCodes    int
+}
+
+// Captcha 图形验证码配置
+type Captcha struct {
+	Enable           bool
+	Store            string
+	FilePath         string
+	Length           int
+	Width            int
+	Height           int
+	RedisDB          int
+	RedisPrefix      string
+	FailureThreshold int
+	FailureExpired   int
+}
+
+// LoginLock 登录失败锁定配置
+type LoginLock struct {
+	Enable         bool
+	Store          string
+	FilePath       string
+	RedisDB        int
+	RedisPrefix    string
+	UserThreshold  int
+	IPThreshold    int
+	Window         int
+	LockDuration   int
+	DelayThreshold int
+	DelayStep      int
+	MaxDelay       int
+}
+
+// Impersonation 模拟登录配置
+type Impersonation struct {
+	Enable  bool
+	Expired int
+}
+
+// LDAP Server
+type LDAP struct {
+	Addr               string
+	DN                 string
+	BindUserName       string
+	BindPassword       string
+	UserFilter         string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	CAFile             string
+	DialTimeout        int
+	ReadTimeout        int
+	PoolSize           int
+	Attributes         LDAPAttributes
+	GroupAttribute     string
+	GroupBaseDN        string
+	GroupFilter        string
+	GroupRoles         []LDAPGroupRole
+}
+
+// LDAPGroupRole LDAP组与角色的映射
+type LDAPGroupRole struct {
+	GroupDN  string
+	RoleName string
+}
+
+// Mail 邮件发送配置
+type Mail struct {
+	Sender   string
+	From     string
+	FilePath string
+	Host     string
+	Port     int
 	UserName string
 	Password string
+	TLS      bool
+}
+
+// OIDC OpenID Connect登录配置
+type OIDC struct {
+	Enable       bool
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	StateExpired int
+	Store        string
+	RedisDB      int
+	RedisPrefix  string
+	Claims       OIDCClaims
+	GroupRoles   []OIDCGroupRole
+}
+
+// OIDCClaims OIDC声明到用户字段的映射
+type OIDCClaims struct {
+	UserName string
 	RealName string
+	Email    string
+	Phone    string
+	Groups   string
 }
 
-// LDAP Server
-type LDAP struct {
-	Addr         string
-	DN           string
-	BindUserName string
-	BindPassword string
+// OIDCGroupRole OIDC用户组与角色的映射
+type OIDCGroupRole struct {
+	Group    string
+	RoleName string
+}
+
+// LDAPAttributes LDAP属性映射
+type LDAPAttributes struct {
+	RealName string
+	Email    string
+	Phone    string
 }
 
 // JWTAuth 用户认证
 type JWTAuth struct {
-	Enable        bool
+	Enable         bool
+	SigningMethod  string
+	SigningKey     string
+	KeyID          string
+	PrivateKeyFile string
+	VerifyKeys     []JWTVerifyKey
+	Expired        int
+	RefreshExpired int
+	Store          string
+	FilePath       string
+	RedisDB        int
+	RedisPrefix    string
+	Views          []string
+	DefaultView    string
+}
+
+// HasView 是否允许签发指定视图的令牌
+func (a JWTAuth) HasView(view string) bool {
+	for _, v := range a.Views {
+		if v == view {
+			return true
+		}
+	}
+	return false
+}
+
+// JWTVerifyKey 令牌校验密钥(密钥轮换期间仍然有效的历史密钥)
+type JWTVerifyKey struct {
+	KeyID         string
 	SigningMethod string
 	SigningKey    string
-	Expired       int
-	Store         string
-	FilePath      string
-	RedisDB       int
-	RedisPrefix   string
+	PublicKeyFile string
 }
 
 // HTTP http配置参数
@@ -177,6 +358,7 @@ type HTTP struct {
 	CertFile        string
 	KeyFile         string
 	ShutdownTimeout int
+	TrustedProxies  []string
 }
 
 // Monitor 监控配置参数
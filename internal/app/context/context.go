@@ -2,6 +2,8 @@ package context
 
 import (
 	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
 )
 
 // 定义全局上下文中的键
@@ -10,7 +12,12 @@ type (
 	noTransCtx   struct{}
 	transLockCtx struct{}
 	userIDCtx    struct{}
+	viewCtx      struct{}
+	actorIDCtx   struct{}
 	traceIDCtx   struct{}
+	dataScopeCtx struct{}
+	tenantIDCtx  struct{}
+	noTenantCtx  struct{}
 )
 
 // NewTrans 创建事务的上下文
@@ -62,6 +69,38 @@ func FromUserID(ctx context.Context) (string, bool) {
 	return "", false
 }
 
+// NewView 创建令牌视图的上下文
+func NewView(ctx context.Context, view string) context.Context {
+	return context.WithValue(ctx, viewCtx{}, view)
+}
+
+// FromView 从上下文中获取令牌视图
+func FromView(ctx context.Context) (string, bool) {
+	v := ctx.Value(viewCtx{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s, s != ""
+		}
+	}
+	return "", false
+}
+
+// NewActorID 创建模拟登录操作者ID的上下文
+func NewActorID(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorIDCtx{}, actorID)
+}
+
+// FromActorID 从上下文中获取模拟登录的操作者ID
+func FromActorID(ctx context.Context) (string, bool) {
+	v := ctx.Value(actorIDCtx{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s, s != ""
+		}
+	}
+	return "", false
+}
+
 // NewTraceID 创建追踪ID的上下文
 func NewTraceID(ctx context.Context, traceID string) context.Context {
 	return context.WithValue(ctx, traceIDCtx{}, traceID)
@@ -77,3 +116,50 @@ func FromTraceID(ctx context.Context) (string, bool) {
 	}
 	return "", false
 }
+
+// NewDataScope 创建数据范围的上下文
+func NewDataScope(ctx context.Context, scope *schema.DataScope) context.Context {
+	return context.WithValue(ctx, dataScopeCtx{}, scope)
+}
+
+// FromDataScope 从上下文中获取数据范围
+func FromDataScope(ctx context.Context) (*schema.DataScope, bool) {
+	v := ctx.Value(dataScopeCtx{})
+	if v != nil {
+		if s, ok := v.(*schema.DataScope); ok {
+			return s, s != nil
+		}
+	}
+	return nil, false
+}
+
+// NewTenantID 创建租户ID的上下文(存储层按照租户ID过滤数据，空值表示默认租户)
+func NewTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDCtx{}, tenantID)
+}
+
+// FromTenantID 从上下文中获取租户ID(未指定租户或不区分租户时ok为false)
+func FromTenantID(ctx context.Context) (string, bool) {
+	if FromNoTenant(ctx) {
+		return "", false
+	}
+
+	v := ctx.Value(tenantIDCtx{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// NewNoTenant 创建不区分租户的上下文(如加载全部租户的策略规则)
+func NewNoTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noTenantCtx{}, true)
+}
+
+// FromNoTenant 从上下文中获取不区分租户标识
+func FromNoTenant(ctx context.Context) bool {
+	v := ctx.Value(noTenantCtx{})
+	return v != nil && v.(bool)
+}
@@ -18,6 +18,12 @@ const (
 	prefix = "gin-admin"
 	// UserIDKey 存储上下文中的键(用户ID)
 	UserIDKey = prefix + "/user-id"
+	// ViewKey 存储上下文中的键(令牌视图)
+	ViewKey = prefix + "/view"
+	// ActorIDKey 存储上下文中的键(模拟登录的操作者ID)
+	ActorIDKey = prefix + "/actor-id"
+	// TenantIDKey 存储上下文中的键(租户ID)
+	TenantIDKey = prefix + "/tenant-id"
 	// ResBodyKey 存储上下文中的键(响应Body数据)
 	ResBodyKey = prefix + "/res-body"
 )
@@ -44,6 +50,36 @@ func SetUserID(c *gin.Context, userID string) {
 	c.Set(UserIDKey, userID)
 }
 
+// GetView 获取令牌视图
+func GetView(c *gin.Context) string {
+	return c.GetString(ViewKey)
+}
+
+// SetView 设定令牌视图
+func SetView(c *gin.Context, view string) {
+	c.Set(ViewKey, view)
+}
+
+// GetActorID 获取模拟登录的操作者ID(不是模拟登录时为空)
+func GetActorID(c *gin.Context) string {
+	return c.GetString(ActorIDKey)
+}
+
+// SetActorID 设定模拟登录的操作者ID
+func SetActorID(c *gin.Context, actorID string) {
+	c.Set(ActorIDKey, actorID)
+}
+
+// GetTenantID 获取租户ID(为空时为默认租户)
+func GetTenantID(c *gin.Context) string {
+	return c.GetString(TenantIDKey)
+}
+
+// SetTenantID 设定租户ID
+func SetTenantID(c *gin.Context, tenantID string) {
+	c.Set(TenantIDKey, tenantID)
+}
+
 // ParseJSON 解析请求JSON
 func ParseJSON(c *gin.Context, obj interface{}) error {
 	if err := c.ShouldBindJSON(obj); err != nil {
@@ -1,6 +1,8 @@
 package initialize
 
 import (
+	"fmt"
+
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/wangwei518/gin-admin/internal/app/config"
 	"github.com/wangwei518/gin-admin/pkg/auth"
@@ -10,31 +12,64 @@ import (
 	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth/store/redis"
 )
 
+// InitKeySet 初始化令牌签名密钥
+func InitKeySet() (*jwtauth.KeySet, error) {
+	cfg := config.C.JWTAuth
+
+	signing, err := newJWTKey(cfg.KeyID, cfg.SigningMethod, cfg.SigningKey, cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	} else if signing.SignKey == nil {
+		return nil, fmt.Errorf("jwt signing key %q has no private key", cfg.KeyID)
+	}
+
+	var verify []*jwtauth.Key
+	for _, item := range cfg.VerifyKeys {
+		key, err := newJWTKey(item.KeyID, item.SigningMethod, item.SigningKey, item.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		verify = append(verify, key)
+	}
+
+	return jwtauth.NewKeySet(signing, verify...)
+}
+
+func newJWTKey(kid, methodName, secret, keyFile string) (*jwtauth.Key, error) {
+	if methodName == "" {
+		methodName = jwt.SigningMethodHS512.Alg()
+	}
+
+	method, err := jwtauth.GetSigningMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		return jwtauth.NewHMACKey(kid, method, []byte(secret))
+	}
+
+	key, err := jwtauth.NewKeyFromFile(kid, method, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load jwt key %q: %s", kid, err.Error())
+	}
+	return key, nil
+}
+
 // InitAuth 初始化用户认证
-func InitAuth() (auth.Auther, func(), error) {
+func InitAuth(ks *jwtauth.KeySet) (auth.Auther, func(), error) {
 	cfg := config.C.JWTAuth
 
 	// the content in JWT
 	var opts []jwtauth.Option
 	opts = append(opts, jwtauth.SetExpired(cfg.Expired))
-	opts = append(opts, jwtauth.SetSigningKey([]byte(cfg.SigningKey)))
-	opts = append(opts, jwtauth.SetKeyfunc(func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, auth.ErrInvalidToken
-		}
-		return []byte(cfg.SigningKey), nil
-	}))
-
-	var method jwt.SigningMethod
-	switch cfg.SigningMethod {
-	case "HS256":
-		method = jwt.SigningMethodHS256
-	case "HS384":
-		method = jwt.SigningMethodHS384
-	default:
-		method = jwt.SigningMethodHS512
+	if cfg.RefreshExpired > 0 {
+		opts = append(opts, jwtauth.SetRefreshExpired(cfg.RefreshExpired))
+	}
+	if v := config.C.MFA.ChallengeExpired; v > 0 {
+		opts = append(opts, jwtauth.SetChallengeExpired(v))
 	}
-	opts = append(opts, jwtauth.SetSigningMethod(method))
+	opts = append(opts, jwtauth.SetKeySet(ks))
 
 	var store jwtauth.Storer
 	switch cfg.Store {
@@ -0,0 +1,80 @@
+package initialize
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/bll/impl/bll"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/module/authenticator"
+	"github.com/wangwei518/gin-admin/pkg/ldap"
+	"github.com/wangwei518/gin-admin/pkg/password"
+)
+
+// InitAuthenticator 初始化登录认证器链
+func InitAuthenticator(userModel model.IUser, pm *password.Manager) (*authenticator.Chain, func(), error) {
+	cfg := config.C.Authenticator
+
+	var list []authenticator.Authenticator
+	var closers []func() error
+	cleanFunc := func() {
+		for _, fn := range closers {
+			_ = fn()
+		}
+	}
+
+	for _, name := range cfg.Chain {
+		switch name {
+		case authenticator.RootName:
+			list = append(list, authenticator.NewRoot(bll.GetRootUser(), pm))
+		case authenticator.LocalName:
+			list = append(list, authenticator.NewLocal(userModel, pm))
+		case authenticator.LDAPName:
+			a, err := newLDAPAuthenticator()
+			if err != nil {
+				cleanFunc()
+				return nil, nil, err
+			}
+			closers = append(closers, a.Close)
+			list = append(list, a)
+		default:
+			cleanFunc()
+			return nil, nil, fmt.Errorf("unknown authenticator: %s", name)
+		}
+	}
+
+	return authenticator.NewChain(list...), cleanFunc, nil
+}
+
+func newLDAPAuthenticator() (*authenticator.LDAP, error) {
+	cfg := config.C.LDAP
+	attrs := authenticator.LDAPAttributes{
+		RealName: cfg.Attributes.RealName,
+		Email:    cfg.Attributes.Email,
+		Phone:    cfg.Attributes.Phone,
+	}
+
+	client, err := ldap.New(ldap.Config{
+		Addr:               cfg.Addr,
+		StartTLS:           cfg.StartTLS,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		CAFile:             cfg.CAFile,
+		BindDN:             cfg.BindUserName,
+		BindPassword:       cfg.BindPassword,
+		BaseDN:             cfg.DN,
+		UserFilter:         cfg.UserFilter,
+		Attributes:         attrs.Names(),
+		GroupAttribute:     cfg.GroupAttribute,
+		GroupBaseDN:        cfg.GroupBaseDN,
+		GroupFilter:        cfg.GroupFilter,
+		DialTimeout:        time.Duration(cfg.DialTimeout) * time.Second,
+		ReadTimeout:        time.Duration(cfg.ReadTimeout) * time.Second,
+		PoolSize:           cfg.PoolSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return authenticator.NewLDAP(client, attrs), nil
+}
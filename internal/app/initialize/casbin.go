@@ -1,9 +1,16 @@
 package initialize
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/wangwei518/gin-admin/internal/app/bll/impl/bll"
 	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/module/adapter"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/wangwei518/gin-admin/pkg/watcher"
+	fileWatcher "github.com/wangwei518/gin-admin/pkg/watcher/file"
+	redisWatcher "github.com/wangwei518/gin-admin/pkg/watcher/redis"
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/persist"
 )
@@ -25,15 +32,77 @@ func InitCasbin(adapter persist.Adapter) (*casbin.SyncedEnforcer, func(), error)
 	if err != nil {
 		return nil, nil, err
 	}
+	e.AddFunction("conditionMatch", casbinConditionMatch)
 	e.EnableEnforce(cfg.Enable)
+	// 批量更新规则时由CasbinPolicy统一重建角色关系，定期自动加载同样由CasbinPolicy执行
+	e.EnableAutoBuildRoleLinks(false)
 
-	cleanFunc := func() {}
-	if cfg.AutoLoad {
-		e.StartAutoLoadPolicy(time.Duration(cfg.AutoLoadInternal) * time.Second)
-		cleanFunc = func() {
-			e.StopAutoLoadPolicy()
+	return e, func() {}, nil
+}
+
+// InitCasbinPolicy 初始化casbin权限策略同步，并启动全量加载策略的后台任务
+func InitCasbinPolicy(e *casbin.SyncedEnforcer, a *adapter.CasbinAdapter, w watcher.Watcher) (*bll.CasbinPolicy, func(), error) {
+	p := &bll.CasbinPolicy{
+		Enforcer: e,
+		Adapter:  a,
+		Watcher:  w,
+	}
+	p.Start()
+	return p, p.Stop, nil
+}
+
+// 判断请求环境是否满足策略规则的访问条件(conditionMatch(r.env, p.cond))
+func casbinConditionMatch(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return false, fmt.Errorf("conditionMatch: expected 2 arguments, got %d", len(args))
+	}
+
+	cond, _ := args[1].(string)
+	if cond == "" {
+		return true, nil
+	}
+
+	env, ok := args[0].(*abac.Env)
+	if !ok {
+		return false, nil
+	}
+
+	c, err := abac.Decode(cond)
+	if err != nil {
+		return false, fmt.Errorf("conditionMatch: %s", err.Error())
+	}
+	return c.Match(env), nil
+}
+
+// InitCasbinWatcher 初始化casbin策略变更通知(多实例部署时同步策略变更)
+func InitCasbinWatcher() (watcher.Watcher, func(), error) {
+	cfg := config.C.Casbin
+
+	var w watcher.Watcher
+	switch cfg.Watcher {
+	case "redis":
+		rc := config.C.Redis
+		rw, err := redisWatcher.NewWatcher(&redisWatcher.Config{
+			Addr:     rc.Addr,
+			Password: rc.Password,
+			Channel:  cfg.WatcherChannel,
+		})
+		if err != nil {
+			return nil, nil, err
 		}
+		w = rw
+	case "file":
+		fw, err := fileWatcher.NewWatcher(cfg.WatcherFilePath, time.Duration(cfg.WatcherInterval)*time.Millisecond)
+		if err != nil {
+			return nil, nil, err
+		}
+		w = fw
+	default:
+		return nil, func() {}, nil
 	}
 
-	return e, cleanFunc, nil
+	cleanFunc := func() {
+		w.Close()
+	}
+	return w, cleanFunc, nil
 }
@@ -5,28 +5,97 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/LyricTian/captcha"
+	"github.com/LyricTian/captcha/store"
 	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/module/adapter"
+	"github.com/wangwei518/gin-admin/pkg/counter"
+	buntdbCounter "github.com/wangwei518/gin-admin/pkg/counter/store/buntdb"
+	redisCounter "github.com/wangwei518/gin-admin/pkg/counter/store/redis"
+	"github.com/wangwei518/gin-admin/pkg/lockout"
 	"github.com/wangwei518/gin-admin/pkg/logger"
-	//"github.com/go-redis/redis"
+	"github.com/go-redis/redis"
 	"github.com/google/gops/agent"
+	"github.com/tidwall/buntdb"
 )
 
-// InitCaptcha 初始化图形验证码
-/*
-func InitCaptcha() {
+// InitCaptcha 初始化图形验证码(同时返回记录登录失败次数的计数器)
+func InitCaptcha() (counter.Counter, func(), error) {
 	cfg := config.C.Captcha
-	if cfg.Store == "redis" {
+	expiration := captcha.Expiration
+
+	var c counter.Counter
+	switch cfg.Store {
+	case "redis":
 		rc := config.C.Redis
-		captcha.SetCustomStore(store.NewRedisStore(&redis.Options{
+		cli := redis.NewClient(&redis.Options{
 			Addr:     rc.Addr,
 			Password: rc.Password,
 			DB:       cfg.RedisDB,
-		}, captcha.Expiration, logger.StandardLogger(), cfg.RedisPrefix))
+		})
+		captcha.SetCustomStore(store.NewRedisStoreWithCli(cli, expiration, logger.StandardLogger(), cfg.RedisPrefix))
+		c = redisCounter.NewStoreWithClient(cli, cfg.RedisPrefix)
+	case "file":
+		os.MkdirAll(filepath.Dir(cfg.FilePath), 0777)
+		db, err := buntdb.Open(cfg.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		captcha.SetCustomStore(adapter.NewCaptchaBuntdbStore(db, expiration, logger.StandardLogger()))
+		c = buntdbCounter.NewStoreWithDB(db)
+	default:
+		captcha.SetCustomStore(store.NewMemoryStore(time.Minute, expiration))
+		c = counter.NewMemoryCounter()
+	}
+
+	cleanFunc := func() {
+		c.Close()
+	}
+	return c, cleanFunc, nil
+}
+
+// InitLoginLock 初始化登录失败锁定
+func InitLoginLock() (*lockout.Locker, func(), error) {
+	cfg := config.C.LoginLock
+
+	var c counter.Counter
+	switch cfg.Store {
+	case "redis":
+		rc := config.C.Redis
+		c = redisCounter.NewStore(&redisCounter.Config{
+			Addr:      rc.Addr,
+			Password:  rc.Password,
+			DB:        cfg.RedisDB,
+			KeyPrefix: cfg.RedisPrefix,
+		})
+	case "file":
+		s, err := buntdbCounter.NewStore(cfg.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		c = s
+	default:
+		c = counter.NewMemoryCounter()
+	}
+
+	locker := lockout.New(c, lockout.Config{
+		UserThreshold:  cfg.UserThreshold,
+		IPThreshold:    cfg.IPThreshold,
+		Window:         time.Duration(cfg.Window) * time.Second,
+		LockDuration:   time.Duration(cfg.LockDuration) * time.Second,
+		DelayThreshold: cfg.DelayThreshold,
+		DelayStep:      time.Duration(cfg.DelayStep) * time.Millisecond,
+		MaxDelay:       time.Duration(cfg.MaxDelay) * time.Millisecond,
+	})
+	cleanFunc := func() {
+		c.Close()
 	}
+	return locker, cleanFunc, nil
 }
-*/
 
 // InitMonitor 初始化服务监控
 func InitMonitor(ctx context.Context) {
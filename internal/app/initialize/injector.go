@@ -1,6 +1,7 @@
 package initialize
 
 import (
+	"github.com/wangwei518/gin-admin/internal/app/bll/impl/bll"
 	"github.com/wangwei518/gin-admin/internal/app/initialize/data"
 	"github.com/wangwei518/gin-admin/pkg/auth"
 	"github.com/casbin/casbin/v2"
@@ -16,5 +17,6 @@ type Injector struct {
 	Engine         *gin.Engine
 	Auth           auth.Auther
 	CasbinEnforcer *casbin.SyncedEnforcer
+	CasbinPolicy   *bll.CasbinPolicy
 	Menu           *data.Menu
 }
@@ -0,0 +1,26 @@
+package initialize
+
+import (
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/pkg/mail"
+)
+
+// InitMailSender 初始化邮件发送器
+func InitMailSender() mail.Sender {
+	cfg := config.C.Mail
+
+	switch cfg.Sender {
+	case "smtp":
+		return mail.NewSMTPSender(mail.SMTPConfig{
+			Host:     cfg.Host,
+			Port:     cfg.Port,
+			UserName: cfg.UserName,
+			Password: cfg.Password,
+			TLS:      cfg.TLS,
+		})
+	case "file":
+		return mail.NewFileSender(cfg.FilePath)
+	default:
+		return mail.NewLogSender()
+	}
+}
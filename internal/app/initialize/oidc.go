@@ -0,0 +1,43 @@
+package initialize
+
+import (
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/pkg/oidc"
+	"github.com/wangwei518/gin-admin/pkg/oidc/store/redis"
+)
+
+// InitOIDC 初始化OIDC客户端(首次登录时发现身份提供方配置)
+func InitOIDC() *oidc.Client {
+	cfg := config.C.OIDC
+	return oidc.New(oidc.Config{
+		Issuer:       cfg.Issuer,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+	})
+}
+
+// InitOIDCSessionStore 初始化OIDC授权请求的会话存储
+func InitOIDCSessionStore() (oidc.SessionStore, func(), error) {
+	cfg := config.C.OIDC
+
+	var store oidc.SessionStore
+	switch cfg.Store {
+	case "redis":
+		rc := config.C.Redis
+		store = redis.NewStore(&redis.Config{
+			Addr:      rc.Addr,
+			Password:  rc.Password,
+			DB:        cfg.RedisDB,
+			KeyPrefix: cfg.RedisPrefix,
+		})
+	default:
+		store = oidc.NewMemorySessionStore()
+	}
+
+	cleanFunc := func() {
+		store.Close()
+	}
+	return store, cleanFunc, nil
+}
@@ -0,0 +1,42 @@
+package initialize
+
+import (
+	"fmt"
+
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/pkg/password"
+)
+
+// InitPassword 初始化密码哈希算法
+func InitPassword() (*password.Manager, error) {
+	cfg := config.C.Password
+
+	bcrypt := password.NewBcrypt(cfg.BcryptCost)
+	argon2id := password.NewArgon2id(password.Argon2idParams{
+		Memory:      cfg.Argon2Memory,
+		Iterations:  cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism,
+	})
+
+	switch cfg.Algorithm {
+	case password.BcryptName:
+		return password.New(bcrypt, argon2id, password.NewSHA1()), nil
+	case password.Argon2idName, "":
+		return password.New(argon2id, bcrypt, password.NewSHA1()), nil
+	default:
+		return nil, fmt.Errorf("unknown password algorithm: %s", cfg.Algorithm)
+	}
+}
+
+// InitPasswordPolicy 初始化密码复杂度策略
+func InitPasswordPolicy() *password.Policy {
+	cfg := config.C.Password
+	return &password.Policy{
+		MinLength:     cfg.MinLength,
+		RequireUpper:  cfg.RequireUpper,
+		RequireLower:  cfg.RequireLower,
+		RequireDigit:  cfg.RequireDigit,
+		RequireSymbol: cfg.RequireSymbol,
+		BannedWords:   cfg.BannedWords,
+	}
+}
@@ -14,11 +14,16 @@ func InitGinEngine(r router.IRouter) *gin.Engine {
 	gin.SetMode(config.C.RunMode)
 
 	app := gin.New()
+	// 客户端IP由RealIPMiddleware根据受信任的代理确定，不直接使用客户端可以伪造的请求头
+	app.ForwardedByClientIP = false
 	app.NoMethod(middleware.NoMethodHandler())
 	app.NoRoute(middleware.NoRouteHandler())
 
 	prefixes := r.Prefixes()
 
+	// 客户端IP
+	app.Use(middleware.RealIPMiddleware())
+
 	// 跟踪ID
 	app.Use(middleware.TraceMiddleware(middleware.AllowPathPrefixNoSkipper(prefixes...)))
 
@@ -24,8 +24,19 @@ func BuildInjector() (*Injector, func(), error) {
 		gormModel.ModelSet,
 		// InitMongoDB,
 		// mongoModel.ModelSet,
+		InitKeySet,
 		InitAuth,
+		InitPassword,
+		InitPasswordPolicy,
+		InitMailSender,
+		InitAuthenticator,
+		InitCaptcha,
+		InitLoginLock,
+		InitOIDC,
+		InitOIDCSessionStore,
 		InitCasbin,
+		InitCasbinWatcher,
+		InitCasbinPolicy,
 		InitGinEngine,
 		bll.BllSet,
 		api.APISet,
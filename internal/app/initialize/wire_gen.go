@@ -1,7 +1,8 @@
 // Code generated by Wire. DO NOT EDIT.
 
 //go:generate wire
-//+build !wireinject
+//go:build !wireinject
+// +build !wireinject
 
 package initialize
 
@@ -18,7 +19,11 @@ import (
 // Injectors from wire.go:
 
 func BuildInjector() (*Injector, func(), error) {
-	auther, cleanup, err := InitAuth()
+	keySet, err := InitKeySet()
+	if err != nil {
+		return nil, nil, err
+	}
+	auther, cleanup, err := InitAuth(keySet)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -58,36 +63,145 @@ func BuildInjector() (*Injector, func(), error) {
 	demo := &model.Demo{
 		DB: db,
 	}
+	userOrg := &model.UserOrg{
+		DB: db,
+	}
+	dataScope := &bll.DataScope{
+		UserModel:    user,
+		UserOrgModel: userOrg,
+		RoleModel:    role,
+	}
 	bllDemo := &bll.Demo{
+		DataScope: dataScope,
 		DemoModel: demo,
 	}
 	apiDemo := &api.Demo{
 		DemoBll: bllDemo,
 	}
 	mockDemo := &mock.Demo{}
+	jwks := &api.JWKS{
+		KeySet: keySet,
+	}
+	mockJWKS := &mock.JWKS{}
 	menu := &model.Menu{
 		DB: db,
 	}
 	menuAction := &model.MenuAction{
 		DB: db,
 	}
+	watcher, cleanup4, err := InitCasbinWatcher()
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	casbinPolicy, cleanup5, err := InitCasbinPolicy(syncedEnforcer, casbinAdapter, watcher)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager, err := InitPassword()
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	chain, cleanup6, err := InitAuthenticator(user, manager)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	trans := &model.Trans{
+		DB: db,
+	}
+	counterCounter, cleanup7, err := InitCaptcha()
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	locker, cleanup8, err := InitLoginLock()
+	if err != nil {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	client := InitOIDC()
+	policy := InitPasswordPolicy()
+	passwordHistory := &model.PasswordHistory{
+		DB: db,
+	}
+	passwordPolicy := &bll.PasswordPolicy{
+		Policy:               policy,
+		PasswordManager:      manager,
+		PasswordHistoryModel: passwordHistory,
+	}
+	passwordReset := &model.PasswordReset{
+		DB: db,
+	}
+	sender := InitMailSender()
+	sessionStore, cleanup9, err := InitOIDCSessionStore()
+	if err != nil {
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	tenant := &model.Tenant{
+		DB: db,
+	}
 	login := &bll.Login{
-		Auth:            auther,
-		UserModel:       user,
-		UserRoleModel:   userRole,
-		RoleModel:       role,
-		RoleMenuModel:   roleMenu,
-		MenuModel:       menu,
-		MenuActionModel: menuAction,
+		CasbinPolicy:       casbinPolicy,
+		Auth:               auther,
+		Authenticator:      chain,
+		TransModel:         trans,
+		UserModel:          user,
+		UserRoleModel:      userRole,
+		RoleModel:          role,
+		RoleMenuModel:      roleMenu,
+		TenantModel:        tenant,
+		MenuModel:          menu,
+		MenuActionModel:    menuAction,
+		PasswordManager:    manager,
+		PasswordPolicy:     passwordPolicy,
+		PasswordResetModel: passwordReset,
+		MailSender:         sender,
+		FailureCounter:     counterCounter,
+		LoginLocker:        locker,
+		OIDCClient:         client,
+		OIDCStore:          sessionStore,
 	}
 	apiLogin := &api.Login{
 		LoginBll: login,
 	}
 	mockLogin := &mock.Login{}
-	trans := &model.Trans{
-		DB: db,
-	}
 	bllMenu := &bll.Menu{
+		CasbinPolicy:            casbinPolicy,
 		TransModel:              trans,
 		MenuModel:               menu,
 		MenuActionModel:         menuAction,
@@ -97,39 +211,113 @@ func BuildInjector() (*Injector, func(), error) {
 		MenuBll: bllMenu,
 	}
 	mockMenu := &mock.Menu{}
+	org := &model.Org{
+		DB: db,
+	}
+	bllOrg := &bll.Org{
+		TransModel: trans,
+		OrgModel:   org,
+		UserModel:  user,
+	}
+	apiOrg := &api.Org{
+		OrgBll: bllOrg,
+	}
+	mockOrg := &mock.Org{}
+	permission := &bll.Permission{
+		CasbinPolicy:            casbinPolicy,
+		UserModel:               user,
+		UserRoleModel:           userRole,
+		RoleModel:               role,
+		RoleMenuModel:           roleMenu,
+		MenuModel:               menu,
+		MenuActionModel:         menuAction,
+		MenuActionResourceModel: menuActionResource,
+	}
+	apiPermission := &api.Permission{
+		PermissionBll: permission,
+	}
+	mockPermission := &mock.Permission{}
 	bllRole := &bll.Role{
-		Enforcer:      syncedEnforcer,
+		CasbinPolicy:  casbinPolicy,
 		TransModel:    trans,
 		RoleModel:     role,
 		RoleMenuModel: roleMenu,
 		UserModel:     user,
+		Permission:    permission,
 	}
 	apiRole := &api.Role{
 		RoleBll: bllRole,
 	}
 	mockRole := &mock.Role{}
+	bllTenant := &bll.Tenant{
+		TenantModel: tenant,
+		RoleModel:   role,
+	}
+	apiTenant := &api.Tenant{
+		TenantBll: bllTenant,
+	}
+	mockTenant := &mock.Tenant{}
+	apiToken := &model.APIToken{
+		DB: db,
+	}
 	bllUser := &bll.User{
-		Enforcer:      syncedEnforcer,
-		TransModel:    trans,
-		UserModel:     user,
-		UserRoleModel: userRole,
-		RoleModel:     role,
+		CasbinPolicy:         casbinPolicy,
+		DataScope:            dataScope,
+		Auth:                 auther,
+		TransModel:           trans,
+		UserModel:            user,
+		UserRoleModel:        userRole,
+		UserOrgModel:         userOrg,
+		OrgModel:             org,
+		RoleModel:            role,
+		APITokenModel:        apiToken,
+		PasswordHistoryModel: passwordHistory,
+		PasswordResetModel:   passwordReset,
+		LoginLocker:          locker,
+		PasswordPolicy:       passwordPolicy,
+		Permission:           permission,
 	}
 	apiUser := &api.User{
 		UserBll: bllUser,
 	}
 	mockUser := &mock.User{}
+	bllAPIToken := &bll.APIToken{
+		CasbinPolicy:            casbinPolicy,
+		DataScope:               dataScope,
+		APITokenModel:           apiToken,
+		UserModel:               user,
+		UserRoleModel:           userRole,
+		RoleModel:               role,
+		RoleMenuModel:           roleMenu,
+		MenuActionModel:         menuAction,
+		MenuActionResourceModel: menuActionResource,
+	}
+	apiAPIToken := &api.APIToken{
+		APITokenBll: bllAPIToken,
+	}
+	mockAPIToken := &mock.APIToken{}
 	routerRouter := &router.Router{
 		Auth:           auther,
 		CasbinEnforcer: syncedEnforcer,
+		APITokenBll:    bllAPIToken,
+		APITokenAPI:    apiAPIToken,
+		APITokenMock:   mockAPIToken,
 		DemoAPI:        apiDemo,
 		DemoMock:       mockDemo,
+		JWKSAPI:        jwks,
+		JWKSMock:       mockJWKS,
 		LoginAPI:       apiLogin,
 		LoginMock:      mockLogin,
 		MenuAPI:        apiMenu,
 		MenuMock:       mockMenu,
+		OrgAPI:         apiOrg,
+		OrgMock:        mockOrg,
+		PermissionAPI:  apiPermission,
+		PermissionMock: mockPermission,
 		RoleAPI:        apiRole,
 		RoleMock:       mockRole,
+		TenantAPI:      apiTenant,
+		TenantMock:     mockTenant,
 		UserAPI:        apiUser,
 		UserMock:       mockUser,
 	}
@@ -142,9 +330,16 @@ func BuildInjector() (*Injector, func(), error) {
 		Engine:         engine,
 		Auth:           auther,
 		CasbinEnforcer: syncedEnforcer,
+		CasbinPolicy:   casbinPolicy,
 		Menu:           dataMenu,
 	}
 	return injector, func() {
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
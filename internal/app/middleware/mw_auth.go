@@ -1,27 +1,83 @@
 package middleware
 
 import (
+	"strings"
+
+	"github.com/wangwei518/gin-admin/internal/app/bll"
 	"github.com/wangwei518/gin-admin/internal/app/config"
 	icontext "github.com/wangwei518/gin-admin/internal/app/context"
 	"github.com/wangwei518/gin-admin/internal/app/ginplus"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"github.com/wangwei518/gin-admin/pkg/auth"
 	"github.com/wangwei518/gin-admin/pkg/errors"
 	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/casbin/casbin/v2/util"
 	"github.com/gin-gonic/gin"
 )
 
-func wrapUserAuthContext(c *gin.Context, userID string) {
+func wrapUserAuthContext(c *gin.Context, userID, view, tenantID, actorID string) {
+	if view == "" {
+		view = config.C.JWTAuth.DefaultView
+	}
+
 	ginplus.SetUserID(c, userID)
+	ginplus.SetView(c, view)
+	ginplus.SetTenantID(c, tenantID)
 	ctx := icontext.NewUserID(c.Request.Context(), userID)
+	ctx = icontext.NewView(ctx, view)
+	// root用户未切换租户时可以访问全部租户的数据
+	if tenantID != "" || userID != config.C.Root.UserName {
+		ctx = icontext.NewTenantID(ctx, tenantID)
+	}
 	ctx = logger.NewUserIDContext(ctx, userID)
+	if actorID != "" {
+		ginplus.SetActorID(c, actorID)
+		ctx = icontext.NewActorID(ctx, actorID)
+		ctx = logger.NewActorIDContext(ctx, actorID)
+	}
 	c.Request = c.Request.WithContext(ctx)
 }
 
-// UserAuthMiddleware 用户授权中间件
-func UserAuthMiddleware(a auth.Auther, skippers ...SkipperFunc) gin.HandlerFunc {
+// 校验访问令牌，并检查请求是否在令牌的授权范围内
+func apiTokenAuth(c *gin.Context, t bll.IAPIToken, token string) {
+	result, err := t.Verify(c.Request.Context(), token)
+	if err != nil {
+		ginplus.ResError(c, err)
+		return
+	}
+
+	p := c.Request.URL.Path
+	m := c.Request.Method
+	var allowed bool
+	for _, item := range result.Resources {
+		// 拒绝规则及访问条件由casbin中间件判定
+		if item.GetEffect() != schema.ResourceEffectAllow {
+			continue
+		}
+		if util.KeyMatch2(p, item.Path) && util.RegexMatch(m, item.Method) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		ginplus.ResError(c, errors.ErrNoPerm)
+		return
+	}
+
+	wrapUserAuthContext(c, result.UserID, schema.ViewPartner, result.TenantID, "")
+	c.Next()
+}
+
+// UserAuthMiddleware 用户授权中间件(支持JWT及访问令牌)
+func UserAuthMiddleware(a auth.Auther, t bll.IAPIToken, skippers ...SkipperFunc) gin.HandlerFunc {
 	if !config.C.JWTAuth.Enable {
 		return func(c *gin.Context) {
-			wrapUserAuthContext(c, config.C.Root.UserName)
+			if token := ginplus.GetToken(c); strings.HasPrefix(token, schema.APITokenPrefix) && !SkipHandler(c, skippers...) {
+				apiTokenAuth(c, t, token)
+				return
+			}
+
+			wrapUserAuthContext(c, config.C.Root.UserName, "", "", "")
 			c.Next()
 		}
 	}
@@ -32,11 +88,16 @@ func UserAuthMiddleware(a auth.Auther, skippers ...SkipperFunc) gin.HandlerFunc
 			return
 		}
 
-		userID, _, err := a.ParseUserID(c.Request.Context(), ginplus.GetToken(c))
+		if token := ginplus.GetToken(c); strings.HasPrefix(token, schema.APITokenPrefix) {
+			apiTokenAuth(c, t, token)
+			return
+		}
+
+		claims, err := a.ParseToken(c.Request.Context(), ginplus.GetToken(c))
 		if err != nil {
 			if err == auth.ErrInvalidToken {
 				if config.C.IsDebugMode() {
-					wrapUserAuthContext(c, config.C.Root.UserName)
+					wrapUserAuthContext(c, config.C.Root.UserName, "", "", "")
 					c.Next()
 					return
 				}
@@ -45,9 +106,38 @@ func UserAuthMiddleware(a auth.Auther, skippers ...SkipperFunc) gin.HandlerFunc
 			}
 			ginplus.ResError(c, errors.WithStack(err))
 			return
+		} else if view := claims.View; view != "" && view != schema.ViewPasswordChange && !config.C.JWTAuth.HasView(view) {
+			// 视图已停用(或令牌签发于视图启用之前)，需要重新登录
+			ginplus.ResError(c, errors.ErrInvalidToken)
+			return
 		}
 
-		wrapUserAuthContext(c, userID)
+		wrapUserAuthContext(c, claims.UserID, claims.View, claims.TenantID, claims.ActorID)
 		c.Next()
 	}
 }
+
+// NoImpersonationMiddleware 禁止模拟登录访问的中间件(修改密码、多因素认证等只能由用户本人操作)
+func NoImpersonationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ginplus.GetActorID(c) != "" {
+			ginplus.ResError(c, errors.ErrNoPerm)
+			return
+		}
+		c.Next()
+	}
+}
+
+// ViewMiddleware 令牌视图中间件(路由组声明其接受的视图，其他视图签发的令牌无权访问)
+func ViewMiddleware(views ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		view := ginplus.GetView(c)
+		for _, v := range views {
+			if v == view {
+				c.Next()
+				return
+			}
+		}
+		ginplus.ResError(c, errors.ErrNoPerm)
+	}
+}
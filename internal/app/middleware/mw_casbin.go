@@ -3,6 +3,7 @@ package middleware
 import (
 	"github.com/wangwei518/gin-admin/internal/app/config"
 	"github.com/wangwei518/gin-admin/internal/app/ginplus"
+	"github.com/wangwei518/gin-admin/pkg/abac"
 	"github.com/wangwei518/gin-admin/pkg/errors"
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
@@ -23,7 +24,8 @@ func CasbinMiddleware(enforcer *casbin.SyncedEnforcer, skippers ...SkipperFunc)
 
 		p := c.Request.URL.Path
 		m := c.Request.Method
-		if b, err := enforcer.Enforce(ginplus.GetUserID(c), p, m); err != nil {
+		env := abac.NewRequestEnv(c.Request, c.ClientIP())
+		if b, err := enforcer.Enforce(ginplus.GetUserID(c), ginplus.GetTenantID(c), p, m, ginplus.GetView(c), env); err != nil {
 			ginplus.ResError(c, errors.WithStack(err))
 			return
 		} else if !b {
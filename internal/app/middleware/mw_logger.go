@@ -63,6 +63,9 @@ func LoggerMiddleware(skippers ...SkipperFunc) gin.HandlerFunc {
 		}
 
 		fields[logger.UserIDKey] = ginplus.GetUserID(c)
+		if v := ginplus.GetActorID(c); v != "" {
+			fields[logger.ActorIDKey] = v
+		}
 		span.WithFields(fields).Infof("[http] %s-%s-%s-%d(%dms)",
 			p, c.Request.Method, c.ClientIP(), c.Writer.Status(), timeConsuming)
 	}
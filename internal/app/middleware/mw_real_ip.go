@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// RealIPMiddleware 客户端IP中间件
+// 只有直接连接的地址属于受信任的代理时，才使用X-Forwarded-For(从右向左第一个不受信任的地址)或X-Real-Ip中的客户端IP，
+// 并替换请求的连接地址；其他请求的客户端IP始终为连接地址，避免客户端通过请求头伪造IP
+func RealIPMiddleware() gin.HandlerFunc {
+	proxies := parseTrustedProxies(config.C.HTTP.TrustedProxies)
+	return func(c *gin.Context) {
+		if len(proxies) > 0 {
+			if ip := forwardedIP(c.Request.RemoteAddr, c.GetHeader("X-Forwarded-For"), c.GetHeader("X-Real-Ip"), proxies); ip != "" {
+				c.Request.RemoteAddr = net.JoinHostPort(ip, "0")
+			}
+		}
+		c.Next()
+	}
+}
+
+// 解析受信任的代理地址(IP或CIDR)，忽略无效的配置
+func parseTrustedProxies(items []string) []*net.IPNet {
+	var proxies []*net.IPNet
+	for _, s := range items {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if !strings.Contains(s, "/") {
+			if ip := net.ParseIP(s); ip != nil {
+				bits := 8 * net.IPv6len
+				if ip.To4() != nil {
+					bits = 8 * net.IPv4len
+				}
+				proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+				continue
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			logger.Warnf(context.Background(), "无效的受信任代理地址[%s]", s)
+			continue
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies
+}
+
+func isTrustedProxy(ip net.IP, proxies []*net.IPNet) bool {
+	for _, item := range proxies {
+		if item.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// 获取受信任的代理转发的客户端IP(连接地址不是受信任的代理时返回空)
+func forwardedIP(remoteAddr, forwardedFor, realIP string, proxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(remoteAddr))
+	if err != nil {
+		host = strings.TrimSpace(remoteAddr)
+	}
+	if ip := net.ParseIP(host); ip == nil || !isTrustedProxy(ip, proxies) {
+		return ""
+	}
+
+	// 代理依次追加连接地址，从右向左跳过受信任的代理，第一个不受信任的地址即客户端IP
+	var client string
+	items := strings.Split(forwardedFor, ",")
+	for i := len(items) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(items[i]))
+		if ip == nil {
+			break
+		}
+		client = ip.String()
+		if !isTrustedProxy(ip, proxies) {
+			return client
+		}
+	}
+	if client != "" {
+		return client
+	}
+
+	if ip := net.ParseIP(strings.TrimSpace(realIP)); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+// GetAPITokenDB 获取访问令牌存储
+func GetAPITokenDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return getDBWithModel(ctx, defDB, new(APIToken))
+}
+
+// SchemaAPIToken 访问令牌对象
+type SchemaAPIToken schema.APIToken
+
+// ToAPIToken 转换为访问令牌实体
+func (a SchemaAPIToken) ToAPIToken() *APIToken {
+	item := new(APIToken)
+	util.StructMapToStruct(a, item)
+	item.Actions = strings.Join(a.ActionIDs, ",")
+	return item
+}
+
+// APIToken 访问令牌实体
+type APIToken struct {
+	Model
+	TenantID   string     `gorm:"column:tenant_id;size:36;index;default:'';not null;"`  // 租户ID
+	UserID     string     `gorm:"column:user_id;size:36;index;default:'';not null;"`    // 所属用户ID
+	Name       string     `gorm:"column:name;size:100;default:'';not null;"`            // 令牌名称
+	Prefix     string     `gorm:"column:prefix;size:20;default:'';not null;"`           // 令牌前缀
+	TokenHash  string     `gorm:"column:token_hash;size:64;index;default:'';not null;"` // 令牌哈希值
+	Actions    string     `gorm:"column:actions;type:text;"`                            // 授权的菜单动作ID(逗号分隔)
+	ExpiresAt  *time.Time `gorm:"column:expires_at;"`                                   // 过期时间
+	LastUsedAt *time.Time `gorm:"column:last_used_at;"`                                 // 最后使用时间
+	Creator    string     `gorm:"column:creator;size:36;"`                              // 创建者
+}
+
+func (a APIToken) String() string {
+	return toString(a)
+}
+
+// TableName 表名
+func (a APIToken) TableName() string {
+	return a.Model.TableName("api_token")
+}
+
+// ToSchemaAPIToken 转换为访问令牌对象
+func (a APIToken) ToSchemaAPIToken() *schema.APIToken {
+	item := new(schema.APIToken)
+	util.StructMapToStruct(a, item)
+	if a.Actions != "" {
+		item.ActionIDs = strings.Split(a.Actions, ",")
+	}
+	return item
+}
+
+// APITokens 访问令牌实体列表
+type APITokens []*APIToken
+
+// ToSchemaAPITokens 转换为访问令牌对象列表
+func (a APITokens) ToSchemaAPITokens() []*schema.APIToken {
+	list := make([]*schema.APIToken, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaAPIToken()
+	}
+	return list
+}
@@ -26,9 +26,14 @@ func (a SchemaMenuActionResource) ToMenuActionResource() *MenuActionResource {
 // MenuActionResource 菜单动作关联资源实体
 type MenuActionResource struct {
 	Model
-	ActionID string `gorm:"column:action_id;size:36;index;default:'';not null;"` // 菜单动作ID
-	Method   string `gorm:"column:method;size:100;default:'';not null;"`         // 资源请求方式(支持正则)
-	Path     string `gorm:"column:path;size:100;default:'';not null;"`           // 资源请求路径（支持/:id匹配）
+	ActionID   string  `gorm:"column:action_id;size:36;index;default:'';not null;"` // 菜单动作ID
+	Method     string  `gorm:"column:method;size:100;default:'';not null;"`         // 资源请求方式(支持正则)
+	Path       string  `gorm:"column:path;size:100;default:'';not null;"`           // 资源请求路径（支持/:id匹配）
+	View       string  `gorm:"column:view;size:50;default:'';not null;"`            // 资源允许的令牌视图(为空表示不限制)
+	Effect     *string `gorm:"column:effect;size:10;"`                              // 授权效果(allow:允许 deny:拒绝，为空表示允许)
+	IPRanges   *string `gorm:"column:ip_ranges;size:1024;"`                         // 客户端IP范围条件
+	TimeWindow *string `gorm:"column:time_window;size:20;"`                         // 时间窗口条件
+	Attributes *string `gorm:"column:attributes;size:1024;"`                        // 请求属性条件
 }
 
 func (a MenuActionResource) String() string {
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"github.com/jinzhu/gorm"
+)
+
+// GetOrgDB 获取部门存储
+func GetOrgDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return getDBWithModel(ctx, defDB, new(Org))
+}
+
+// SchemaOrg 部门对象
+type SchemaOrg schema.Org
+
+// ToOrg 转换为部门实体
+func (a SchemaOrg) ToOrg() *Org {
+	item := new(Org)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Org 部门实体
+type Org struct {
+	Model
+	TenantID   string  `gorm:"column:tenant_id;size:36;index;default:'';not null;"` // 租户ID
+	Name       string  `gorm:"column:name;size:100;index;default:'';not null;"`     // 部门名称
+	Sequence   int     `gorm:"column:sequence;index;default:0;not null;"`           // 排序值
+	ParentID   *string `gorm:"column:parent_id;size:36;index;"`                     // 父级内码
+	ParentPath *string `gorm:"column:parent_path;size:518;index;"`                  // 父级路径
+	Leader     *string `gorm:"column:leader;size:50;"`                              // 负责人
+	Phone      *string `gorm:"column:phone;size:20;"`                               // 联系电话
+	Status     int     `gorm:"column:status;index;default:0;not null;"`             // 状态(1:启用 2:禁用)
+	Memo       *string `gorm:"column:memo;size:1024;"`                              // 备注
+	Creator    string  `gorm:"column:creator;size:36;"`                             // 创建人
+}
+
+func (a Org) String() string {
+	return toString(a)
+}
+
+// TableName 表名
+func (a Org) TableName() string {
+	return a.Model.TableName("org")
+}
+
+// ToSchemaOrg 转换为部门对象
+func (a Org) ToSchemaOrg() *schema.Org {
+	item := new(schema.Org)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Orgs 部门实体列表
+type Orgs []*Org
+
+// ToSchemaOrgs 转换为部门对象列表
+func (a Orgs) ToSchemaOrgs() []*schema.Org {
+	list := make([]*schema.Org, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaOrg()
+	}
+	return list
+}
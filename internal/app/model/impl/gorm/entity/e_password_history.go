@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+// GetPasswordHistoryDB 获取用户历史密码存储
+func GetPasswordHistoryDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return getDBWithModel(ctx, defDB, new(PasswordHistory))
+}
+
+// SchemaPasswordHistory 用户历史密码对象
+type SchemaPasswordHistory schema.PasswordHistory
+
+// ToPasswordHistory 转换为用户历史密码实体
+func (a SchemaPasswordHistory) ToPasswordHistory() *PasswordHistory {
+	item := new(PasswordHistory)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordHistory 用户历史密码实体
+type PasswordHistory struct {
+	Model
+	UserID   string `gorm:"column:user_id;size:36;index;default:'';not null;"` // 用户ID
+	Password string `gorm:"column:password;size:255;default:'';not null;"`     // 密码(bcrypt/argon2id哈希)
+}
+
+func (a PasswordHistory) String() string {
+	return toString(a)
+}
+
+// TableName 表名
+func (a PasswordHistory) TableName() string {
+	return a.Model.TableName("password_history")
+}
+
+// ToSchemaPasswordHistory 转换为用户历史密码对象
+func (a PasswordHistory) ToSchemaPasswordHistory() *schema.PasswordHistory {
+	item := new(schema.PasswordHistory)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordHistories 用户历史密码实体列表
+type PasswordHistories []*PasswordHistory
+
+// ToSchemaPasswordHistories 转换为用户历史密码对象列表
+func (a PasswordHistories) ToSchemaPasswordHistories() []*schema.PasswordHistory {
+	list := make([]*schema.PasswordHistory, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaPasswordHistory()
+	}
+	return list
+}
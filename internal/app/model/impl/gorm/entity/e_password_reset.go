@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+// GetPasswordResetDB 获取密码重置令牌存储
+func GetPasswordResetDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return getDBWithModel(ctx, defDB, new(PasswordReset))
+}
+
+// SchemaPasswordReset 密码重置令牌对象
+type SchemaPasswordReset schema.PasswordReset
+
+// ToPasswordReset 转换为密码重置令牌实体
+func (a SchemaPasswordReset) ToPasswordReset() *PasswordReset {
+	item := new(PasswordReset)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordReset 密码重置令牌实体
+type PasswordReset struct {
+	Model
+	UserID    string    `gorm:"column:user_id;size:36;index;default:'';not null;"`    // 用户ID
+	TokenHash string    `gorm:"column:token_hash;size:64;index;default:'';not null;"` // 令牌哈希值
+	ExpiresAt time.Time `gorm:"column:expires_at;"`                                   // 过期时间
+}
+
+func (a PasswordReset) String() string {
+	return toString(a)
+}
+
+// TableName 表名
+func (a PasswordReset) TableName() string {
+	return a.Model.TableName("password_reset")
+}
+
+// ToSchemaPasswordReset 转换为密码重置令牌对象
+func (a PasswordReset) ToSchemaPasswordReset() *schema.PasswordReset {
+	item := new(schema.PasswordReset)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordResets 密码重置令牌实体列表
+type PasswordResets []*PasswordReset
+
+// ToSchemaPasswordResets 转换为密码重置令牌对象列表
+func (a PasswordResets) ToSchemaPasswordResets() []*schema.PasswordReset {
+	list := make([]*schema.PasswordReset, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaPasswordReset()
+	}
+	return list
+}
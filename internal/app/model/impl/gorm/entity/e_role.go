@@ -2,6 +2,7 @@ package entity
 
 import (
 	"context"
+	"strings"
 
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"github.com/wangwei518/gin-admin/pkg/util"
@@ -20,17 +21,24 @@ type SchemaRole schema.Role
 func (a SchemaRole) ToRole() *Role {
 	item := new(Role)
 	util.StructMapToStruct(a, item)
+	dataOrgs := strings.Join(a.DataOrgIDs, ",")
+	item.DataOrgs = &dataOrgs
 	return item
 }
 
 // Role 角色实体
 type Role struct {
 	Model
-	Name     string  `gorm:"column:name;size:100;index;default:'';not null;"` // 角色名称
-	Sequence int     `gorm:"column:sequence;index;default:0;not null;"`       // 排序值
-	Memo     *string `gorm:"column:memo;size:1024;"`                          // 备注
-	Status   int     `gorm:"column:status;index;default:0;not null;"`         // 状态(1:启用 2:禁用)
-	Creator  string  `gorm:"column:creator;size:36;"`                         // 创建者
+	TenantID   string  `gorm:"column:tenant_id;size:36;index;default:'';not null;"` // 租户ID
+	Name       string  `gorm:"column:name;size:100;index;default:'';not null;"`     // 角色名称
+	Sequence   int     `gorm:"column:sequence;index;default:0;not null;"`           // 排序值
+	ParentID   *string `gorm:"column:parent_id;size:36;index;"`                     // 上级角色ID
+	Memo       *string `gorm:"column:memo;size:1024;"`                              // 备注
+	Status     int     `gorm:"column:status;index;default:0;not null;"`             // 状态(1:启用 2:禁用)
+	RequireMFA int     `gorm:"column:require_mfa;default:0;not null;"`              // 是否要求多因素认证(1:要求 2:不要求)
+	DataScope  int     `gorm:"column:data_scope;default:0;not null;"`               // 数据范围(1:全部 2:本人创建 3:本部门 4:自定义部门)
+	DataOrgs   *string `gorm:"column:data_orgs;size:2048;"`                         // 自定义数据范围的部门ID(逗号分隔)
+	Creator    string  `gorm:"column:creator;size:36;"`                             // 创建者
 }
 
 func (a Role) String() string {
@@ -46,6 +54,9 @@ func (a Role) TableName() string {
 func (a Role) ToSchemaRole() *schema.Role {
 	item := new(schema.Role)
 	util.StructMapToStruct(a, item)
+	if a.DataOrgs != nil && *a.DataOrgs != "" {
+		item.DataOrgIDs = strings.Split(*a.DataOrgs, ",")
+	}
 	return item
 }
 
@@ -26,6 +26,7 @@ func (a SchemaRoleMenu) ToRoleMenu() *RoleMenu {
 // RoleMenu 角色菜单实体
 type RoleMenu struct {
 	Model
+	TenantID string `gorm:"column:tenant_id;size:36;index;default:'';not null;"` // 租户ID
 	RoleID   string `gorm:"column:role_id;size:36;index;default:'';not null;"`   // 角色ID
 	MenuID   string `gorm:"column:menu_id;size:36;index;default:'';not null;"`   // 菜单ID
 	ActionID string `gorm:"column:action_id;size:36;index;default:'';not null;"` // 动作ID
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"github.com/jinzhu/gorm"
+)
+
+// GetTenantDB 获取租户存储
+func GetTenantDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return getDBWithModel(ctx, defDB, new(Tenant))
+}
+
+// SchemaTenant 租户对象
+type SchemaTenant schema.Tenant
+
+// ToTenant 转换为租户实体
+func (a SchemaTenant) ToTenant() *Tenant {
+	item := new(Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenant 租户实体
+type Tenant struct {
+	Model
+	Code    string  `gorm:"column:code;size:50;index;default:'';not null;"`  // 编号
+	Name    string  `gorm:"column:name;size:100;index;default:'';not null;"` // 名称
+	Memo    *string `gorm:"column:memo;size:200;"`                           // 备注
+	Status  int     `gorm:"column:status;index;default:0;not null;"`         // 状态(1:启用 2:停用)
+	Creator string  `gorm:"column:creator;size:36;"`                         // 创建者
+}
+
+func (a Tenant) String() string {
+	return toString(a)
+}
+
+// TableName 表名
+func (a Tenant) TableName() string {
+	return a.Model.TableName("tenant")
+}
+
+// ToSchemaTenant 转换为租户对象
+func (a Tenant) ToSchemaTenant() *schema.Tenant {
+	item := new(schema.Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenants 租户列表
+type Tenants []*Tenant
+
+// ToSchemaTenants 转换为租户对象列表
+func (a Tenants) ToSchemaTenants() []*schema.Tenant {
+	list := make([]*schema.Tenant, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaTenant()
+	}
+	return list
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"context"
+	"time"
 
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"github.com/wangwei518/gin-admin/pkg/util"
@@ -26,13 +27,23 @@ func (a SchemaUser) ToUser() *User {
 // User 用户实体
 type User struct {
 	Model
-	UserName string  `gorm:"column:user_name;size:64;index;default:'';not null;"` // 用户名
-	RealName string  `gorm:"column:real_name;size:64;index;default:'';not null;"` // 真实姓名
-	Password string  `gorm:"column:password;size:40;default:'';not null;"`        // 密码(sha1(md5(明文))加密)
-	Email    *string `gorm:"column:email;size:255;index;"`                        // 邮箱
-	Phone    *string `gorm:"column:phone;size:20;index;"`                         // 手机号
-	Status   int     `gorm:"column:status;index;default:0;not null;"`             // 状态(1:启用 2:停用)
-	Creator  string  `gorm:"column:creator;size:36;"`                             // 创建者
+	UserName           string     `gorm:"column:user_name;size:64;index;default:'';not null;"` // 用户名
+	RealName           string     `gorm:"column:real_name;size:64;index;default:'';not null;"` // 真实姓名
+	Password           string     `gorm:"column:password;size:255;default:'';not null;"`       // 密码(bcrypt/argon2id哈希)
+	Email              *string    `gorm:"column:email;size:255;index;"`                        // 邮箱
+	Phone              *string    `gorm:"column:phone;size:20;index;"`                         // 手机号
+	Status             int        `gorm:"column:status;index;default:0;not null;"`             // 状态(1:启用 2:停用)
+	Type               int        `gorm:"column:type;index;default:1;not null;"`               // 类型(1:普通用户 2:服务账号)
+	OrgID              string     `gorm:"column:org_id;size:36;index;default:'';not null;"`    // 所属部门ID
+	Provider           string     `gorm:"column:provider;size:32;default:'';not null;"`        // 外部认证来源(ldap/oidc，为空表示本地用户)
+	Subject            string     `gorm:"column:subject;size:255;index;default:'';not null;"`  // 外部认证的用户标识
+	MFAEnabled         int        `gorm:"column:mfa_enabled;default:0;not null;"`              // 多因素认证状态(1:启用 2:未启用)
+	MFASecret          string     `gorm:"column:mfa_secret;size:64;default:'';not null;"`      // 多因素认证密钥
+	MFARecoveryCodes   string     `gorm:"column:mfa_recovery_codes;size:1024;"`                // 多因素认证恢复码(哈希值)
+	MFALastStep        int64      `gorm:"column:mfa_last_step;default:0;not null;"`            // 多因素认证最后使用的时间步长
+	MustChangePassword int        `gorm:"column:must_change_password;default:0;not null;"`     // 下次登录是否必须修改密码(1:是 2:否)
+	PasswordChangedAt  *time.Time `gorm:"column:password_changed_at;"`                         // 密码修改时间
+	Creator            string     `gorm:"column:creator;size:36;"`                             // 创建者
 }
 
 func (a User) String() string {
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"github.com/jinzhu/gorm"
+)
+
+// GetUserOrgDB 获取用户兼任部门存储
+func GetUserOrgDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	return getDBWithModel(ctx, defDB, new(UserOrg))
+}
+
+// SchemaUserOrg 用户兼任部门
+type SchemaUserOrg schema.UserOrg
+
+// ToUserOrg 转换为用户兼任部门实体
+func (a SchemaUserOrg) ToUserOrg() *UserOrg {
+	item := new(UserOrg)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// UserOrg 用户兼任部门实体
+type UserOrg struct {
+	Model
+	TenantID string `gorm:"column:tenant_id;size:36;index;default:'';not null;"` // 租户ID
+	UserID   string `gorm:"column:user_id;size:36;index;default:'';not null;"`   // 用户内码
+	OrgID    string `gorm:"column:org_id;size:36;index;default:'';not null;"`    // 部门内码
+}
+
+// TableName 表名
+func (a UserOrg) TableName() string {
+	return a.Model.TableName("user_org")
+}
+
+// ToSchemaUserOrg 转换为用户兼任部门对象
+func (a UserOrg) ToSchemaUserOrg() *schema.UserOrg {
+	item := new(schema.UserOrg)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// UserOrgs 用户兼任部门列表
+type UserOrgs []*UserOrg
+
+// ToSchemaUserOrgs 转换为用户兼任部门对象列表
+func (a UserOrgs) ToSchemaUserOrgs() []*schema.UserOrg {
+	list := make([]*schema.UserOrg, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaUserOrg()
+	}
+	return list
+}
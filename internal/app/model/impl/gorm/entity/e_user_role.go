@@ -26,8 +26,9 @@ func (a SchemaUserRole) ToUserRole() *UserRole {
 // UserRole 用户角色关联实体
 type UserRole struct {
 	Model
-	UserID string `gorm:"column:user_id;size:36;index;default:'';not null;"` // 用户内码
-	RoleID string `gorm:"column:role_id;size:36;index;default:'';not null;"` // 角色内码
+	TenantID string `gorm:"column:tenant_id;size:36;index;default:'';not null;"` // 租户ID
+	UserID   string `gorm:"column:user_id;size:36;index;default:'';not null;"`   // 用户内码
+	RoleID   string `gorm:"column:role_id;size:36;index;default:'';not null;"`   // 角色内码
 }
 
 // TableName 表名
@@ -30,20 +30,61 @@ func toString(v interface{}) string {
 }
 
 func getDB(ctx context.Context, defDB *gorm.DB) *gorm.DB {
+	db := defDB
 	trans, ok := icontext.FromTrans(ctx)
 	if ok && !icontext.FromNoTrans(ctx) {
-		db, ok := trans.(*gorm.DB)
-		if ok {
+		if tdb, ok := trans.(*gorm.DB); ok {
+			db = tdb
 			if icontext.FromTransLock(ctx) {
 				if dbType := config.C.Gorm.DBType; dbType == "mysql" ||
 					dbType == "postgres" {
 					db = db.Set("gorm:query_option", "FOR UPDATE")
 				}
 			}
-			return db
 		}
 	}
-	return defDB
+
+	// 按照上下文中的租户过滤数据(由租户回调处理包含租户ID字段的实体)
+	if tenantID, ok := icontext.FromTenantID(ctx); ok {
+		db = db.Set(tenantIDKey, tenantID)
+	}
+	return db
+}
+
+// 存储租户ID的键
+const tenantIDKey = "gin-admin:tenant_id"
+
+func init() {
+	callback := gorm.DefaultCallback
+	callback.Create().Before("gorm:create").Register("gin-admin:tenant_create", tenantCreateCallback)
+	callback.Query().Before("gorm:query").Register("gin-admin:tenant_query", tenantWhereCallback)
+	callback.RowQuery().Before("gorm:row_query").Register("gin-admin:tenant_row_query", tenantWhereCallback)
+	callback.Update().Before("gorm:update").Register("gin-admin:tenant_update", tenantWhereCallback)
+	callback.Delete().Before("gorm:delete").Register("gin-admin:tenant_delete", tenantWhereCallback)
+}
+
+// 创建数据时使用上下文中的租户ID(未指定租户时保留数据中的租户ID)
+func tenantCreateCallback(scope *gorm.Scope) {
+	tenantID, ok := scope.Get(tenantIDKey)
+	if !ok {
+		return
+	}
+
+	if field, ok := scope.FieldByName("TenantID"); ok {
+		_ = field.Set(tenantID)
+	}
+}
+
+// 查询、更新及删除数据时只处理当前租户的数据
+func tenantWhereCallback(scope *gorm.Scope) {
+	tenantID, ok := scope.Get(tenantIDKey)
+	if !ok {
+		return
+	}
+
+	if field, ok := scope.FieldByName("TenantID"); ok {
+		scope.Search.Where(fmt.Sprintf("%s.%s = ?", scope.QuotedTableName(), scope.Quote(field.DBName)), tenantID)
+	}
 }
 
 func getDBWithModel(ctx context.Context, defDB *gorm.DB, m interface{}) *gorm.DB {
@@ -53,8 +94,9 @@ func getDBWithModel(ctx context.Context, defDB *gorm.DB, m interface{}) *gorm.DB
 		TableName() string
 	}
 
+	// 同时指定Model，计数及更新时才会排除软删除的数据
 	if t, ok := m.(tabler); ok {
-		return db.Table(t.TableName())
+		return db.Table(t.TableName()).Model(m)
 	}
 	return db.Model(m)
 }
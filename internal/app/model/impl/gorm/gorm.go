@@ -62,12 +62,18 @@ func AutoMigrate(db *gorm.DB) error {
 	}
 
 	return db.AutoMigrate(
+		new(entity.APIToken),
 		new(entity.Demo),
 		new(entity.MenuAction),
 		new(entity.MenuActionResource),
+		new(entity.PasswordHistory),
+		new(entity.PasswordReset),
 		new(entity.Menu),
+		new(entity.Org),
 		new(entity.RoleMenu),
 		new(entity.Role),
+		new(entity.Tenant),
+		new(entity.UserOrg),
 		new(entity.UserRole),
 		new(entity.User),
 	).Error
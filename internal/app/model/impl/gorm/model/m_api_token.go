@@ -0,0 +1,110 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+)
+
+var _ model.IAPIToken = (*APIToken)(nil)
+
+// APITokenSet 注入APIToken
+var APITokenSet = wire.NewSet(wire.Struct(new(APIToken), "*"), wire.Bind(new(model.IAPIToken), new(*APIToken)))
+
+// APIToken 访问令牌存储
+type APIToken struct {
+	DB *gorm.DB
+}
+
+func (a *APIToken) getQueryOption(opts ...schema.APITokenQueryOptions) schema.APITokenQueryOptions {
+	var opt schema.APITokenQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *APIToken) Query(ctx context.Context, params schema.APITokenQueryParam, opts ...schema.APITokenQueryOptions) (*schema.APITokenQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetAPITokenDB(ctx, a.DB)
+	if v := params.UserID; v != "" {
+		db = db.Where("user_id=?", v)
+	}
+	if v := params.TokenHash; v != "" {
+		db = db.Where("token_hash=?", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.APITokens
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.APITokenQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaAPITokens(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *APIToken) Get(ctx context.Context, recordID string, opts ...schema.APITokenQueryOptions) (*schema.APIToken, error) {
+	db := entity.GetAPITokenDB(ctx, a.DB).Where("record_id=?", recordID)
+	var item entity.APIToken
+	ok, err := FindOne(ctx, db, &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaAPIToken(), nil
+}
+
+// Create 创建数据
+func (a *APIToken) Create(ctx context.Context, item schema.APIToken) error {
+	eitem := entity.SchemaAPIToken(item).ToAPIToken()
+	result := entity.GetAPITokenDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *APIToken) Delete(ctx context.Context, recordID string) error {
+	result := entity.GetAPITokenDB(ctx, a.DB).Where("record_id=?", recordID).Delete(entity.APIToken{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *APIToken) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetAPITokenDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.APIToken{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateLastUsed 更新最后使用时间
+func (a *APIToken) UpdateLastUsed(ctx context.Context, recordID string, lastUsedAt time.Time) error {
+	result := entity.GetAPITokenDB(ctx, a.DB).Where("record_id=?", recordID).UpdateColumn("last_used_at", lastUsedAt)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
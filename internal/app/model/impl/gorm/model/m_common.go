@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/entity"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"github.com/jinzhu/gorm"
 )
@@ -91,6 +92,53 @@ func Check(ctx context.Context, db *gorm.DB) (bool, error) {
 	return count > 0, nil
 }
 
+// WrapDataScope 按上下文中的数据范围过滤数据(本人创建的数据，以及范围内部门的用户创建的数据)
+// orgField 不为空时表示数据所属部门的字段，所属部门在范围内的数据同样可以访问
+func WrapDataScope(ctx context.Context, defDB, db *gorm.DB, orgField string) (*gorm.DB, error) {
+	scope, ok := icontext.FromDataScope(ctx)
+	if !ok {
+		return db, nil
+	}
+
+	userID, _ := icontext.FromUserID(ctx)
+	if len(scope.OrgIDs) == 0 {
+		return db.Where("creator=?", userID), nil
+	}
+
+	// 先查询出部门内的用户(MySQL不支持在更新和删除的子查询中引用同一张表)
+	var userIDs []string
+	err := entity.GetUserDB(ctx, defDB).
+		Where("deleted_at is null").
+		Where("org_id IN(?)", scope.OrgIDs).
+		Pluck("record_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	userIDs = append(userIDs, userID)
+
+	if orgField != "" {
+		return db.Where(fmt.Sprintf("creator IN(?) OR %s IN(?)", orgField), userIDs, scope.OrgIDs), nil
+	}
+	return db.Where("creator IN(?)", userIDs), nil
+}
+
+// WrapTenantUser 按上下文中的租户过滤用户(用户在租户中分配了角色即属于该租户，全部用户均属于默认租户)
+func WrapTenantUser(ctx context.Context, defDB, db *gorm.DB) (*gorm.DB, error) {
+	if tenantID, ok := icontext.FromTenantID(ctx); !ok || tenantID == "" {
+		return db, nil
+	}
+
+	// 先查询出租户内的用户(用户角色按照租户过滤)
+	var userIDs []string
+	err := entity.GetUserRoleDB(ctx, defDB).
+		Where("deleted_at is null").
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return db.Where("record_id IN(?)", userIDs), nil
+}
+
 // OrderFieldFunc 排序字段转换函数
 type OrderFieldFunc func(string) string
 
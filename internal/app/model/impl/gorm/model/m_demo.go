@@ -33,7 +33,10 @@ func (a *Demo) getQueryOption(opts ...schema.DemoQueryOptions) schema.DemoQueryO
 func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...schema.DemoQueryOptions) (*schema.DemoQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetDemoDB(ctx, a.DB)
+	db, err := WrapDataScope(ctx, a.DB, entity.GetDemoDB(ctx, a.DB), "")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	if v := params.Code; v != "" {
 		db = db.Where("code=?", v)
 	}
@@ -60,7 +63,11 @@ func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...
 
 // Get 查询指定数据
 func (a *Demo) Get(ctx context.Context, recordID string, opts ...schema.DemoQueryOptions) (*schema.Demo, error) {
-	db := entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID)
+	db, err := WrapDataScope(ctx, a.DB, entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID), "")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	var item entity.Demo
 	ok, err := FindOne(ctx, db, &item)
 	if err != nil {
@@ -85,7 +92,12 @@ func (a *Demo) Create(ctx context.Context, item schema.Demo) error {
 // Update 更新数据
 func (a *Demo) Update(ctx context.Context, recordID string, item schema.Demo) error {
 	eitem := entity.SchemaDemo(item).ToDemo()
-	result := entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID).Updates(eitem)
+	db, err := WrapDataScope(ctx, a.DB, entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID), "")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := db.Updates(eitem)
 	if err := result.Error; err != nil {
 		return errors.WithStack(err)
 	}
@@ -94,7 +106,12 @@ func (a *Demo) Update(ctx context.Context, recordID string, item schema.Demo) er
 
 // Delete 删除数据
 func (a *Demo) Delete(ctx context.Context, recordID string) error {
-	result := entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID).Delete(entity.Demo{})
+	db, err := WrapDataScope(ctx, a.DB, entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID), "")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := db.Delete(entity.Demo{})
 	if err := result.Error; err != nil {
 		return errors.WithStack(err)
 	}
@@ -103,7 +120,12 @@ func (a *Demo) Delete(ctx context.Context, recordID string) error {
 
 // UpdateStatus 更新状态
 func (a *Demo) UpdateStatus(ctx context.Context, recordID string, status int) error {
-	result := entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID).Update("status", status)
+	db, err := WrapDataScope(ctx, a.DB, entity.GetDemoDB(ctx, a.DB).Where("record_id=?", recordID), "")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := db.Update("status", status)
 	if err := result.Error; err != nil {
 		return errors.WithStack(err)
 	}
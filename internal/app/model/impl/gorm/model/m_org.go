@@ -0,0 +1,132 @@
+package model
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+)
+
+var _ model.IOrg = (*Org)(nil)
+
+// OrgSet 注入Org
+var OrgSet = wire.NewSet(wire.Struct(new(Org), "*"), wire.Bind(new(model.IOrg), new(*Org)))
+
+// Org 部门存储
+type Org struct {
+	DB *gorm.DB
+}
+
+func (a *Org) getQueryOption(opts ...schema.OrgQueryOptions) schema.OrgQueryOptions {
+	var opt schema.OrgQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Org) Query(ctx context.Context, params schema.OrgQueryParam, opts ...schema.OrgQueryOptions) (*schema.OrgQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetOrgDB(ctx, a.DB)
+	if v := params.RecordIDs; len(v) > 0 {
+		db = db.Where("record_id IN(?)", v)
+	}
+	if v := params.Name; v != "" {
+		db = db.Where("name=?", v)
+	}
+	if v := params.ParentID; v != nil {
+		db = db.Where("parent_id=?", *v)
+	}
+	if v := params.PrefixParentPath; v != "" {
+		db = db.Where("parent_path LIKE ?", v+"%")
+	}
+	if v := params.Status; v != 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.QueryValue; v != "" {
+		v = "%" + v + "%"
+		db = db.Where("name LIKE ? OR leader LIKE ? OR memo LIKE ?", v, v, v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.Orgs
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	qr := &schema.OrgQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaOrgs(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Org) Get(ctx context.Context, recordID string, opts ...schema.OrgQueryOptions) (*schema.Org, error) {
+	var item entity.Org
+	ok, err := FindOne(ctx, entity.GetOrgDB(ctx, a.DB).Where("record_id=?", recordID), &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaOrg(), nil
+}
+
+// Create 创建数据
+func (a *Org) Create(ctx context.Context, item schema.Org) error {
+	eitem := entity.SchemaOrg(item).ToOrg()
+	result := entity.GetOrgDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *Org) Update(ctx context.Context, recordID string, item schema.Org) error {
+	eitem := entity.SchemaOrg(item).ToOrg()
+	result := entity.GetOrgDB(ctx, a.DB).Where("record_id=?", recordID).Updates(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateParentPath 更新父级路径
+func (a *Org) UpdateParentPath(ctx context.Context, recordID, parentPath string) error {
+	result := entity.GetOrgDB(ctx, a.DB).Where("record_id=?", recordID).Update("parent_path", parentPath)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Org) Delete(ctx context.Context, recordID string) error {
+	result := entity.GetOrgDB(ctx, a.DB).Where("record_id=?", recordID).Delete(entity.Org{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Org) UpdateStatus(ctx context.Context, recordID string, status int) error {
+	result := entity.GetOrgDB(ctx, a.DB).Where("record_id=?", recordID).Update("status", status)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+)
+
+var _ model.IPasswordHistory = (*PasswordHistory)(nil)
+
+// PasswordHistorySet 注入PasswordHistory
+var PasswordHistorySet = wire.NewSet(wire.Struct(new(PasswordHistory), "*"), wire.Bind(new(model.IPasswordHistory), new(*PasswordHistory)))
+
+// PasswordHistory 用户历史密码存储
+type PasswordHistory struct {
+	DB *gorm.DB
+}
+
+func (a *PasswordHistory) getQueryOption(opts ...schema.PasswordHistoryQueryOptions) schema.PasswordHistoryQueryOptions {
+	var opt schema.PasswordHistoryQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *PasswordHistory) Query(ctx context.Context, params schema.PasswordHistoryQueryParam, opts ...schema.PasswordHistoryQueryOptions) (*schema.PasswordHistoryQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetPasswordHistoryDB(ctx, a.DB)
+	if v := params.UserID; v != "" {
+		db = db.Where("user_id=?", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.PasswordHistories
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.PasswordHistoryQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaPasswordHistories(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *PasswordHistory) Create(ctx context.Context, item schema.PasswordHistory) error {
+	eitem := entity.SchemaPasswordHistory(item).ToPasswordHistory()
+	result := entity.GetPasswordHistoryDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *PasswordHistory) Delete(ctx context.Context, recordID string) error {
+	result := entity.GetPasswordHistoryDB(ctx, a.DB).Where("record_id=?", recordID).Delete(entity.PasswordHistory{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *PasswordHistory) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetPasswordHistoryDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.PasswordHistory{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
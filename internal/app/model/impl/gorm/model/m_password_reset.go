@@ -0,0 +1,95 @@
+package model
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+)
+
+var _ model.IPasswordReset = (*PasswordReset)(nil)
+
+// PasswordResetSet 注入PasswordReset
+var PasswordResetSet = wire.NewSet(wire.Struct(new(PasswordReset), "*"), wire.Bind(new(model.IPasswordReset), new(*PasswordReset)))
+
+// PasswordReset 密码重置令牌存储
+type PasswordReset struct {
+	DB *gorm.DB
+}
+
+func (a *PasswordReset) getQueryOption(opts ...schema.PasswordResetQueryOptions) schema.PasswordResetQueryOptions {
+	var opt schema.PasswordResetQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *PasswordReset) Query(ctx context.Context, params schema.PasswordResetQueryParam, opts ...schema.PasswordResetQueryOptions) (*schema.PasswordResetQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetPasswordResetDB(ctx, a.DB)
+	if v := params.UserID; v != "" {
+		db = db.Where("user_id=?", v)
+	}
+	if v := params.TokenHash; v != "" {
+		db = db.Where("token_hash=?", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.PasswordResets
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.PasswordResetQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaPasswordResets(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *PasswordReset) Create(ctx context.Context, item schema.PasswordReset) error {
+	eitem := entity.SchemaPasswordReset(item).ToPasswordReset()
+	result := entity.GetPasswordResetDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *PasswordReset) Delete(ctx context.Context, recordID string) error {
+	result := entity.GetPasswordResetDB(ctx, a.DB).Where("record_id=?", recordID).Delete(entity.PasswordReset{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByTokenHash 根据令牌哈希删除数据
+func (a *PasswordReset) DeleteByTokenHash(ctx context.Context, tokenHash string) (bool, error) {
+	result := entity.GetPasswordResetDB(ctx, a.DB).Where("token_hash=?", tokenHash).Delete(entity.PasswordReset{})
+	if err := result.Error; err != nil {
+		return false, errors.WithStack(err)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *PasswordReset) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetPasswordResetDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.PasswordReset{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -40,6 +40,12 @@ func (a *Role) Query(ctx context.Context, params schema.RoleQueryParam, opts ...
 	if v := params.Name; v != "" {
 		db = db.Where("name=?", v)
 	}
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.ParentID; v != nil {
+		db = db.Where("parent_id=?", *v)
+	}
 	if v := params.UserID; v != "" {
 		subQuery := entity.GetUserRoleDB(ctx, a.DB).
 			Where("deleted_at is null").
@@ -0,0 +1,124 @@
+package model
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+)
+
+var _ model.ITenant = (*Tenant)(nil)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"), wire.Bind(new(model.ITenant), new(*Tenant)))
+
+// Tenant 租户存储
+type Tenant struct {
+	DB *gorm.DB
+}
+
+func (a *Tenant) getQueryOption(opts ...schema.TenantQueryOptions) schema.TenantQueryOptions {
+	var opt schema.TenantQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetTenantDB(ctx, a.DB)
+	if v := params.RecordIDs; len(v) > 0 {
+		db = db.Where("record_id IN(?)", v)
+	}
+	if v := params.Code; v != "" {
+		db = db.Where("code=?", v)
+	}
+	if v := params.Status; v > 0 {
+		db = db.Where("status=?", v)
+	}
+	if v := params.QueryValue; v != "" {
+		v = "%" + v + "%"
+		db = db.Where("code LIKE ? OR name LIKE ? OR memo LIKE ?", v, v, v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.Tenants
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.TenantQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaTenants(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(ctx context.Context, recordID string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error) {
+	db := entity.GetTenantDB(ctx, a.DB).Where("record_id=?", recordID)
+
+	var item entity.Tenant
+	ok, err := FindOne(ctx, db, &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaTenant(), nil
+}
+
+// Create 创建数据
+func (a *Tenant) Create(ctx context.Context, item schema.Tenant) error {
+	eitem := entity.SchemaTenant(item).ToTenant()
+	result := entity.GetTenantDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *Tenant) Update(ctx context.Context, recordID string, item schema.Tenant) error {
+	eitem := entity.SchemaTenant(item).ToTenant()
+	db := entity.GetTenantDB(ctx, a.DB).Where("record_id=?", recordID)
+
+	result := db.Updates(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Tenant) Delete(ctx context.Context, recordID string) error {
+	db := entity.GetTenantDB(ctx, a.DB).Where("record_id=?", recordID)
+
+	result := db.Delete(entity.Tenant{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Tenant) UpdateStatus(ctx context.Context, recordID string, status int) error {
+	db := entity.GetTenantDB(ctx, a.DB).Where("record_id=?", recordID)
+
+	result := db.Update("status", status)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -29,17 +29,41 @@ func (a *User) getQueryOption(opts ...schema.UserQueryOptions) schema.UserQueryO
 	return opt
 }
 
+// 过滤当前租户内及数据范围内的用户
+func (a *User) wrapScope(ctx context.Context, db *gorm.DB) (*gorm.DB, error) {
+	db, err := WrapTenantUser(ctx, a.DB, db)
+	if err != nil {
+		return nil, err
+	}
+	return WrapDataScope(ctx, a.DB, db, "org_id")
+}
+
 // Query 查询数据
 func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...schema.UserQueryOptions) (*schema.UserQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	db := entity.GetUserDB(ctx, a.DB)
+	db, err := a.wrapScope(ctx, entity.GetUserDB(ctx, a.DB))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if v := params.RecordIDs; len(v) > 0 {
+		db = db.Where("record_id IN(?)", v)
+	}
 	if v := params.UserName; v != "" {
 		db = db.Where("user_name=?", v)
 	}
 	if v := params.Status; v > 0 {
 		db = db.Where("status=?", v)
 	}
+	if v := params.Type; v > 0 {
+		db = db.Where("type=?", v)
+	}
+	if v := params.Provider; v != "" {
+		db = db.Where("provider=?", v)
+	}
+	if v := params.Subject; v != "" {
+		db = db.Where("subject=?", v)
+	}
 	if v := params.RoleIDs; len(v) > 0 {
 		subQuery := entity.GetUserRoleDB(ctx, a.DB).
 			Select("user_id").
@@ -48,6 +72,14 @@ func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...
 			SubQuery()
 		db = db.Where("record_id IN ?", subQuery)
 	}
+	if v := params.OrgID; v != "" {
+		subQuery := entity.GetUserOrgDB(ctx, a.DB).
+			Select("user_id").
+			Where("deleted_at is null").
+			Where("org_id=?", v).
+			SubQuery()
+		db = db.Where("org_id=? OR record_id IN ?", v, subQuery)
+	}
 	if v := params.QueryValue; v != "" {
 		v = "%" + v + "%"
 		db = db.Where("user_name LIKE ? OR real_name LIKE ? OR phone LIKE ? OR email LIKE ?", v, v, v, v)
@@ -71,8 +103,13 @@ func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...
 
 // Get 查询指定数据
 func (a *User) Get(ctx context.Context, recordID string, opts ...schema.UserQueryOptions) (*schema.User, error) {
+	db, err := a.wrapScope(ctx, entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	var item entity.User
-	ok, err := FindOne(ctx, entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID), &item)
+	ok, err := FindOne(ctx, db, &item)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	} else if !ok {
@@ -95,7 +132,12 @@ func (a *User) Create(ctx context.Context, item schema.User) error {
 // Update 更新数据
 func (a *User) Update(ctx context.Context, recordID string, item schema.User) error {
 	eitem := entity.SchemaUser(item).ToUser()
-	result := entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID).Updates(eitem)
+	db, err := a.wrapScope(ctx, entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := db.Updates(eitem)
 	if err := result.Error; err != nil {
 		return errors.WithStack(err)
 	}
@@ -104,7 +146,12 @@ func (a *User) Update(ctx context.Context, recordID string, item schema.User) er
 
 // Delete 删除数据
 func (a *User) Delete(ctx context.Context, recordID string) error {
-	result := entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID).Delete(entity.User{})
+	db, err := a.wrapScope(ctx, entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := db.Delete(entity.User{})
 	if err := result.Error; err != nil {
 		return errors.WithStack(err)
 	}
@@ -113,7 +160,12 @@ func (a *User) Delete(ctx context.Context, recordID string) error {
 
 // UpdateStatus 更新状态
 func (a *User) UpdateStatus(ctx context.Context, recordID string, status int) error {
-	result := entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID).Update("status", status)
+	db, err := a.wrapScope(ctx, entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	result := db.Update("status", status)
 	if err := result.Error; err != nil {
 		return errors.WithStack(err)
 	}
@@ -128,3 +180,38 @@ func (a *User) UpdatePassword(ctx context.Context, recordID, password string) er
 	}
 	return nil
 }
+
+// ChangePassword 修改密码
+func (a *User) ChangePassword(ctx context.Context, recordID string, item schema.UserPassword) error {
+	result := entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID).Updates(map[string]interface{}{
+		"password":             item.Password,
+		"password_changed_at":  item.ChangedAt,
+		"must_change_password": item.MustChange,
+	})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateMFA 更新多因素认证信息
+func (a *User) UpdateMFA(ctx context.Context, recordID string, item schema.UserMFA) error {
+	result := entity.GetUserDB(ctx, a.DB).Where("record_id=?", recordID).Updates(map[string]interface{}{
+		"mfa_enabled":        item.Enabled,
+		"mfa_secret":         item.Secret,
+		"mfa_recovery_codes": item.RecoveryCodes,
+	})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateMFAStep 更新多因素认证最后使用的时间步长
+func (a *User) UpdateMFAStep(ctx context.Context, recordID string, step int64) (bool, error) {
+	result := entity.GetUserDB(ctx, a.DB).Where("record_id=? AND mfa_last_step<?", recordID, step).Update("mfa_last_step", step)
+	if err := result.Error; err != nil {
+		return false, errors.WithStack(err)
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/google/wire"
+	"github.com/jinzhu/gorm"
+)
+
+var _ model.IUserOrg = (*UserOrg)(nil)
+
+// UserOrgSet 注入UserOrg
+var UserOrgSet = wire.NewSet(wire.Struct(new(UserOrg), "*"), wire.Bind(new(model.IUserOrg), new(*UserOrg)))
+
+// UserOrg 用户兼任部门存储
+type UserOrg struct {
+	DB *gorm.DB
+}
+
+func (a *UserOrg) getQueryOption(opts ...schema.UserOrgQueryOptions) schema.UserOrgQueryOptions {
+	var opt schema.UserOrgQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *UserOrg) Query(ctx context.Context, params schema.UserOrgQueryParam, opts ...schema.UserOrgQueryOptions) (*schema.UserOrgQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	db := entity.GetUserOrgDB(ctx, a.DB)
+	if v := params.UserID; v != "" {
+		db = db.Where("user_id=?", v)
+	}
+	if v := params.UserIDs; len(v) > 0 {
+		db = db.Where("user_id IN(?)", v)
+	}
+	if v := params.OrgIDs; len(v) > 0 {
+		db = db.Where("org_id IN(?)", v)
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("id", schema.OrderByDESC))
+	db = db.Order(ParseOrder(opt.OrderFields))
+
+	var list entity.UserOrgs
+	pr, err := WrapPageQuery(ctx, db, params.PaginationParam, &list)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.UserOrgQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaUserOrgs(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *UserOrg) Create(ctx context.Context, item schema.UserOrg) error {
+	eitem := entity.SchemaUserOrg(item).ToUserOrg()
+	result := entity.GetUserOrgDB(ctx, a.DB).Create(eitem)
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *UserOrg) Delete(ctx context.Context, recordID string) error {
+	result := entity.GetUserOrgDB(ctx, a.DB).Where("record_id=?", recordID).Delete(entity.UserOrg{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *UserOrg) DeleteByUserID(ctx context.Context, userID string) error {
+	result := entity.GetUserOrgDB(ctx, a.DB).Where("user_id=?", userID).Delete(entity.UserOrg{})
+	if err := result.Error; err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
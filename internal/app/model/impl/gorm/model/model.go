@@ -4,13 +4,19 @@ import "github.com/google/wire"
 
 // ModelSet model注入
 var ModelSet = wire.NewSet(
+	APITokenSet,
 	DemoSet,
 	MenuActionResourceSet,
 	MenuActionSet,
 	MenuSet,
+	OrgSet,
+	PasswordHistorySet,
+	PasswordResetSet,
 	RoleMenuSet,
 	RoleSet,
+	TenantSet,
 	TransSet,
+	UserOrgSet,
 	UserRoleSet,
 	UserSet,
 )
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetAPITokenCollection 获取APIToken存储
+func GetAPITokenCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return getCollection(ctx, cli, APIToken{})
+}
+
+// SchemaAPIToken 访问令牌对象
+type SchemaAPIToken schema.APIToken
+
+// ToAPIToken 转换为访问令牌实体
+func (a SchemaAPIToken) ToAPIToken() *APIToken {
+	item := new(APIToken)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// APIToken 访问令牌实体
+type APIToken struct {
+	Model      `bson:",inline"`
+	TenantID   string     `bson:"tenant_id"`    // 租户ID
+	UserID     string     `bson:"user_id"`      // 所属用户ID
+	Name       string     `bson:"name"`         // 令牌名称
+	Prefix     string     `bson:"prefix"`       // 令牌前缀
+	TokenHash  string     `bson:"token_hash"`   // 令牌哈希值
+	ActionIDs  []string   `bson:"action_ids"`   // 授权的菜单动作ID列表
+	ExpiresAt  *time.Time `bson:"expires_at"`   // 过期时间
+	LastUsedAt *time.Time `bson:"last_used_at"` // 最后使用时间
+	Creator    string     `bson:"creator"`      // 创建者
+}
+
+func (a APIToken) String() string {
+	return toString(a)
+}
+
+// CollectionName 集合名
+func (a APIToken) CollectionName() string {
+	return a.Model.CollectionName("api_token")
+}
+
+// CreateIndexes 创建索引
+func (a APIToken) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"tenant_id": 1}},
+		{Keys: bson.M{"user_id": 1}},
+		{Keys: bson.M{"token_hash": 1}},
+	})
+}
+
+// ToSchemaAPIToken 转换为访问令牌对象
+func (a APIToken) ToSchemaAPIToken() *schema.APIToken {
+	item := new(schema.APIToken)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// APITokens 访问令牌实体列表
+type APITokens []*APIToken
+
+// ToSchemaAPITokens 转换为访问令牌对象列表
+func (a APITokens) ToSchemaAPITokens() []*schema.APIToken {
+	list := make([]*schema.APIToken, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaAPIToken()
+	}
+	return list
+}
@@ -26,12 +26,13 @@ func (a SchemaDemo) ToDemo() *Demo {
 
 // Demo demo实体
 type Demo struct {
-	Model   `bson:",inline"`
-	Code    string `bson:"code"`    // 编号
-	Name    string `bson:"name"`    // 名称
-	Memo    string `bson:"memo"`    // 备注
-	Status  int    `bson:"status"`  // 状态(1:启用 2:停用)
-	Creator string `bson:"creator"` // 创建者
+	Model    `bson:",inline"`
+	TenantID string `bson:"tenant_id"` // 租户ID
+	Code     string `bson:"code"`      // 编号
+	Name     string `bson:"name"`      // 名称
+	Memo     string `bson:"memo"`      // 备注
+	Status   int    `bson:"status"`    // 状态(1:启用 2:停用)
+	Creator  string `bson:"creator"`   // 创建者
 }
 
 func (a Demo) String() string {
@@ -46,6 +47,7 @@ func (a Demo) CollectionName() string {
 // CreateIndexes 创建索引
 func (a Demo) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
 	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"tenant_id": 1}},
 		{Keys: bson.M{"code": 1}},
 		{Keys: bson.M{"name": 1}},
 		{Keys: bson.M{"status": 1}},
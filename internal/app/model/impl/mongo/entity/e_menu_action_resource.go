@@ -26,10 +26,15 @@ func (a SchemaMenuActionResource) ToMenuActionResource() *MenuActionResource {
 
 // MenuActionResource 菜单动作关联资源实体
 type MenuActionResource struct {
-	Model    `bson:",inline"`
-	ActionID string `bson:"action_id"` // 菜单动作ID
-	Method   string `bson:"method"`    // 资源请求方式(支持正则)
-	Path     string `bson:"path"`      // 资源请求路径（支持/:id匹配）
+	Model      `bson:",inline"`
+	ActionID   string `bson:"action_id"`   // 菜单动作ID
+	Method     string `bson:"method"`      // 资源请求方式(支持正则)
+	Path       string `bson:"path"`        // 资源请求路径（支持/:id匹配）
+	View       string `bson:"view"`        // 资源允许的令牌视图(为空表示不限制)
+	Effect     string `bson:"effect"`      // 授权效果(allow:允许 deny:拒绝，为空表示允许)
+	IPRanges   string `bson:"ip_ranges"`   // 客户端IP范围条件
+	TimeWindow string `bson:"time_window"` // 时间窗口条件
+	Attributes string `bson:"attributes"`  // 请求属性条件
 }
 
 func (a MenuActionResource) String() string {
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetOrgCollection 获取Org存储
+func GetOrgCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return getCollection(ctx, cli, Org{})
+}
+
+// SchemaOrg 部门对象
+type SchemaOrg schema.Org
+
+// ToOrg 转换为部门实体
+func (a SchemaOrg) ToOrg() *Org {
+	item := new(Org)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Org 部门实体
+type Org struct {
+	Model      `bson:",inline"`
+	TenantID   string `bson:"tenant_id"`   // 租户ID
+	Name       string `bson:"name"`        // 部门名称
+	Sequence   int    `bson:"sequence"`    // 排序值
+	ParentID   string `bson:"parent_id"`   // 父级内码
+	ParentPath string `bson:"parent_path"` // 父级路径
+	Leader     string `bson:"leader"`      // 负责人
+	Phone      string `bson:"phone"`       // 联系电话
+	Status     int    `bson:"status"`      // 状态(1:启用 2:禁用)
+	Memo       string `bson:"memo"`        // 备注
+	Creator    string `bson:"creator"`     // 创建人
+}
+
+func (a Org) String() string {
+	return toString(a)
+}
+
+// CollectionName 集合名
+func (a Org) CollectionName() string {
+	return a.Model.CollectionName("org")
+}
+
+// CreateIndexes 创建索引
+func (a Org) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"tenant_id": 1}},
+		{Keys: bson.M{"name": 1}},
+		{Keys: bson.M{"sequence": -1}},
+		{Keys: bson.M{"parent_id": 1}},
+		{Keys: bson.M{"parent_path": 1}},
+		{Keys: bson.M{"status": 1}},
+	})
+}
+
+// ToSchemaOrg 转换为部门对象
+func (a Org) ToSchemaOrg() *schema.Org {
+	item := new(schema.Org)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Orgs 部门实体列表
+type Orgs []*Org
+
+// ToSchemaOrgs 转换为部门对象列表
+func (a Orgs) ToSchemaOrgs() []*schema.Org {
+	list := make([]*schema.Org, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaOrg()
+	}
+	return list
+}
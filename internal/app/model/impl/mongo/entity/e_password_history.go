@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetPasswordHistoryCollection 获取PasswordHistory存储
+func GetPasswordHistoryCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return getCollection(ctx, cli, PasswordHistory{})
+}
+
+// SchemaPasswordHistory 用户历史密码对象
+type SchemaPasswordHistory schema.PasswordHistory
+
+// ToPasswordHistory 转换为用户历史密码实体
+func (a SchemaPasswordHistory) ToPasswordHistory() *PasswordHistory {
+	item := new(PasswordHistory)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordHistory 用户历史密码实体
+type PasswordHistory struct {
+	Model    `bson:",inline"`
+	UserID   string `bson:"user_id"`  // 用户ID
+	Password string `bson:"password"` // 密码(bcrypt/argon2id哈希)
+}
+
+func (a PasswordHistory) String() string {
+	return toString(a)
+}
+
+// CollectionName 集合名
+func (a PasswordHistory) CollectionName() string {
+	return a.Model.CollectionName("password_history")
+}
+
+// CreateIndexes 创建索引
+func (a PasswordHistory) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"user_id": 1}},
+	})
+}
+
+// ToSchemaPasswordHistory 转换为用户历史密码对象
+func (a PasswordHistory) ToSchemaPasswordHistory() *schema.PasswordHistory {
+	item := new(schema.PasswordHistory)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordHistories 用户历史密码实体列表
+type PasswordHistories []*PasswordHistory
+
+// ToSchemaPasswordHistories 转换为用户历史密码对象列表
+func (a PasswordHistories) ToSchemaPasswordHistories() []*schema.PasswordHistory {
+	list := make([]*schema.PasswordHistory, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaPasswordHistory()
+	}
+	return list
+}
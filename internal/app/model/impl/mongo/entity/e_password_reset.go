@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"context"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetPasswordResetCollection 获取PasswordReset存储
+func GetPasswordResetCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return getCollection(ctx, cli, PasswordReset{})
+}
+
+// SchemaPasswordReset 密码重置令牌对象
+type SchemaPasswordReset schema.PasswordReset
+
+// ToPasswordReset 转换为密码重置令牌实体
+func (a SchemaPasswordReset) ToPasswordReset() *PasswordReset {
+	item := new(PasswordReset)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordReset 密码重置令牌实体
+type PasswordReset struct {
+	Model     `bson:",inline"`
+	UserID    string    `bson:"user_id"`    // 用户ID
+	TokenHash string    `bson:"token_hash"` // 令牌哈希值
+	ExpiresAt time.Time `bson:"expires_at"` // 过期时间
+}
+
+func (a PasswordReset) String() string {
+	return toString(a)
+}
+
+// CollectionName 集合名
+func (a PasswordReset) CollectionName() string {
+	return a.Model.CollectionName("password_reset")
+}
+
+// CreateIndexes 创建索引
+func (a PasswordReset) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"user_id": 1}},
+		{Keys: bson.M{"token_hash": 1}},
+	})
+}
+
+// ToSchemaPasswordReset 转换为密码重置令牌对象
+func (a PasswordReset) ToSchemaPasswordReset() *schema.PasswordReset {
+	item := new(schema.PasswordReset)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// PasswordResets 密码重置令牌实体列表
+type PasswordResets []*PasswordReset
+
+// ToSchemaPasswordResets 转换为密码重置令牌对象列表
+func (a PasswordResets) ToSchemaPasswordResets() []*schema.PasswordReset {
+	list := make([]*schema.PasswordReset, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaPasswordReset()
+	}
+	return list
+}
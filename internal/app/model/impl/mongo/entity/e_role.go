@@ -26,12 +26,17 @@ func (a SchemaRole) ToRole() *Role {
 
 // Role 角色实体
 type Role struct {
-	Model    `bson:",inline"`
-	Name     string `bson:"name"`     // 角色名称
-	Sequence int    `bson:"sequence"` // 排序值
-	Memo     string `bson:"memo"`     // 备注
-	Status   int    `bson:"status"`   // 状态(1:启用 2:禁用)
-	Creator  string `bson:"creator"`  // 创建者
+	Model      `bson:",inline"`
+	TenantID   string   `bson:"tenant_id"`    // 租户ID
+	Name       string   `bson:"name"`         // 角色名称
+	Sequence   int      `bson:"sequence"`     // 排序值
+	ParentID   string   `bson:"parent_id"`    // 上级角色ID
+	Memo       string   `bson:"memo"`         // 备注
+	Status     int      `bson:"status"`       // 状态(1:启用 2:禁用)
+	RequireMFA int      `bson:"require_mfa"`  // 是否要求多因素认证(1:要求 2:不要求)
+	DataScope  int      `bson:"data_scope"`   // 数据范围(1:全部 2:本人创建 3:本部门 4:自定义部门)
+	DataOrgIDs []string `bson:"data_org_ids"` // 自定义数据范围的部门ID列表
+	Creator    string   `bson:"creator"`      // 创建者
 }
 
 func (a Role) String() string {
@@ -46,8 +51,10 @@ func (a Role) CollectionName() string {
 // CreateIndexes 创建索引
 func (a Role) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
 	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"tenant_id": 1}},
 		{Keys: bson.M{"name": 1}},
 		{Keys: bson.M{"sequence": -1}},
+		{Keys: bson.M{"parent_id": 1}},
 		{Keys: bson.M{"status": 1}},
 	})
 }
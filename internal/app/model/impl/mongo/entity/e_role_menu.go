@@ -27,6 +27,7 @@ func (a SchemaRoleMenu) ToRoleMenu() *RoleMenu {
 // RoleMenu 角色菜单实体
 type RoleMenu struct {
 	Model    `bson:",inline"`
+	TenantID string `bson:"tenant_id"` // 租户ID
 	RoleID   string `bson:"role_id"`   // 角色ID
 	MenuID   string `bson:"menu_id"`   // 菜单ID
 	ActionID string `bson:"action_id"` // 动作ID
@@ -44,6 +45,7 @@ func (a RoleMenu) CollectionName() string {
 // CreateIndexes 创建索引
 func (a RoleMenu) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
 	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"tenant_id": 1}},
 		{Keys: bson.M{"role_id": 1}},
 		{Keys: bson.M{"menu_id": 1}},
 		{Keys: bson.M{"action_id": 1}},
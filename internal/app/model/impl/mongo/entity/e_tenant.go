@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetTenantCollection 获取租户存储
+func GetTenantCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return getCollection(ctx, cli, Tenant{})
+}
+
+// SchemaTenant 租户对象
+type SchemaTenant schema.Tenant
+
+// ToTenant 转换为租户实体
+func (a SchemaTenant) ToTenant() *Tenant {
+	item := new(Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenant 租户实体
+type Tenant struct {
+	Model   `bson:",inline"`
+	Code    string `bson:"code"`    // 编号
+	Name    string `bson:"name"`    // 名称
+	Memo    string `bson:"memo"`    // 备注
+	Status  int    `bson:"status"`  // 状态(1:启用 2:停用)
+	Creator string `bson:"creator"` // 创建者
+}
+
+func (a Tenant) String() string {
+	return toString(a)
+}
+
+// CollectionName 集合名
+func (a Tenant) CollectionName() string {
+	return a.Model.CollectionName("tenant")
+}
+
+// CreateIndexes 创建索引
+func (a Tenant) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"code": 1}},
+		{Keys: bson.M{"name": 1}},
+		{Keys: bson.M{"status": 1}},
+	})
+}
+
+// ToSchemaTenant 转换为租户对象
+func (a Tenant) ToSchemaTenant() *schema.Tenant {
+	item := new(schema.Tenant)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// Tenants 租户列表
+type Tenants []*Tenant
+
+// ToSchemaTenants 转换为租户对象列表
+func (a Tenants) ToSchemaTenants() []*schema.Tenant {
+	list := make([]*schema.Tenant, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaTenant()
+	}
+	return list
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"context"
+	"time"
 
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"github.com/wangwei518/gin-admin/pkg/util"
@@ -26,14 +27,24 @@ func (a SchemaUser) ToUser() *User {
 
 // User 用户实体
 type User struct {
-	Model    `bson:",inline"`
-	UserName string `bson:"user_name"` // 用户名
-	RealName string `bson:"real_name"` // 真实姓名
-	Password string `bson:"password"`  // 密码(sha1(md5(明文))加密)
-	Email    string `bson:"email"`     // 邮箱
-	Phone    string `bson:"phone"`     // 手机号
-	Status   int    `bson:"status"`    // 状态(1:启用 2:停用)
-	Creator  string `bson:"creator"`   // 创建者
+	Model              `bson:",inline"`
+	UserName           string     `bson:"user_name"`               // 用户名
+	RealName           string     `bson:"real_name"`               // 真实姓名
+	Password           string     `bson:"password"`                // 密码(bcrypt/argon2id哈希)
+	Email              string     `bson:"email"`                   // 邮箱
+	Phone              string     `bson:"phone"`                   // 手机号
+	Status             int        `bson:"status"`                  // 状态(1:启用 2:停用)
+	Type               int        `bson:"type"`                    // 类型(1:普通用户 2:服务账号)
+	OrgID              string     `bson:"org_id"`                  // 所属部门ID
+	Provider           string     `bson:"provider"`                // 外部认证来源(ldap/oidc，为空表示本地用户)
+	Subject            string     `bson:"subject"`                 // 外部认证的用户标识
+	MFAEnabled         int        `bson:"mfa_enabled"`             // 多因素认证状态(1:启用 2:未启用)
+	MFASecret          string     `bson:"mfa_secret"`              // 多因素认证密钥
+	MFARecoveryCodes   string     `bson:"mfa_recovery_codes"`      // 多因素认证恢复码(哈希值)
+	MFALastStep        int64      `bson:"mfa_last_step,omitempty"` // 多因素认证最后使用的时间步长
+	MustChangePassword int        `bson:"must_change_password"`    // 下次登录是否必须修改密码(1:是 2:否)
+	PasswordChangedAt  *time.Time `bson:"password_changed_at"`     // 密码修改时间
+	Creator            string     `bson:"creator"`                 // 创建者
 }
 
 func (a User) String() string {
@@ -51,6 +62,8 @@ func (a User) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
 		{Keys: bson.M{"user_name": 1}},
 		{Keys: bson.M{"real_name": 1}},
 		{Keys: bson.M{"status": 1}},
+		{Keys: bson.M{"org_id": 1}},
+		{Keys: bson.M{"provider": 1, "subject": 1}},
 	})
 }
 
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetUserOrgCollection 获取UserOrg存储
+func GetUserOrgCollection(ctx context.Context, cli *mongo.Client) *mongo.Collection {
+	return getCollection(ctx, cli, UserOrg{})
+}
+
+// SchemaUserOrg 用户兼任部门
+type SchemaUserOrg schema.UserOrg
+
+// ToUserOrg 转换为用户兼任部门实体
+func (a SchemaUserOrg) ToUserOrg() *UserOrg {
+	item := new(UserOrg)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// UserOrg 用户兼任部门关联实体
+type UserOrg struct {
+	Model    `bson:",inline"`
+	TenantID string `bson:"tenant_id"` // 租户ID
+	UserID   string `bson:"user_id"`   // 用户内码
+	OrgID    string `bson:"org_id"`    // 部门内码
+}
+
+// CollectionName 集合名
+func (a UserOrg) CollectionName() string {
+	return a.Model.CollectionName("user_org")
+}
+
+// CreateIndexes 创建索引
+func (a UserOrg) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
+	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"tenant_id": 1}},
+		{Keys: bson.M{"user_id": 1}},
+		{Keys: bson.M{"org_id": 1}},
+	})
+}
+
+// ToSchemaUserOrg 转换为用户兼任部门对象
+func (a UserOrg) ToSchemaUserOrg() *schema.UserOrg {
+	item := new(schema.UserOrg)
+	util.StructMapToStruct(a, item)
+	return item
+}
+
+// UserOrgs 用户兼任部门关联列表
+type UserOrgs []*UserOrg
+
+// ToSchemaUserOrgs 转换为用户兼任部门对象列表
+func (a UserOrgs) ToSchemaUserOrgs() []*schema.UserOrg {
+	list := make([]*schema.UserOrg, len(a))
+	for i, item := range a {
+		list[i] = item.ToSchemaUserOrg()
+	}
+	return list
+}
@@ -26,9 +26,10 @@ func (a SchemaUserRole) ToUserRole() *UserRole {
 
 // UserRole 用户角色关联实体
 type UserRole struct {
-	Model  `bson:",inline"`
-	UserID string `bson:"user_id"` // 用户内码
-	RoleID string `bson:"role_id"` // 角色内码
+	Model    `bson:",inline"`
+	TenantID string `bson:"tenant_id"` // 租户ID
+	UserID   string `bson:"user_id"`   // 用户内码
+	RoleID   string `bson:"role_id"`   // 角色内码
 }
 
 // CollectionName 集合名
@@ -39,6 +40,7 @@ func (a UserRole) CollectionName() string {
 // CreateIndexes 创建索引
 func (a UserRole) CreateIndexes(ctx context.Context, cli *mongo.Client) error {
 	return a.Model.CreateIndexes(ctx, cli, a, []mongo.IndexModel{
+		{Keys: bson.M{"tenant_id": 1}},
 		{Keys: bson.M{"user_id": 1}},
 		{Keys: bson.M{"role_id": 1}},
 	})
@@ -0,0 +1,119 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/mongo/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.IAPIToken = (*APIToken)(nil)
+
+// APITokenSet 注入APIToken
+var APITokenSet = wire.NewSet(wire.Struct(new(APIToken), "*"), wire.Bind(new(model.IAPIToken), new(*APIToken)))
+
+// APIToken 访问令牌存储
+type APIToken struct {
+	Client *mongo.Client
+}
+
+func (a *APIToken) getQueryOption(opts ...schema.APITokenQueryOptions) schema.APITokenQueryOptions {
+	var opt schema.APITokenQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *APIToken) Query(ctx context.Context, params schema.APITokenQueryParam, opts ...schema.APITokenQueryOptions) (*schema.APITokenQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetAPITokenCollection(ctx, a.Client)
+	filter := DefaultFilter(ctx)
+	if v := params.UserID; v != "" {
+		filter = append(filter, Filter("user_id", v))
+	}
+	if v := params.TokenHash; v != "" {
+		filter = append(filter, Filter("token_hash", v))
+	}
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("_id", schema.OrderByDESC))
+
+	var list entity.APITokens
+	pr, err := WrapPageQuery(ctx, c, params.PaginationParam, filter, &list, options.Find().SetSort(ParseOrder(opt.OrderFields)))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.APITokenQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaAPITokens(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *APIToken) Get(ctx context.Context, recordID string, opts ...schema.APITokenQueryOptions) (*schema.APIToken, error) {
+	c := entity.GetAPITokenCollection(ctx, a.Client)
+	filter := DefaultFilter(ctx, Filter("_id", recordID))
+	var item entity.APIToken
+	ok, err := FindOne(ctx, c, filter, &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaAPIToken(), nil
+}
+
+// Create 创建数据
+func (a *APIToken) Create(ctx context.Context, item schema.APIToken) error {
+	eitem := entity.SchemaAPIToken(item).ToAPIToken()
+	eitem.TenantID = GetTenantID(ctx, eitem.TenantID)
+	eitem.CreatedAt = time.Now()
+	eitem.UpdatedAt = time.Now()
+	c := entity.GetAPITokenCollection(ctx, a.Client)
+	err := Insert(ctx, c, eitem)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *APIToken) Delete(ctx context.Context, recordID string) error {
+	c := entity.GetAPITokenCollection(ctx, a.Client)
+	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *APIToken) DeleteByUserID(ctx context.Context, userID string) error {
+	c := entity.GetAPITokenCollection(ctx, a.Client)
+	err := DeleteMany(ctx, c, DefaultFilter(ctx, Filter("user_id", userID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateLastUsed 更新最后使用时间
+func (a *APIToken) UpdateLastUsed(ctx context.Context, recordID string, lastUsedAt time.Time) error {
+	c := entity.GetAPITokenCollection(ctx, a.Client)
+	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"last_used_at": lastUsedAt})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
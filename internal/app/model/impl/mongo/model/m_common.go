@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/mongo/entity"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -135,8 +137,22 @@ func DeleteMany(ctx context.Context, c *mongo.Collection, filter interface{}) er
 	return err
 }
 
-// DefaultFilter 默认的查询参数
+// DefaultFilter 默认的查询参数(只查询上下文中租户的数据)
 func DefaultFilter(ctx context.Context, params ...bson.E) bson.D {
+	d := GlobalFilter(ctx, params...)
+	if tenantID, ok := icontext.FromTenantID(ctx); ok {
+		if tenantID == "" {
+			// 默认租户包含启用多租户之前(没有租户ID)的数据
+			d = append(d, Filter("tenant_id", bson.M{"$in": bson.A{"", nil}}))
+		} else {
+			d = append(d, Filter("tenant_id", tenantID))
+		}
+	}
+	return d
+}
+
+// GlobalFilter 不区分租户的查询参数(用于用户、菜单等全部租户共用的数据)
+func GlobalFilter(ctx context.Context, params ...bson.E) bson.D {
 	var d bson.D
 	if len(params) > 0 {
 		d = append(d, params...)
@@ -145,6 +161,55 @@ func DefaultFilter(ctx context.Context, params ...bson.E) bson.D {
 	return d
 }
 
+// GetTenantID 获取创建数据时使用的租户ID(未指定租户的上下文中保留数据中的租户ID)
+func GetTenantID(ctx context.Context, tenantID string) string {
+	if v, ok := icontext.FromTenantID(ctx); ok {
+		return v
+	}
+	return tenantID
+}
+
+// WrapDataScope 按上下文中的数据范围过滤数据(本人创建的数据，以及范围内部门的用户创建的数据)
+// orgField 不为空时表示数据所属部门的字段，所属部门在范围内的数据同样可以访问
+func WrapDataScope(ctx context.Context, cli *mongo.Client, filter bson.D, orgField string) (bson.D, error) {
+	scope, ok := icontext.FromDataScope(ctx)
+	if !ok {
+		return filter, nil
+	}
+
+	userID, _ := icontext.FromUserID(ctx)
+	if len(scope.OrgIDs) == 0 {
+		return append(filter, Filter("creator", userID)), nil
+	}
+
+	userIDs, err := entity.GetUserCollection(ctx, cli).Distinct(ctx, "_id", GlobalFilter(ctx, Filter("org_id", bson.M{"$in": scope.OrgIDs})))
+	if err != nil {
+		return nil, err
+	}
+	userIDs = append(userIDs, userID)
+
+	or := bson.A{bson.M{"creator": bson.M{"$in": userIDs}}}
+	if orgField != "" {
+		or = append(or, bson.M{orgField: bson.M{"$in": scope.OrgIDs}})
+	}
+	// 使用$and包装，避免与模糊查询的$or条件冲突
+	return append(filter, Filter("$and", bson.A{bson.M{"$or": or}})), nil
+}
+
+// WrapTenantUser 按上下文中的租户过滤用户(用户在租户中分配了角色即属于该租户，全部用户均属于默认租户)
+func WrapTenantUser(ctx context.Context, cli *mongo.Client, filter bson.D) (bson.D, error) {
+	if tenantID, ok := icontext.FromTenantID(ctx); !ok || tenantID == "" {
+		return filter, nil
+	}
+
+	userIDs, err := entity.GetUserRoleCollection(ctx, cli).Distinct(ctx, "user_id", DefaultFilter(ctx))
+	if err != nil {
+		return nil, err
+	}
+	// 使用$and包装，避免与其他条件中的_id冲突
+	return append(filter, Filter("$and", bson.A{bson.M{"_id": bson.M{"$in": userIDs}}})), nil
+}
+
 // RegexFilter 正则过滤
 func RegexFilter(key, value string) bson.E {
 	return bson.E{
@@ -49,6 +49,11 @@ func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...
 		}))
 	}
 
+	filter, err := WrapDataScope(ctx, a.Client, filter, "")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("_id", schema.OrderByDESC))
 
 	var list entity.Demos
@@ -67,7 +72,11 @@ func (a *Demo) Query(ctx context.Context, params schema.DemoQueryParam, opts ...
 // Get 查询指定数据
 func (a *Demo) Get(ctx context.Context, recordID string, opts ...schema.DemoQueryOptions) (*schema.Demo, error) {
 	c := entity.GetDemoCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx, Filter("_id", recordID))
+	filter, err := WrapDataScope(ctx, a.Client, DefaultFilter(ctx, Filter("_id", recordID)), "")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	var item entity.Demo
 	ok, err := FindOne(ctx, c, filter, &item)
 	if err != nil {
@@ -82,6 +91,7 @@ func (a *Demo) Get(ctx context.Context, recordID string, opts ...schema.DemoQuer
 // Create 创建数据
 func (a *Demo) Create(ctx context.Context, item schema.Demo) error {
 	eitem := entity.SchemaDemo(item).ToDemo()
+	eitem.TenantID = GetTenantID(ctx, eitem.TenantID)
 	eitem.CreatedAt = time.Now()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetDemoCollection(ctx, a.Client)
@@ -97,7 +107,12 @@ func (a *Demo) Update(ctx context.Context, recordID string, item schema.Demo) er
 	eitem := entity.SchemaDemo(item).ToDemo()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetDemoCollection(ctx, a.Client)
-	err := Update(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), eitem)
+	filter, err := WrapDataScope(ctx, a.Client, DefaultFilter(ctx, Filter("_id", recordID)), "")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = Update(ctx, c, filter, eitem)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -107,7 +122,12 @@ func (a *Demo) Update(ctx context.Context, recordID string, item schema.Demo) er
 // Delete 删除数据
 func (a *Demo) Delete(ctx context.Context, recordID string) error {
 	c := entity.GetDemoCollection(ctx, a.Client)
-	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
+	filter, err := WrapDataScope(ctx, a.Client, DefaultFilter(ctx, Filter("_id", recordID)), "")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = Delete(ctx, c, filter)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -117,7 +137,12 @@ func (a *Demo) Delete(ctx context.Context, recordID string) error {
 // UpdateStatus 更新状态
 func (a *Demo) UpdateStatus(ctx context.Context, recordID string, status int) error {
 	c := entity.GetDemoCollection(ctx, a.Client)
-	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"status": status})
+	filter, err := WrapDataScope(ctx, a.Client, DefaultFilter(ctx, Filter("_id", recordID)), "")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = UpdateFields(ctx, c, filter, bson.M{"status": status})
 	if err != nil {
 		return errors.WithStack(err)
 	}
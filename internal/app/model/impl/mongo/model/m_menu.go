@@ -38,7 +38,7 @@ func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...
 	opt := a.getQueryOption(opts...)
 
 	c := entity.GetMenuCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx)
+	filter := GlobalFilter(ctx)
 	if v := params.RecordIDs; len(v) > 0 {
 		filter = append(filter, Filter("_id", bson.M{"$in": v}))
 	}
@@ -81,7 +81,7 @@ func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...
 // Get 查询指定数据
 func (a *Menu) Get(ctx context.Context, recordID string, opts ...schema.MenuQueryOptions) (*schema.Menu, error) {
 	c := entity.GetMenuCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx, Filter("_id", recordID))
+	filter := GlobalFilter(ctx, Filter("_id", recordID))
 	var item entity.Menu
 	ok, err := FindOne(ctx, c, filter, &item)
 	if err != nil {
@@ -111,7 +111,7 @@ func (a *Menu) Update(ctx context.Context, recordID string, item schema.Menu) er
 	eitem := entity.SchemaMenu(item).ToMenu()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetMenuCollection(ctx, a.Client)
-	err := Update(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), eitem)
+	err := Update(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), eitem)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -121,7 +121,7 @@ func (a *Menu) Update(ctx context.Context, recordID string, item schema.Menu) er
 // Delete 删除数据
 func (a *Menu) Delete(ctx context.Context, recordID string) error {
 	c := entity.GetMenuCollection(ctx, a.Client)
-	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
+	err := Delete(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)))
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -131,7 +131,7 @@ func (a *Menu) Delete(ctx context.Context, recordID string) error {
 // UpdateStatus 更新状态
 func (a *Menu) UpdateStatus(ctx context.Context, recordID string, status int) error {
 	c := entity.GetMenuCollection(ctx, a.Client)
-	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"status": status})
+	err := UpdateFields(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), bson.M{"status": status})
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -141,7 +141,7 @@ func (a *Menu) UpdateStatus(ctx context.Context, recordID string, status int) er
 // UpdateParentPath 更新父级路径
 func (a *Menu) UpdateParentPath(ctx context.Context, recordID, parentPath string) error {
 	c := entity.GetMenuCollection(ctx, a.Client)
-	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"parent_path": parentPath})
+	err := UpdateFields(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), bson.M{"parent_path": parentPath})
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -37,7 +37,7 @@ func (a *MenuAction) Query(ctx context.Context, params schema.MenuActionQueryPar
 	opt := a.getQueryOption(opts...)
 
 	c := entity.GetMenuActionCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx)
+	filter := GlobalFilter(ctx)
 	if v := params.MenuID; v != "" {
 		filter = append(filter, Filter("menu_id", v))
 	}
@@ -63,7 +63,7 @@ func (a *MenuAction) Query(ctx context.Context, params schema.MenuActionQueryPar
 // Get 查询指定数据
 func (a *MenuAction) Get(ctx context.Context, recordID string, opts ...schema.MenuActionQueryOptions) (*schema.MenuAction, error) {
 	c := entity.GetMenuActionCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx, Filter("_id", recordID))
+	filter := GlobalFilter(ctx, Filter("_id", recordID))
 	var item entity.MenuAction
 	ok, err := FindOne(ctx, c, filter, &item)
 	if err != nil {
@@ -93,7 +93,7 @@ func (a *MenuAction) Update(ctx context.Context, recordID string, item schema.Me
 	eitem := entity.SchemaMenuAction(item).ToMenuAction()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetMenuActionCollection(ctx, a.Client)
-	err := Update(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), eitem)
+	err := Update(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), eitem)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -103,7 +103,7 @@ func (a *MenuAction) Update(ctx context.Context, recordID string, item schema.Me
 // Delete 删除数据
 func (a *MenuAction) Delete(ctx context.Context, recordID string) error {
 	c := entity.GetMenuActionCollection(ctx, a.Client)
-	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
+	err := Delete(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)))
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -113,7 +113,7 @@ func (a *MenuAction) Delete(ctx context.Context, recordID string) error {
 // DeleteByMenuID 根据菜单ID删除数据
 func (a *MenuAction) DeleteByMenuID(ctx context.Context, menuID string) error {
 	c := entity.GetMenuActionCollection(ctx, a.Client)
-	err := DeleteMany(ctx, c, DefaultFilter(ctx, Filter("menu_id", menuID)))
+	err := DeleteMany(ctx, c, GlobalFilter(ctx, Filter("menu_id", menuID)))
 	if err != nil {
 		return errors.WithStack(err)
 	}
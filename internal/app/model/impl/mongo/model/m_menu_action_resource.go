@@ -37,7 +37,7 @@ func (a *MenuActionResource) Query(ctx context.Context, params schema.MenuAction
 	opt := a.getQueryOption(opts...)
 
 	c := entity.GetMenuActionResourceCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx)
+	filter := GlobalFilter(ctx)
 	menuIDs := params.MenuIDs
 	if v := params.MenuID; v != "" {
 		menuIDs = append(menuIDs, v)
@@ -67,7 +67,7 @@ func (a *MenuActionResource) Query(ctx context.Context, params schema.MenuAction
 // Get 查询指定数据
 func (a *MenuActionResource) Get(ctx context.Context, recordID string, opts ...schema.MenuActionResourceQueryOptions) (*schema.MenuActionResource, error) {
 	c := entity.GetMenuActionResourceCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx, Filter("_id", recordID))
+	filter := GlobalFilter(ctx, Filter("_id", recordID))
 	var item entity.MenuActionResource
 	ok, err := FindOne(ctx, c, filter, &item)
 	if err != nil {
@@ -97,7 +97,7 @@ func (a *MenuActionResource) Update(ctx context.Context, recordID string, item s
 	eitem := entity.SchemaMenuActionResource(item).ToMenuActionResource()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetMenuActionResourceCollection(ctx, a.Client)
-	err := Update(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), eitem)
+	err := Update(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), eitem)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -107,7 +107,7 @@ func (a *MenuActionResource) Update(ctx context.Context, recordID string, item s
 // Delete 删除数据
 func (a *MenuActionResource) Delete(ctx context.Context, recordID string) error {
 	c := entity.GetMenuActionResourceCollection(ctx, a.Client)
-	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
+	err := Delete(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)))
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -117,7 +117,7 @@ func (a *MenuActionResource) Delete(ctx context.Context, recordID string) error
 // DeleteByActionID 根据动作ID删除数据
 func (a *MenuActionResource) DeleteByActionID(ctx context.Context, actionID string) error {
 	c := entity.GetMenuActionResourceCollection(ctx, a.Client)
-	err := DeleteMany(ctx, c, DefaultFilter(ctx, Filter("action_id", actionID)))
+	err := DeleteMany(ctx, c, GlobalFilter(ctx, Filter("action_id", actionID)))
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -132,7 +132,7 @@ func (a *MenuActionResource) DeleteByMenuID(ctx context.Context, menuID string)
 	}
 
 	c := entity.GetMenuActionResourceCollection(ctx, a.Client)
-	err = DeleteMany(ctx, c, DefaultFilter(ctx, Filter("action_id", bson.M{"$in": actionIDs})))
+	err = DeleteMany(ctx, c, GlobalFilter(ctx, Filter("action_id", bson.M{"$in": actionIDs})))
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -140,7 +140,7 @@ func (a *MenuActionResource) DeleteByMenuID(ctx context.Context, menuID string)
 }
 
 func (a *MenuActionResource) queryActionIDs(ctx context.Context, menuIDs ...string) ([]interface{}, error) {
-	result, err := entity.GetMenuActionCollection(ctx, a.Client).Distinct(ctx, "_id", DefaultFilter(ctx, Filter("menu_id", bson.M{"$in": menuIDs})))
+	result, err := entity.GetMenuActionCollection(ctx, a.Client).Distinct(ctx, "_id", GlobalFilter(ctx, Filter("menu_id", bson.M{"$in": menuIDs})))
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
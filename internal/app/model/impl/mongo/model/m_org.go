@@ -15,18 +15,18 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var _ model.IMenu = (*Menu)(nil)
+var _ model.IOrg = (*Org)(nil)
 
-// MenuSet 注入Menu
-var MenuSet = wire.NewSet(wire.Struct(new(Menu), "*"), wire.Bind(new(model.IMenu), new(*Menu)))
+// OrgSet 注入Org
+var OrgSet = wire.NewSet(wire.Struct(new(Org), "*"), wire.Bind(new(model.IOrg), new(*Org)))
 
-// Menu 菜单存储
-type Menu struct {
+// Org 部门存储
+type Org struct {
 	Client *mongo.Client
 }
 
-func (a *Menu) getQueryOption(opts ...schema.MenuQueryOptions) schema.MenuQueryOptions {
-	var opt schema.MenuQueryOptions
+func (a *Org) getQueryOption(opts ...schema.OrgQueryOptions) schema.OrgQueryOptions {
+	var opt schema.OrgQueryOptions
 	if len(opts) > 0 {
 		opt = opts[0]
 	}
@@ -34,10 +34,10 @@ func (a *Menu) getQueryOption(opts ...schema.MenuQueryOptions) schema.MenuQueryO
 }
 
 // Query 查询数据
-func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...schema.MenuQueryOptions) (*schema.MenuQueryResult, error) {
+func (a *Org) Query(ctx context.Context, params schema.OrgQueryParam, opts ...schema.OrgQueryOptions) (*schema.OrgQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
-	c := entity.GetMenuCollection(ctx, a.Client)
+	c := entity.GetOrgCollection(ctx, a.Client)
 	filter := DefaultFilter(ctx)
 	if v := params.RecordIDs; len(v) > 0 {
 		filter = append(filter, Filter("_id", bson.M{"$in": v}))
@@ -48,6 +48,7 @@ func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...
 	if v := params.QueryValue; v != "" {
 		filter = append(filter, Filter("$or", bson.A{
 			OrRegexFilter("name", v),
+			OrRegexFilter("leader", v),
 			OrRegexFilter("memo", v),
 		}))
 	}
@@ -57,32 +58,29 @@ func (a *Menu) Query(ctx context.Context, params schema.MenuQueryParam, opts ...
 	if v := params.PrefixParentPath; v != "" {
 		filter = append(filter, RegexFilter("parent_path", fmt.Sprintf("^%s.*", v)))
 	}
-	if v := params.ShowStatus; v != 0 {
-		filter = append(filter, Filter("show_status", v))
-	}
 	if v := params.Status; v != 0 {
 		filter = append(filter, Filter("status", v))
 	}
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("_id", schema.OrderByDESC))
 
-	var list entity.Menus
+	var list entity.Orgs
 	pr, err := WrapPageQuery(ctx, c, params.PaginationParam, filter, &list, options.Find().SetSort(ParseOrder(opt.OrderFields)))
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
-	qr := &schema.MenuQueryResult{
+	qr := &schema.OrgQueryResult{
 		PageResult: pr,
-		Data:       list.ToSchemaMenus(),
+		Data:       list.ToSchemaOrgs(),
 	}
 
 	return qr, nil
 }
 
 // Get 查询指定数据
-func (a *Menu) Get(ctx context.Context, recordID string, opts ...schema.MenuQueryOptions) (*schema.Menu, error) {
-	c := entity.GetMenuCollection(ctx, a.Client)
+func (a *Org) Get(ctx context.Context, recordID string, opts ...schema.OrgQueryOptions) (*schema.Org, error) {
+	c := entity.GetOrgCollection(ctx, a.Client)
 	filter := DefaultFilter(ctx, Filter("_id", recordID))
-	var item entity.Menu
+	var item entity.Org
 	ok, err := FindOne(ctx, c, filter, &item)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -90,15 +88,16 @@ func (a *Menu) Get(ctx context.Context, recordID string, opts ...schema.MenuQuer
 		return nil, nil
 	}
 
-	return item.ToSchemaMenu(), nil
+	return item.ToSchemaOrg(), nil
 }
 
 // Create 创建数据
-func (a *Menu) Create(ctx context.Context, item schema.Menu) error {
-	eitem := entity.SchemaMenu(item).ToMenu()
+func (a *Org) Create(ctx context.Context, item schema.Org) error {
+	eitem := entity.SchemaOrg(item).ToOrg()
+	eitem.TenantID = GetTenantID(ctx, eitem.TenantID)
 	eitem.CreatedAt = time.Now()
 	eitem.UpdatedAt = time.Now()
-	c := entity.GetMenuCollection(ctx, a.Client)
+	c := entity.GetOrgCollection(ctx, a.Client)
 	err := Insert(ctx, c, eitem)
 	if err != nil {
 		return errors.WithStack(err)
@@ -107,10 +106,10 @@ func (a *Menu) Create(ctx context.Context, item schema.Menu) error {
 }
 
 // Update 更新数据
-func (a *Menu) Update(ctx context.Context, recordID string, item schema.Menu) error {
-	eitem := entity.SchemaMenu(item).ToMenu()
+func (a *Org) Update(ctx context.Context, recordID string, item schema.Org) error {
+	eitem := entity.SchemaOrg(item).ToOrg()
 	eitem.UpdatedAt = time.Now()
-	c := entity.GetMenuCollection(ctx, a.Client)
+	c := entity.GetOrgCollection(ctx, a.Client)
 	err := Update(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), eitem)
 	if err != nil {
 		return errors.WithStack(err)
@@ -119,8 +118,8 @@ func (a *Menu) Update(ctx context.Context, recordID string, item schema.Menu) er
 }
 
 // Delete 删除数据
-func (a *Menu) Delete(ctx context.Context, recordID string) error {
-	c := entity.GetMenuCollection(ctx, a.Client)
+func (a *Org) Delete(ctx context.Context, recordID string) error {
+	c := entity.GetOrgCollection(ctx, a.Client)
 	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
 	if err != nil {
 		return errors.WithStack(err)
@@ -129,8 +128,8 @@ func (a *Menu) Delete(ctx context.Context, recordID string) error {
 }
 
 // UpdateStatus 更新状态
-func (a *Menu) UpdateStatus(ctx context.Context, recordID string, status int) error {
-	c := entity.GetMenuCollection(ctx, a.Client)
+func (a *Org) UpdateStatus(ctx context.Context, recordID string, status int) error {
+	c := entity.GetOrgCollection(ctx, a.Client)
 	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"status": status})
 	if err != nil {
 		return errors.WithStack(err)
@@ -139,8 +138,8 @@ func (a *Menu) UpdateStatus(ctx context.Context, recordID string, status int) er
 }
 
 // UpdateParentPath 更新父级路径
-func (a *Menu) UpdateParentPath(ctx context.Context, recordID, parentPath string) error {
-	c := entity.GetMenuCollection(ctx, a.Client)
+func (a *Org) UpdateParentPath(ctx context.Context, recordID, parentPath string) error {
+	c := entity.GetOrgCollection(ctx, a.Client)
 	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"parent_path": parentPath})
 	if err != nil {
 		return errors.WithStack(err)
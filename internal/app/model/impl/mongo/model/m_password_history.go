@@ -0,0 +1,89 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/mongo/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.IPasswordHistory = (*PasswordHistory)(nil)
+
+// PasswordHistorySet 注入PasswordHistory
+var PasswordHistorySet = wire.NewSet(wire.Struct(new(PasswordHistory), "*"), wire.Bind(new(model.IPasswordHistory), new(*PasswordHistory)))
+
+// PasswordHistory 用户历史密码存储
+type PasswordHistory struct {
+	Client *mongo.Client
+}
+
+func (a *PasswordHistory) getQueryOption(opts ...schema.PasswordHistoryQueryOptions) schema.PasswordHistoryQueryOptions {
+	var opt schema.PasswordHistoryQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *PasswordHistory) Query(ctx context.Context, params schema.PasswordHistoryQueryParam, opts ...schema.PasswordHistoryQueryOptions) (*schema.PasswordHistoryQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetPasswordHistoryCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx)
+	if v := params.UserID; v != "" {
+		filter = append(filter, Filter("user_id", v))
+	}
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("created_at", schema.OrderByDESC))
+
+	var list entity.PasswordHistories
+	pr, err := WrapPageQuery(ctx, c, params.PaginationParam, filter, &list, options.Find().SetSort(ParseOrder(opt.OrderFields)))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.PasswordHistoryQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaPasswordHistories(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *PasswordHistory) Create(ctx context.Context, item schema.PasswordHistory) error {
+	eitem := entity.SchemaPasswordHistory(item).ToPasswordHistory()
+	eitem.CreatedAt = time.Now()
+	eitem.UpdatedAt = time.Now()
+	c := entity.GetPasswordHistoryCollection(ctx, a.Client)
+	err := Insert(ctx, c, eitem)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *PasswordHistory) Delete(ctx context.Context, recordID string) error {
+	c := entity.GetPasswordHistoryCollection(ctx, a.Client)
+	err := Delete(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *PasswordHistory) DeleteByUserID(ctx context.Context, userID string) error {
+	c := entity.GetPasswordHistoryCollection(ctx, a.Client)
+	err := DeleteMany(ctx, c, GlobalFilter(ctx, Filter("user_id", userID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/mongo/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.IPasswordReset = (*PasswordReset)(nil)
+
+// PasswordResetSet 注入PasswordReset
+var PasswordResetSet = wire.NewSet(wire.Struct(new(PasswordReset), "*"), wire.Bind(new(model.IPasswordReset), new(*PasswordReset)))
+
+// PasswordReset 密码重置令牌存储
+type PasswordReset struct {
+	Client *mongo.Client
+}
+
+func (a *PasswordReset) getQueryOption(opts ...schema.PasswordResetQueryOptions) schema.PasswordResetQueryOptions {
+	var opt schema.PasswordResetQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *PasswordReset) Query(ctx context.Context, params schema.PasswordResetQueryParam, opts ...schema.PasswordResetQueryOptions) (*schema.PasswordResetQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetPasswordResetCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx)
+	if v := params.UserID; v != "" {
+		filter = append(filter, Filter("user_id", v))
+	}
+	if v := params.TokenHash; v != "" {
+		filter = append(filter, Filter("token_hash", v))
+	}
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("created_at", schema.OrderByDESC))
+
+	var list entity.PasswordResets
+	pr, err := WrapPageQuery(ctx, c, params.PaginationParam, filter, &list, options.Find().SetSort(ParseOrder(opt.OrderFields)))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.PasswordResetQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaPasswordResets(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *PasswordReset) Create(ctx context.Context, item schema.PasswordReset) error {
+	eitem := entity.SchemaPasswordReset(item).ToPasswordReset()
+	eitem.CreatedAt = time.Now()
+	eitem.UpdatedAt = time.Now()
+	c := entity.GetPasswordResetCollection(ctx, a.Client)
+	err := Insert(ctx, c, eitem)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *PasswordReset) Delete(ctx context.Context, recordID string) error {
+	c := entity.GetPasswordResetCollection(ctx, a.Client)
+	err := Delete(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByTokenHash 根据令牌哈希删除数据
+func (a *PasswordReset) DeleteByTokenHash(ctx context.Context, tokenHash string) (bool, error) {
+	c := entity.GetPasswordResetCollection(ctx, a.Client)
+	result, err := c.UpdateOne(ctx, GlobalFilter(ctx, Filter("token_hash", tokenHash)), bson.D{{Key: "$set", Value: bson.M{"deleted_at": time.Now()}}})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *PasswordReset) DeleteByUserID(ctx context.Context, userID string) error {
+	c := entity.GetPasswordResetCollection(ctx, a.Client)
+	err := DeleteMany(ctx, c, GlobalFilter(ctx, Filter("user_id", userID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
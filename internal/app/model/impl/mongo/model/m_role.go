@@ -61,6 +61,9 @@ func (a *Role) Query(ctx context.Context, params schema.RoleQueryParam, opts ...
 	if v := params.Status; v > 0 {
 		filter = append(filter, Filter("status", v))
 	}
+	if v := params.ParentID; v != nil {
+		filter = append(filter, Filter("parent_id", *v))
+	}
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("_id", schema.OrderByDESC))
 
 	var list entity.Roles
@@ -94,6 +97,7 @@ func (a *Role) Get(ctx context.Context, recordID string, opts ...schema.RoleQuer
 // Create 创建数据
 func (a *Role) Create(ctx context.Context, item schema.Role) error {
 	eitem := entity.SchemaRole(item).ToRole()
+	eitem.TenantID = GetTenantID(ctx, eitem.TenantID)
 	eitem.CreatedAt = time.Now()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetRoleCollection(ctx, a.Client)
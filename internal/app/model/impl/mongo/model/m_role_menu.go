@@ -78,6 +78,7 @@ func (a *RoleMenu) Get(ctx context.Context, recordID string, opts ...schema.Role
 // Create 创建数据
 func (a *RoleMenu) Create(ctx context.Context, item schema.RoleMenu) error {
 	eitem := entity.SchemaRoleMenu(item).ToRoleMenu()
+	eitem.TenantID = GetTenantID(ctx, eitem.TenantID)
 	eitem.CreatedAt = time.Now()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetRoleMenuCollection(ctx, a.Client)
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/mongo/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/google/wire"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.ITenant = (*Tenant)(nil)
+
+// TenantSet 注入Tenant
+var TenantSet = wire.NewSet(wire.Struct(new(Tenant), "*"), wire.Bind(new(model.ITenant), new(*Tenant)))
+
+// Tenant 租户存储
+type Tenant struct {
+	Client *mongo.Client
+}
+
+func (a *Tenant) getQueryOption(opts ...schema.TenantQueryOptions) schema.TenantQueryOptions {
+	var opt schema.TenantQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *Tenant) Query(ctx context.Context, params schema.TenantQueryParam, opts ...schema.TenantQueryOptions) (*schema.TenantQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetTenantCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx)
+	if v := params.RecordIDs; len(v) > 0 {
+		filter = append(filter, Filter("_id", bson.M{"$in": v}))
+	}
+	if v := params.Code; v != "" {
+		filter = append(filter, Filter("code", v))
+	}
+	if v := params.Status; v > 0 {
+		filter = append(filter, Filter("status", v))
+	}
+	if v := params.QueryValue; v != "" {
+		filter = append(filter, Filter("$or", bson.A{
+			OrRegexFilter("code", v),
+			OrRegexFilter("name", v),
+			OrRegexFilter("memo", v),
+		}))
+	}
+
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("_id", schema.OrderByDESC))
+
+	var list entity.Tenants
+	pr, err := WrapPageQuery(ctx, c, params.PaginationParam, filter, &list, options.Find().SetSort(ParseOrder(opt.OrderFields)))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.TenantQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaTenants(),
+	}
+
+	return qr, nil
+}
+
+// Get 查询指定数据
+func (a *Tenant) Get(ctx context.Context, recordID string, opts ...schema.TenantQueryOptions) (*schema.Tenant, error) {
+	c := entity.GetTenantCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx, Filter("_id", recordID))
+
+	var item entity.Tenant
+	ok, err := FindOne(ctx, c, filter, &item)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, nil
+	}
+
+	return item.ToSchemaTenant(), nil
+}
+
+// Create 创建数据
+func (a *Tenant) Create(ctx context.Context, item schema.Tenant) error {
+	eitem := entity.SchemaTenant(item).ToTenant()
+	eitem.CreatedAt = time.Now()
+	eitem.UpdatedAt = time.Now()
+	c := entity.GetTenantCollection(ctx, a.Client)
+	err := Insert(ctx, c, eitem)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Update 更新数据
+func (a *Tenant) Update(ctx context.Context, recordID string, item schema.Tenant) error {
+	eitem := entity.SchemaTenant(item).ToTenant()
+	eitem.UpdatedAt = time.Now()
+	c := entity.GetTenantCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx, Filter("_id", recordID))
+
+	err := Update(ctx, c, filter, eitem)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *Tenant) Delete(ctx context.Context, recordID string) error {
+	c := entity.GetTenantCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx, Filter("_id", recordID))
+
+	err := Delete(ctx, c, filter)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateStatus 更新状态
+func (a *Tenant) UpdateStatus(ctx context.Context, recordID string, status int) error {
+	c := entity.GetTenantCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx, Filter("_id", recordID))
+
+	err := UpdateFields(ctx, c, filter, bson.M{"status": status})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -32,12 +32,24 @@ func (a *User) getQueryOption(opts ...schema.UserQueryOptions) schema.UserQueryO
 	return opt
 }
 
+// 过滤当前租户内及数据范围内的用户
+func (a *User) wrapScope(ctx context.Context, filter bson.D) (bson.D, error) {
+	filter, err := WrapTenantUser(ctx, a.Client, filter)
+	if err != nil {
+		return nil, err
+	}
+	return WrapDataScope(ctx, a.Client, filter, "org_id")
+}
+
 // Query 查询数据
 func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...schema.UserQueryOptions) (*schema.UserQueryResult, error) {
 	opt := a.getQueryOption(opts...)
 
 	c := entity.GetUserCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx)
+	filter := GlobalFilter(ctx)
+	if v := params.RecordIDs; len(v) > 0 {
+		filter = append(filter, Filter("_id", bson.M{"$in": v}))
+	}
 	if v := params.UserName; v != "" {
 		filter = append(filter, Filter("user_name", v))
 	}
@@ -56,9 +68,34 @@ func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...
 		}
 		filter = append(filter, Filter("_id", bson.M{"$in": result}))
 	}
+	if v := params.OrgID; v != "" {
+		result, err := entity.GetUserCollection(ctx, a.Client).Distinct(ctx, "_id", GlobalFilter(ctx, Filter("org_id", v)))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		orgUserIDs, err := entity.GetUserOrgCollection(ctx, a.Client).Distinct(ctx, "user_id", DefaultFilter(ctx, Filter("org_id", v)))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		filter = append(filter, Filter("_id", bson.M{"$in": append(result, orgUserIDs...)}))
+	}
 	if v := params.Status; v > 0 {
 		filter = append(filter, Filter("status", v))
 	}
+	if v := params.Type; v > 0 {
+		filter = append(filter, Filter("type", v))
+	}
+	if v := params.Provider; v != "" {
+		filter = append(filter, Filter("provider", v))
+	}
+	if v := params.Subject; v != "" {
+		filter = append(filter, Filter("subject", v))
+	}
+	filter, err := a.wrapScope(ctx, filter)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("_id", schema.OrderByDESC))
 
 	var list entity.Users
@@ -77,7 +114,11 @@ func (a *User) Query(ctx context.Context, params schema.UserQueryParam, opts ...
 // Get 查询指定数据
 func (a *User) Get(ctx context.Context, recordID string, opts ...schema.UserQueryOptions) (*schema.User, error) {
 	c := entity.GetUserCollection(ctx, a.Client)
-	filter := DefaultFilter(ctx, Filter("_id", recordID))
+	filter, err := a.wrapScope(ctx, GlobalFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	var item entity.User
 	ok, err := FindOne(ctx, c, filter, &item)
 	if err != nil {
@@ -107,7 +148,12 @@ func (a *User) Update(ctx context.Context, recordID string, item schema.User) er
 	eitem := entity.SchemaUser(item).ToUser()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetUserCollection(ctx, a.Client)
-	err := Update(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), eitem)
+	filter, err := a.wrapScope(ctx, GlobalFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = Update(ctx, c, filter, eitem)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -117,7 +163,12 @@ func (a *User) Update(ctx context.Context, recordID string, item schema.User) er
 // Delete 删除数据
 func (a *User) Delete(ctx context.Context, recordID string) error {
 	c := entity.GetUserCollection(ctx, a.Client)
-	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
+	filter, err := a.wrapScope(ctx, GlobalFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = Delete(ctx, c, filter)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -127,7 +178,12 @@ func (a *User) Delete(ctx context.Context, recordID string) error {
 // UpdateStatus 更新状态
 func (a *User) UpdateStatus(ctx context.Context, recordID string, status int) error {
 	c := entity.GetUserCollection(ctx, a.Client)
-	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"status": status})
+	filter, err := a.wrapScope(ctx, GlobalFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = UpdateFields(ctx, c, filter, bson.M{"status": status})
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -137,9 +193,51 @@ func (a *User) UpdateStatus(ctx context.Context, recordID string, status int) er
 // UpdatePassword 更新密码
 func (a *User) UpdatePassword(ctx context.Context, recordID, password string) error {
 	c := entity.GetUserCollection(ctx, a.Client)
-	err := UpdateFields(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)), bson.M{"password": password})
+	err := UpdateFields(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), bson.M{"password": password})
 	if err != nil {
 		return errors.WithStack(err)
 	}
 	return nil
 }
+
+// ChangePassword 修改密码
+func (a *User) ChangePassword(ctx context.Context, recordID string, item schema.UserPassword) error {
+	c := entity.GetUserCollection(ctx, a.Client)
+	err := UpdateFields(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), bson.M{
+		"password":             item.Password,
+		"password_changed_at":  item.ChangedAt,
+		"must_change_password": item.MustChange,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateMFA 更新多因素认证信息
+func (a *User) UpdateMFA(ctx context.Context, recordID string, item schema.UserMFA) error {
+	c := entity.GetUserCollection(ctx, a.Client)
+	err := UpdateFields(ctx, c, GlobalFilter(ctx, Filter("_id", recordID)), bson.M{
+		"mfa_enabled":        item.Enabled,
+		"mfa_secret":         item.Secret,
+		"mfa_recovery_codes": item.RecoveryCodes,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// UpdateMFAStep 更新多因素认证最后使用的时间步长
+func (a *User) UpdateMFAStep(ctx context.Context, recordID string, step int64) (bool, error) {
+	c := entity.GetUserCollection(ctx, a.Client)
+	filter := GlobalFilter(ctx, Filter("_id", recordID), Filter("$or", bson.A{
+		bson.M{"mfa_last_step": bson.M{"$lt": step}},
+		bson.M{"mfa_last_step": bson.M{"$exists": false}},
+	}))
+	result, err := c.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: bson.M{"mfa_last_step": step}}})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return result.ModifiedCount == 1, nil
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/mongo/entity"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/google/wire"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var _ model.IUserOrg = (*UserOrg)(nil)
+
+// UserOrgSet 注入UserOrg
+var UserOrgSet = wire.NewSet(wire.Struct(new(UserOrg), "*"), wire.Bind(new(model.IUserOrg), new(*UserOrg)))
+
+// UserOrg 用户兼任部门存储
+type UserOrg struct {
+	Client *mongo.Client
+}
+
+func (a *UserOrg) getQueryOption(opts ...schema.UserOrgQueryOptions) schema.UserOrgQueryOptions {
+	var opt schema.UserOrgQueryOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+// Query 查询数据
+func (a *UserOrg) Query(ctx context.Context, params schema.UserOrgQueryParam, opts ...schema.UserOrgQueryOptions) (*schema.UserOrgQueryResult, error) {
+	opt := a.getQueryOption(opts...)
+
+	c := entity.GetUserOrgCollection(ctx, a.Client)
+	filter := DefaultFilter(ctx)
+	userIDs := params.UserIDs
+	if v := params.UserID; v != "" {
+		userIDs = append(userIDs, v)
+	}
+	if v := userIDs; len(v) > 0 {
+		filter = append(filter, Filter("user_id", bson.M{"$in": v}))
+	}
+	if v := params.OrgIDs; len(v) > 0 {
+		filter = append(filter, Filter("org_id", bson.M{"$in": v}))
+	}
+	opt.OrderFields = append(opt.OrderFields, schema.NewOrderField("_id", schema.OrderByDESC))
+
+	var list entity.UserOrgs
+	pr, err := WrapPageQuery(ctx, c, params.PaginationParam, filter, &list, options.Find().SetSort(ParseOrder(opt.OrderFields)))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	qr := &schema.UserOrgQueryResult{
+		PageResult: pr,
+		Data:       list.ToSchemaUserOrgs(),
+	}
+
+	return qr, nil
+}
+
+// Create 创建数据
+func (a *UserOrg) Create(ctx context.Context, item schema.UserOrg) error {
+	eitem := entity.SchemaUserOrg(item).ToUserOrg()
+	eitem.TenantID = GetTenantID(ctx, eitem.TenantID)
+	eitem.CreatedAt = time.Now()
+	eitem.UpdatedAt = time.Now()
+	c := entity.GetUserOrgCollection(ctx, a.Client)
+	err := Insert(ctx, c, eitem)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Delete 删除数据
+func (a *UserOrg) Delete(ctx context.Context, recordID string) error {
+	c := entity.GetUserOrgCollection(ctx, a.Client)
+	err := Delete(ctx, c, DefaultFilter(ctx, Filter("_id", recordID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// DeleteByUserID 根据用户ID删除数据
+func (a *UserOrg) DeleteByUserID(ctx context.Context, userID string) error {
+	c := entity.GetUserOrgCollection(ctx, a.Client)
+	err := DeleteMany(ctx, c, DefaultFilter(ctx, Filter("user_id", userID)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
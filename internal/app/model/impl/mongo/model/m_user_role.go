@@ -78,6 +78,7 @@ func (a *UserRole) Get(ctx context.Context, recordID string, opts ...schema.User
 // Create 创建数据
 func (a *UserRole) Create(ctx context.Context, item schema.UserRole) error {
 	eitem := entity.SchemaUserRole(item).ToUserRole()
+	eitem.TenantID = GetTenantID(ctx, eitem.TenantID)
 	eitem.CreatedAt = time.Now()
 	eitem.UpdatedAt = time.Now()
 	c := entity.GetUserRoleCollection(ctx, a.Client)
@@ -53,12 +53,18 @@ func CreateIndexes(ctx context.Context, cli *mongo.Client) error {
 	return createIndexes(
 		ctx,
 		cli,
+		new(entity.APIToken),
 		new(entity.Demo),
 		new(entity.MenuAction),
 		new(entity.MenuActionResource),
+		new(entity.PasswordHistory),
+		new(entity.PasswordReset),
 		new(entity.Menu),
+		new(entity.Org),
 		new(entity.RoleMenu),
 		new(entity.Role),
+		new(entity.Tenant),
+		new(entity.UserOrg),
 		new(entity.UserRole),
 		new(entity.User),
 	)
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+)
+
+// IAPIToken 访问令牌存储接口
+type IAPIToken interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.APITokenQueryParam, opts ...schema.APITokenQueryOptions) (*schema.APITokenQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, recordID string, opts ...schema.APITokenQueryOptions) (*schema.APIToken, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.APIToken) error
+	// 删除数据
+	Delete(ctx context.Context, recordID string) error
+	// 根据用户ID删除数据
+	DeleteByUserID(ctx context.Context, userID string) error
+	// 更新最后使用时间
+	UpdateLastUsed(ctx context.Context, recordID string, lastUsedAt time.Time) error
+}
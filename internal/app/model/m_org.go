@@ -0,0 +1,25 @@
+package model
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+)
+
+// IOrg 部门管理存储接口
+type IOrg interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.OrgQueryParam, opts ...schema.OrgQueryOptions) (*schema.OrgQueryResult, error)
+	// 查询指定数据
+	Get(ctx context.Context, recordID string, opts ...schema.OrgQueryOptions) (*schema.Org, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.Org) error
+	// 更新数据
+	Update(ctx context.Context, recordID string, item schema.Org) error
+	// 删除数据
+	Delete(ctx context.Context, recordID string) error
+	// 更新父级路径
+	UpdateParentPath(ctx context.Context, recordID, parentPath string) error
+	// 更新状态
+	UpdateStatus(ctx context.Context, recordID string, status int) error
+}
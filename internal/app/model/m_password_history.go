@@ -0,0 +1,19 @@
+package model
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+)
+
+// IPasswordHistory 用户历史密码存储接口
+type IPasswordHistory interface {
+	// 查询数据(按创建时间倒序)
+	Query(ctx context.Context, params schema.PasswordHistoryQueryParam, opts ...schema.PasswordHistoryQueryOptions) (*schema.PasswordHistoryQueryResult, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.PasswordHistory) error
+	// 删除数据
+	Delete(ctx context.Context, recordID string) error
+	// 根据用户ID删除数据
+	DeleteByUserID(ctx context.Context, userID string) error
+}
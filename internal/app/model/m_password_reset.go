@@ -0,0 +1,21 @@
+package model
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+)
+
+// IPasswordReset 密码重置令牌存储接口
+type IPasswordReset interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.PasswordResetQueryParam, opts ...schema.PasswordResetQueryOptions) (*schema.PasswordResetQueryResult, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.PasswordReset) error
+	// 删除数据
+	Delete(ctx context.Context, recordID string) error
+	// 根据令牌哈希删除数据(返回是否删除了数据，用于保证令牌只能使用一次)
+	DeleteByTokenHash(ctx context.Context, tokenHash string) (bool, error)
+	// 根据用户ID删除数据
+	DeleteByUserID(ctx context.Context, userID string) error
+}
@@ -20,6 +20,12 @@ type IUser interface {
 	Delete(ctx context.Context, recordID string) error
 	// 更新状态
 	UpdateStatus(ctx context.Context, recordID string, status int) error
-	// 更新密码
+	// 更新密码(仅更新哈希值，用于哈希算法升级)
 	UpdatePassword(ctx context.Context, recordID, password string) error
+	// 修改密码(同时更新密码修改时间及下次登录是否必须修改密码)
+	ChangePassword(ctx context.Context, recordID string, item schema.UserPassword) error
+	// 更新多因素认证信息
+	UpdateMFA(ctx context.Context, recordID string, item schema.UserMFA) error
+	// 更新多因素认证最后使用的时间步长(只允许递增，返回是否更新成功)
+	UpdateMFAStep(ctx context.Context, recordID string, step int64) (bool, error)
 }
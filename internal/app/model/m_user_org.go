@@ -0,0 +1,19 @@
+package model
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+)
+
+// IUserOrg 用户兼任部门存储接口
+type IUserOrg interface {
+	// 查询数据
+	Query(ctx context.Context, params schema.UserOrgQueryParam, opts ...schema.UserOrgQueryOptions) (*schema.UserOrgQueryResult, error)
+	// 创建数据
+	Create(ctx context.Context, item schema.UserOrg) error
+	// 删除数据
+	Delete(ctx context.Context, recordID string) error
+	// 根据用户ID删除数据
+	DeleteByUserID(ctx context.Context, userID string) error
+}
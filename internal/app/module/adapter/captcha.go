@@ -0,0 +1,75 @@
+package adapter
+
+import (
+	"time"
+
+	"github.com/LyricTian/captcha/store"
+	"github.com/tidwall/buntdb"
+)
+
+var _ store.Store = (*CaptchaBuntdbStore)(nil)
+
+// 验证码在buntdb中的键前缀
+const captchaKeyPrefix = "captcha:"
+
+// NewCaptchaBuntdbStore 创建基于buntdb的图形验证码存储
+func NewCaptchaBuntdbStore(db *buntdb.DB, expiration time.Duration, out store.Logger) *CaptchaBuntdbStore {
+	return &CaptchaBuntdbStore{
+		db:         db,
+		expiration: expiration,
+		out:        out,
+	}
+}
+
+// CaptchaBuntdbStore 图形验证码的buntdb存储
+type CaptchaBuntdbStore struct {
+	db         *buntdb.DB
+	expiration time.Duration
+	out        store.Logger
+}
+
+// Set sets the digits for the captcha id.
+func (a *CaptchaBuntdbStore) Set(id string, digits []byte) {
+	err := a.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(captchaKeyPrefix+id, string(digits), &buntdb.SetOptions{
+			Expires: true,
+			TTL:     a.expiration,
+		})
+		return err
+	})
+	if err != nil && a.out != nil {
+		a.out.Printf("captcha buntdb store set error: %s", err.Error())
+	}
+}
+
+// Get returns stored digits for the captcha id. Clear indicates
+// whether the captcha must be deleted from the store.
+func (a *CaptchaBuntdbStore) Get(id string, clear bool) []byte {
+	var digits []byte
+	fn := func(tx *buntdb.Tx) error {
+		val, err := tx.Get(captchaKeyPrefix + id)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		digits = []byte(val)
+
+		if clear {
+			_, err = tx.Delete(captchaKeyPrefix + id)
+		}
+		return err
+	}
+
+	var err error
+	if clear {
+		err = a.db.Update(fn)
+	} else {
+		err = a.db.View(fn)
+	}
+	if err != nil && a.out != nil {
+		a.out.Printf("captcha buntdb store get error: %s", err.Error())
+	}
+	return digits
+}
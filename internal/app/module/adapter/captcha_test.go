@@ -0,0 +1,23 @@
+package adapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/buntdb"
+)
+
+func TestCaptchaBuntdbStore(t *testing.T) {
+	db, err := buntdb.Open(":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	store := NewCaptchaBuntdbStore(db, time.Minute, nil)
+	store.Set("foo", []byte{1, 2, 3, 4})
+
+	assert.Equal(t, []byte{1, 2, 3, 4}, store.Get("foo", false))
+	assert.Equal(t, []byte{1, 2, 3, 4}, store.Get("foo", true))
+	assert.Nil(t, store.Get("foo", false))
+	assert.Nil(t, store.Get("bar", true))
+}
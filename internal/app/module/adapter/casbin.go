@@ -3,9 +3,13 @@ package adapter
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
 	"github.com/wangwei518/gin-admin/internal/app/model"
 	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
 	"github.com/wangwei518/gin-admin/pkg/logger"
 	casbinModel "github.com/casbin/casbin/v2/model"
 	"github.com/casbin/casbin/v2/persist"
@@ -29,12 +33,20 @@ type CasbinAdapter struct {
 // LoadPolicy loads all policy rules from the storage.
 func (a *CasbinAdapter) LoadPolicy(model casbinModel.Model) error {
 	ctx := context.Background()
+	a.loadRootPolicy(model)
+
 	err := a.loadRolePolicy(ctx, model)
 	if err != nil {
 		logger.Errorf(ctx, "Load casbin role policy error: %s", err.Error())
 		return err
 	}
 
+	err = a.loadRoleInheritPolicy(ctx, model)
+	if err != nil {
+		logger.Errorf(ctx, "Load casbin role inherit policy error: %s", err.Error())
+		return err
+	}
+
 	err = a.loadUserPolicy(ctx, model)
 	if err != nil {
 		logger.Errorf(ctx, "Load casbin user policy error: %s", err.Error())
@@ -43,79 +55,177 @@ func (a *CasbinAdapter) LoadPolicy(model casbinModel.Model) error {
 	return nil
 }
 
-// 加载角色策略(p,role_id,path,method)
+// 加载超级管理员的策略(允许访问全部租户的全部资源，超级管理员不分配角色，因此不受拒绝规则的限制)
+func (a *CasbinAdapter) loadRootPolicy(m casbinModel.Model) {
+	rule := []string{config.C.Root.UserName, "*", "/*", ".*", "*", schema.ResourceEffectAllow, ""}
+	persist.LoadPolicyLine("p,"+strings.Join(rule, ","), m)
+}
+
+// 加载角色策略(p,role_id,tenant_id,path,method,view,eft,cond)
 func (a *CasbinAdapter) loadRolePolicy(ctx context.Context, m casbinModel.Model) error {
+	policies, err := a.QueryRolePolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range policies {
+		persist.LoadPolicyLine("p,"+strings.Join(rule, ","), m)
+	}
+	return nil
+}
+
+// 加载角色继承策略(g,role_id,parent_id,tenant_id)
+func (a *CasbinAdapter) loadRoleInheritPolicy(ctx context.Context, m casbinModel.Model) error {
+	policies, err := a.QueryRoleInheritPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range policies {
+		persist.LoadPolicyLine("g,"+strings.Join(rule, ","), m)
+	}
+	return nil
+}
+
+// 加载用户策略(g,user_id,role_id,tenant_id)
+func (a *CasbinAdapter) loadUserPolicy(ctx context.Context, m casbinModel.Model) error {
+	policies, err := a.QueryUserPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range policies {
+		persist.LoadPolicyLine("g,"+strings.Join(rule, ","), m)
+	}
+	return nil
+}
+
+// QueryRolePolicies 查询角色策略规则(role_id,tenant_id,path,method,view,eft,cond)，未指定角色时查询全部启用的角色
+// 资源未限定视图时使用*匹配全部视图，访问条件编码为一个字段，停用的角色没有策略规则
+func (a *CasbinAdapter) QueryRolePolicies(ctx context.Context, roleIDs ...string) ([][]string, error) {
+	ctx = icontext.NewNoTenant(ctx)
 	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
-		Status: 1,
+		RecordIDs: roleIDs,
+		Status:    1,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	} else if len(roleResult.Data) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{})
+	roleMenuResult, err := a.RoleMenuModel.Query(ctx, schema.RoleMenuQueryParam{
+		RoleIDs: roleIDs,
+	})
 	if err != nil {
-		return err
+		return nil, err
+	} else if len(roleMenuResult.Data) == 0 {
+		return nil, nil
 	}
 	mRoleMenus := roleMenuResult.Data.ToRoleIDMap()
 
-	menuResourceResult, err := a.MenuResourceModel.Query(ctx, schema.MenuActionResourceQueryParam{})
+	menuResourceResult, err := a.MenuResourceModel.Query(ctx, schema.MenuActionResourceQueryParam{
+		MenuIDs: roleMenuResult.Data.ToMenuIDs(),
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	mMenuResources := menuResourceResult.Data.ToActionIDMap()
 
-	mcache := make(map[string]struct{})
+	var policies [][]string
 	for _, item := range roleResult.Data {
-		if rms, ok := mRoleMenus[item.RecordID]; ok {
-			for _, actionID := range rms.ToActionIDs() {
-				if mrs, ok := mMenuResources[actionID]; ok {
-					for _, mr := range mrs {
-						if mr.Path == "" || mr.Method == "" {
-							continue
-						} else if _, ok := mcache[mr.Path+mr.Method]; ok {
-							continue
-						}
-						mcache[mr.Path+mr.Method] = struct{}{}
-						line := fmt.Sprintf("p,%s,%s,%s", item.RecordID, mr.Path, mr.Method)
-						persist.LoadPolicyLine(line, m)
-					}
+		rms, ok := mRoleMenus[item.RecordID]
+		if !ok {
+			continue
+		}
+
+		// 同一角色下相同的资源只保留一条规则
+		mcache := make(map[string]struct{})
+		for _, actionID := range rms.ToActionIDs() {
+			mrs, ok := mMenuResources[actionID]
+			if !ok {
+				continue
+			}
+
+			for _, mr := range mrs {
+				if mr.Path == "" || mr.Method == "" {
+					continue
+				}
+
+				view := mr.View
+				if view == "" {
+					view = "*"
 				}
+				cond := abac.Encode(mr.IPRanges, mr.TimeWindow, mr.Attributes)
+				key := fmt.Sprintf("%s,%s,%s,%s,%s", mr.Path, mr.Method, view, mr.GetEffect(), cond)
+				if _, ok := mcache[key]; ok {
+					continue
+				}
+				mcache[key] = struct{}{}
+				policies = append(policies, []string{item.RecordID, item.TenantID, mr.Path, mr.Method, view, mr.GetEffect(), cond})
 			}
 		}
 	}
 
-	return nil
+	return policies, nil
 }
 
-// 加载用户策略(g,user_id,role_id)
-func (a *CasbinAdapter) loadUserPolicy(ctx context.Context, m casbinModel.Model) error {
-	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+// QueryRoleInheritPolicies 查询角色继承规则(role_id,parent_id,tenant_id)
+// 角色及其上级角色均启用时才有继承规则，停用的角色不继承也不被继承
+func (a *CasbinAdapter) QueryRoleInheritPolicies(ctx context.Context) ([][]string, error) {
+	ctx = icontext.NewNoTenant(ctx)
+	roleResult, err := a.RoleModel.Query(ctx, schema.RoleQueryParam{
 		Status: 1,
 	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var policies [][]string
+	mRoles := roleResult.Data.ToMap()
+	for _, item := range roleResult.Data {
+		if item.ParentID == "" {
+			continue
+		} else if _, ok := mRoles[item.ParentID]; !ok {
+			continue
+		}
+		policies = append(policies, []string{item.RecordID, item.ParentID, item.TenantID})
+	}
+	return policies, nil
+}
+
+// QueryUserPolicies 查询用户的角色分配规则(user_id,role_id,tenant_id)，未指定用户时查询全部启用的用户
+// 停用的用户没有角色分配规则，同一用户在不同租户中可以分配不同的角色
+func (a *CasbinAdapter) QueryUserPolicies(ctx context.Context, userIDs ...string) ([][]string, error) {
+	ctx = icontext.NewNoTenant(ctx)
+	userResult, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		RecordIDs: userIDs,
+		Status:    1,
+	})
+	if err != nil {
+		return nil, err
 	} else if len(userResult.Data) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{})
+	userRoleResult, err := a.UserRoleModel.Query(ctx, schema.UserRoleQueryParam{
+		UserIDs: userIDs,
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var policies [][]string
 	mUserRoles := userRoleResult.Data.ToUserIDMap()
 	for _, uitem := range userResult.Data {
 		if urs, ok := mUserRoles[uitem.RecordID]; ok {
 			for _, ur := range urs {
-				line := fmt.Sprintf("g,%s,%s", ur.UserID, ur.RoleID)
-				persist.LoadPolicyLine(line, m)
+				policies = append(policies, []string{ur.UserID, ur.RoleID, ur.TenantID})
 			}
 		}
 	}
 
-	return nil
+	return policies, nil
 }
 
 // SavePolicy saves all policy rules to the storage.
@@ -125,6 +235,8 @@ func (a *CasbinAdapter) SavePolicy(model casbinModel.Model) error {
 
 // AddPolicy adds a policy rule to the storage.
 // This is part of the Auto-Save feature.
+// 策略规则由角色、菜单和用户数据计算得出，不单独存储，因此Auto-Save相关的方法均不做处理
+// (增量更新由业务层根据数据变更计算差异后直接应用于Enforcer)
 func (a *CasbinAdapter) AddPolicy(sec string, ptype string, rule []string) error {
 	return nil
 }
@@ -0,0 +1,78 @@
+package authenticator
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+)
+
+// 认证器名称
+const (
+	RootName  = "root"
+	LocalName = "local"
+	LDAPName  = "ldap"
+	OIDCName  = "oidc"
+)
+
+// Authenticator 登录认证器
+//
+// Authenticate 的返回值约定：
+// 返回身份信息表示认证通过；返回错误表示拒绝登录；
+// 两者均为nil表示当前认证器无法处理该用户，交由下一个认证器处理
+type Authenticator interface {
+	// 认证器名称
+	Name() string
+	// 认证用户名和密码
+	Authenticate(ctx context.Context, userName, password string) (*Identity, error)
+}
+
+// Identity 认证通过的身份信息
+type Identity struct {
+	User    *schema.User // 用户信息
+	Subject string       // 外部用户标识(如OIDC的sub，为空时使用用户名)
+	Groups  []string     // 外部用户组(如LDAP组DN)
+}
+
+// Result 认证结果
+type Result struct {
+	Identity
+	Provider string // 认证通过的认证器名称
+}
+
+// NewChain 创建认证器链
+func NewChain(authenticators ...Authenticator) *Chain {
+	return &Chain{
+		authenticators: authenticators,
+	}
+}
+
+// Chain 认证器链(按顺序依次执行认证)
+type Chain struct {
+	authenticators []Authenticator
+}
+
+// Names 认证器名称列表
+func (a *Chain) Names() []string {
+	names := make([]string, len(a.authenticators))
+	for i, item := range a.authenticators {
+		names[i] = item.Name()
+	}
+	return names
+}
+
+// Authenticate 依次执行认证器，直到有认证器接受或拒绝该用户
+func (a *Chain) Authenticate(ctx context.Context, userName, password string) (*Result, error) {
+	for _, item := range a.authenticators {
+		identity, err := item.Authenticate(ctx, userName, password)
+		if err != nil {
+			return nil, err
+		} else if identity != nil {
+			return &Result{
+				Identity: *identity,
+				Provider: item.Name(),
+			}, nil
+		}
+	}
+	return nil, errors.ErrInvalidUserName
+}
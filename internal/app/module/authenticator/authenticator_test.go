@@ -0,0 +1,145 @@
+package authenticator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/password"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+type mockAuthenticator struct {
+	name string
+	user *schema.User
+	err  error
+}
+
+func (a *mockAuthenticator) Name() string {
+	return a.name
+}
+
+func (a *mockAuthenticator) Authenticate(ctx context.Context, userName, password string) (*Identity, error) {
+	if a.user == nil {
+		return nil, a.err
+	}
+	return &Identity{User: a.user}, a.err
+}
+
+func TestChain(t *testing.T) {
+	ctx := context.Background()
+	user := &schema.User{RecordID: "1", UserName: "foo"}
+
+	chain := NewChain(
+		&mockAuthenticator{name: "pass"},
+		&mockAuthenticator{name: "accept", user: user},
+		&mockAuthenticator{name: "reject", err: errors.ErrInvalidPassword},
+	)
+	assert.Equal(t, []string{"pass", "accept", "reject"}, chain.Names())
+
+	result, err := chain.Authenticate(ctx, "foo", "bar")
+	assert.Nil(t, err)
+	assert.Equal(t, "accept", result.Provider)
+	assert.Equal(t, user, result.User)
+
+	chain = NewChain(
+		&mockAuthenticator{name: "reject", err: errors.ErrInvalidPassword},
+		&mockAuthenticator{name: "accept", user: user},
+	)
+	_, err = chain.Authenticate(ctx, "foo", "bar")
+	assert.Equal(t, errors.ErrInvalidPassword, err)
+
+	chain = NewChain(&mockAuthenticator{name: "pass"})
+	_, err = chain.Authenticate(ctx, "foo", "bar")
+	assert.Equal(t, errors.ErrInvalidUserName, err)
+}
+
+func TestRoot(t *testing.T) {
+	ctx := context.Background()
+	root := NewRoot(&schema.User{RecordID: "root", UserName: "root", Password: "abc-123"}, nil)
+
+	identity, err := root.Authenticate(ctx, "foo", "abc-123")
+	assert.Nil(t, err)
+	assert.Nil(t, identity)
+
+	_, err = root.Authenticate(ctx, "root", "foo")
+	assert.Equal(t, errors.ErrInvalidPassword, err)
+
+	identity, err = root.Authenticate(ctx, "root", "abc-123")
+	assert.Nil(t, err)
+	assert.Equal(t, "root", identity.User.RecordID)
+}
+
+func TestRootEncoded(t *testing.T) {
+	ctx := context.Background()
+	pm := password.New(password.NewBcrypt(4))
+	encoded, err := pm.Hash("abc-123")
+	assert.Nil(t, err)
+
+	root := NewRoot(&schema.User{RecordID: "root", UserName: "root", Password: encoded}, pm)
+
+	_, err = root.Authenticate(ctx, "root", encoded)
+	assert.Equal(t, errors.ErrInvalidPassword, err)
+
+	identity, err := root.Authenticate(ctx, "root", "abc-123")
+	assert.Nil(t, err)
+	assert.Equal(t, "root", identity.User.RecordID)
+}
+
+type mockUserModel struct {
+	model.IUser
+	user     *schema.User
+	password string
+}
+
+func (a *mockUserModel) Query(ctx context.Context, params schema.UserQueryParam, opts ...schema.UserQueryOptions) (*schema.UserQueryResult, error) {
+	result := new(schema.UserQueryResult)
+	if a.user.UserName == params.UserName {
+		result.Data = schema.Users{a.user}
+	}
+	return result, nil
+}
+
+func (a *mockUserModel) UpdatePassword(ctx context.Context, recordID, password string) error {
+	a.password = password
+	return nil
+}
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	pm := password.New(password.NewBcrypt(4), password.NewSHA1())
+	encoded, err := password.NewSHA1().Hash("abc-123")
+	assert.Nil(t, err)
+
+	m := &mockUserModel{user: &schema.User{RecordID: "1", UserName: "foo", Password: encoded, Status: 2}}
+	local := NewLocal(m, pm)
+
+	// 停用用户无论密码是否正确均返回相同的错误，且不会重新计算密码哈希
+	_, err = local.Authenticate(ctx, "foo", "abc-123")
+	assert.Equal(t, errors.ErrUserDisable, err)
+	_, err = local.Authenticate(ctx, "foo", "bar")
+	assert.Equal(t, errors.ErrUserDisable, err)
+	assert.Empty(t, m.password)
+
+	m.user.Status = 1
+	_, err = local.Authenticate(ctx, "foo", "bar")
+	assert.Equal(t, errors.ErrInvalidPassword, err)
+
+	identity, err := local.Authenticate(ctx, "foo", "abc-123")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", identity.User.RecordID)
+	assert.True(t, pm.IsEncoded(m.password))
+
+	// 客户端提交MD5摘要时保存的密码，使用明文登录后重新计算
+	m.user.Password, err = pm.Hash(util.MD5HashString("abc-123"))
+	assert.Nil(t, err)
+	m.password = ""
+	_, err = local.Authenticate(ctx, "foo", "abc-123")
+	assert.Nil(t, err)
+	ok, _, err := pm.Verify(m.password, "abc-123")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,99 @@
+package authenticator
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/ldap"
+)
+
+var _ Authenticator = (*LDAP)(nil)
+
+// LDAPAttributes LDAP属性到用户字段的映射
+type LDAPAttributes struct {
+	RealName string // 真实姓名
+	Email    string // 邮箱
+	Phone    string // 手机号
+}
+
+// Names 需要查询的属性列表
+func (a LDAPAttributes) Names() []string {
+	var names []string
+	for _, name := range []string{a.RealName, a.Email, a.Phone} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// NewLDAP 创建LDAP认证器
+func NewLDAP(client *ldap.Client, attrs LDAPAttributes) *LDAP {
+	return &LDAP{client: client, attrs: attrs}
+}
+
+// LDAP 基于LDAP服务的用户认证(先搜索用户DN，再以用户身份绑定)
+type LDAP struct {
+	client *ldap.Client
+	attrs  LDAPAttributes
+}
+
+// Name 认证器名称
+func (a *LDAP) Name() string {
+	return LDAPName
+}
+
+// Authenticate 搜索用户条目并以用户身份绑定LDAP服务进行认证
+func (a *LDAP) Authenticate(ctx context.Context, userName, password string) (*Identity, error) {
+	if password == "" {
+		return nil, errors.ErrInvalidPassword
+	}
+
+	entry, err := a.client.Authenticate(ctx, userName, password)
+	if err != nil {
+		switch err {
+		case ldap.ErrUserNotFound:
+			return nil, nil
+		case ldap.ErrInvalidCredentials:
+			return nil, errors.ErrInvalidPassword
+		case ldap.ErrMultipleEntries:
+			return nil, errors.ErrInvalidUserName
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	groups, err := a.client.Groups(ctx, entry)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &Identity{
+		User:   a.toUser(userName, entry),
+		Groups: groups,
+	}, nil
+}
+
+func (a *LDAP) toUser(userName string, entry *ldap.Entry) *schema.User {
+	user := &schema.User{
+		UserName: userName,
+		RealName: userName,
+	}
+	if a.attrs.RealName != "" {
+		if v := entry.GetAttributeValue(a.attrs.RealName); v != "" {
+			user.RealName = v
+		}
+	}
+	if a.attrs.Email != "" {
+		user.Email = entry.GetAttributeValue(a.attrs.Email)
+	}
+	if a.attrs.Phone != "" {
+		user.Phone = entry.GetAttributeValue(a.attrs.Phone)
+	}
+	return user
+}
+
+// Close 关闭LDAP连接池
+func (a *LDAP) Close() error {
+	return a.client.Close()
+}
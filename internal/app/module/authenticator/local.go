@@ -0,0 +1,88 @@
+package authenticator
+
+import (
+	"context"
+
+	"github.com/wangwei518/gin-admin/internal/app/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/password"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+var _ Authenticator = (*Local)(nil)
+
+// NewLocal 创建本地用户认证器
+func NewLocal(userModel model.IUser, pm *password.Manager) *Local {
+	return &Local{UserModel: userModel, PasswordManager: pm}
+}
+
+// Local 基于用户表(IUser)的本地认证
+type Local struct {
+	UserModel       model.IUser
+	PasswordManager *password.Manager
+}
+
+// Name 认证器名称
+func (a *Local) Name() string {
+	return LocalName
+}
+
+// Authenticate 认证本地用户
+// 用户不存在或者未设置本地密码时，交由下一个认证器处理
+func (a *Local) Authenticate(ctx context.Context, userName, password string) (*Identity, error) {
+	result, err := a.UserModel.Query(ctx, schema.UserQueryParam{
+		UserName: userName,
+	})
+	if err != nil {
+		return nil, err
+	} else if len(result.Data) == 0 {
+		return nil, nil
+	}
+
+	user := result.Data[0]
+	if user.IsService() {
+		// 服务账号只能使用访问令牌
+		return nil, errors.ErrInvalidUserName
+	} else if user.Password == "" {
+		return nil, nil
+	} else if user.Status != 1 {
+		// 先检查用户状态，避免通过停用用户的登录结果确认其密码
+		return nil, errors.ErrUserDisable
+	}
+
+	ok, rehash, err := a.PasswordManager.Verify(user.Password, password)
+	if err == nil && !ok {
+		// 兼容客户端提交MD5摘要时保存的密码，验证通过后使用明文重新计算
+		ok, _, err = a.PasswordManager.Verify(user.Password, util.MD5HashString(password))
+		rehash = ok
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	} else if !ok {
+		return nil, errors.ErrInvalidPassword
+	}
+
+	if rehash {
+		err := a.rehash(ctx, user, password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Identity{User: user}, nil
+}
+
+// 使用当前算法重新计算密码的哈希值
+func (a *Local) rehash(ctx context.Context, user *schema.User, password string) error {
+	encoded, err := a.PasswordManager.Hash(password)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = a.UserModel.UpdatePassword(ctx, user.RecordID, encoded)
+	if err != nil {
+		return err
+	}
+	user.Password = encoded
+	return nil
+}
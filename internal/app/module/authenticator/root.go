@@ -0,0 +1,46 @@
+package authenticator
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/errors"
+	"github.com/wangwei518/gin-admin/pkg/password"
+)
+
+var _ Authenticator = (*Root)(nil)
+
+// NewRoot 创建root用户认证器
+func NewRoot(root *schema.User, pm *password.Manager) *Root {
+	return &Root{root: root, pm: pm}
+}
+
+// Root 配置文件中的root用户认证(密码支持明文或者哈希值)
+type Root struct {
+	root *schema.User
+	pm   *password.Manager
+}
+
+// Name 认证器名称
+func (a *Root) Name() string {
+	return RootName
+}
+
+// Authenticate 认证root用户
+func (a *Root) Authenticate(ctx context.Context, userName, password string) (*Identity, error) {
+	if userName != a.root.UserName {
+		return nil, nil
+	} else if !a.verify(password) {
+		return nil, errors.ErrInvalidPassword
+	}
+	return &Identity{User: a.root}, nil
+}
+
+func (a *Root) verify(password string) bool {
+	if a.pm != nil && a.pm.IsEncoded(a.root.Password) {
+		ok, _, err := a.pm.Verify(a.root.Password, password)
+		return err == nil && ok
+	}
+	return subtle.ConstantTimeCompare([]byte(password), []byte(a.root.Password)) == 1
+}
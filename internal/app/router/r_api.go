@@ -3,14 +3,15 @@ package router
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/wangwei518/gin-admin/internal/app/middleware"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
 )
 
 // RegisterAPI register api group router
 func (a *Router) RegisterAPI(app *gin.Engine) {
 	g := app.Group("/api")
 
-	g.Use(middleware.UserAuthMiddleware(a.Auth,
-		middleware.AllowPathPrefixSkipper("/api/v1/pub/login"),
+	g.Use(middleware.UserAuthMiddleware(a.Auth, a.APITokenBll,
+		middleware.AllowPathPrefixSkipper("/api/v1/pub/login", "/api/v1/pub/refresh-token", "/api/v1/pub/password"),
 	))
 
 	g.Use(middleware.CasbinMiddleware(a.CasbinEnforcer,
@@ -25,20 +26,52 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 		{
 			gLogin := pub.Group("login")
 			{
+				gLogin.GET("captchaid", a.LoginAPI.GetCaptcha)
+				gLogin.GET("captcha", a.LoginAPI.ResCaptcha)
 				gLogin.POST("", a.LoginAPI.Login)
 				gLogin.POST("exit", a.LoginAPI.Logout)
+				gLogin.POST("mfa", a.LoginAPI.LoginMFA)
+				gLogin.POST("mfa/enroll", a.LoginAPI.LoginMFAEnroll)
+				gLogin.GET("oidc", a.LoginAPI.LoginOIDC)
+				gLogin.GET("oidc/callback", a.LoginAPI.LoginOIDCCallback)
 			}
 
-			gCurrent := pub.Group("current")
+			gPassword := pub.Group("password")
+			{
+				gPassword.POST("forgot", a.LoginAPI.ForgotPassword)
+				gPassword.POST("reset", a.LoginAPI.ResetPassword)
+			}
+
+			// 必须修改密码时签发的受限令牌只能访问修改密码接口
+			pub.PUT("current/password", middleware.ViewMiddleware(schema.ViewAdmin, schema.ViewMobile, schema.ViewPasswordChange), middleware.NoImpersonationMiddleware(), a.LoginAPI.UpdatePassword)
+			pub.DELETE("current/impersonation", a.LoginAPI.EndImpersonation)
+
+			gCurrent := pub.Group("current", middleware.ViewMiddleware(schema.ViewAdmin, schema.ViewMobile))
 			{
-				gCurrent.PUT("password", a.LoginAPI.UpdatePassword)
 				gCurrent.GET("user", a.LoginAPI.GetUserInfo)
 				gCurrent.GET("menutree", a.LoginAPI.QueryUserMenuTree)
+				gCurrent.GET("sessions", a.LoginAPI.QuerySessions)
+				gCurrent.GET("tokens", a.APITokenAPI.QueryCurrent)
+				gCurrent.GET("tenants", a.LoginAPI.QueryTenants)
+				gCurrent.POST("permissions/check", a.PermissionAPI.CheckCurrent)
+
+				// 模拟登录时不允许修改用户本人的安全设置
+				gSecurity := gCurrent.Group("", middleware.NoImpersonationMiddleware())
+				{
+					gSecurity.DELETE("sessions/:sid", a.LoginAPI.RevokeSession)
+					gSecurity.POST("mfa", a.LoginAPI.EnrollMFA)
+					gSecurity.PUT("mfa", a.LoginAPI.ActivateMFA)
+					gSecurity.DELETE("mfa", a.LoginAPI.DisableMFA)
+					gSecurity.POST("mfa/recovery-codes", a.LoginAPI.RegenerateRecoveryCodes)
+					gSecurity.POST("tokens", a.APITokenAPI.CreateCurrent)
+					gSecurity.DELETE("tokens/:tid", a.APITokenAPI.DeleteCurrent)
+					gSecurity.PUT("tenant", a.LoginAPI.SwitchTenant)
+				}
 			}
 			pub.POST("/refresh-token", a.LoginAPI.RefreshToken)
 		}
 
-		gDemo := v1.Group("demos")
+		gDemo := v1.Group("demos", middleware.ViewMiddleware(schema.ViewAdmin, schema.ViewMobile, schema.ViewPartner))
 		{
 			gDemo.GET("", a.DemoAPI.Query)
 			gDemo.GET(":id", a.DemoAPI.Get)
@@ -49,7 +82,7 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 			gDemo.PATCH(":id/disable", a.DemoAPI.Disable)
 		}
 
-		gMenu := v1.Group("menus")
+		gMenu := v1.Group("menus", middleware.ViewMiddleware(schema.ViewAdmin, schema.ViewPartner))
 		{
 			gMenu.GET("", a.MenuAPI.Query)
 			gMenu.GET(":id", a.MenuAPI.Get)
@@ -59,29 +92,74 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 			gMenu.PATCH(":id/enable", a.MenuAPI.Enable)
 			gMenu.PATCH(":id/disable", a.MenuAPI.Disable)
 		}
-		v1.GET("/menus.tree", a.MenuAPI.QueryTree)
+		v1.GET("/menus.tree", middleware.ViewMiddleware(schema.ViewAdmin), a.MenuAPI.QueryTree)
+
+		gOrg := v1.Group("orgs", middleware.ViewMiddleware(schema.ViewAdmin, schema.ViewPartner))
+		{
+			gOrg.GET("", a.OrgAPI.Query)
+			gOrg.GET(":id", a.OrgAPI.Get)
+			gOrg.POST("", a.OrgAPI.Create)
+			gOrg.PUT(":id", a.OrgAPI.Update)
+			gOrg.PUT(":id/move", a.OrgAPI.Move)
+			gOrg.DELETE(":id", a.OrgAPI.Delete)
+			gOrg.PATCH(":id/enable", a.OrgAPI.Enable)
+			gOrg.PATCH(":id/disable", a.OrgAPI.Disable)
+		}
+		v1.GET("/orgs.tree", middleware.ViewMiddleware(schema.ViewAdmin), a.OrgAPI.QueryTree)
+
+		gPermission := v1.Group("permissions", middleware.ViewMiddleware(schema.ViewAdmin))
+		{
+			gPermission.GET("explain", a.PermissionAPI.Explain)
+			gPermission.POST("check", a.PermissionAPI.Check)
+		}
 
-		gRole := v1.Group("roles")
+		gRole := v1.Group("roles", middleware.ViewMiddleware(schema.ViewAdmin, schema.ViewPartner))
 		{
 			gRole.GET("", a.RoleAPI.Query)
 			gRole.GET(":id", a.RoleAPI.Get)
 			gRole.POST("", a.RoleAPI.Create)
 			gRole.PUT(":id", a.RoleAPI.Update)
+			gRole.PUT(":id/dry-run", a.RoleAPI.DryRunUpdate)
 			gRole.DELETE(":id", a.RoleAPI.Delete)
 			gRole.PATCH(":id/enable", a.RoleAPI.Enable)
 			gRole.PATCH(":id/disable", a.RoleAPI.Disable)
 		}
-		v1.GET("/roles.select", a.RoleAPI.QuerySelect)
+		v1.GET("/roles.select", middleware.ViewMiddleware(schema.ViewAdmin), a.RoleAPI.QuerySelect)
 
-		gUser := v1.Group("users")
+		gTenant := v1.Group("tenants", middleware.ViewMiddleware(schema.ViewAdmin))
+		{
+			gTenant.GET("", a.TenantAPI.Query)
+			gTenant.GET(":id", a.TenantAPI.Get)
+			gTenant.POST("", a.TenantAPI.Create)
+			gTenant.PUT(":id", a.TenantAPI.Update)
+			gTenant.DELETE(":id", a.TenantAPI.Delete)
+			gTenant.PATCH(":id/enable", a.TenantAPI.Enable)
+			gTenant.PATCH(":id/disable", a.TenantAPI.Disable)
+		}
+
+		gUser := v1.Group("users", middleware.ViewMiddleware(schema.ViewAdmin, schema.ViewPartner))
 		{
 			gUser.GET("", a.UserAPI.Query)
 			gUser.GET(":id", a.UserAPI.Get)
 			gUser.POST("", a.UserAPI.Create)
 			gUser.PUT(":id", a.UserAPI.Update)
+			gUser.PUT(":id/dry-run", a.UserAPI.DryRunUpdate)
 			gUser.DELETE(":id", a.UserAPI.Delete)
 			gUser.PATCH(":id/enable", a.UserAPI.Enable)
 			gUser.PATCH(":id/disable", a.UserAPI.Disable)
+			gUser.GET(":id/sessions", a.UserAPI.QuerySessions)
+			gUser.DELETE(":id/sessions", a.UserAPI.RevokeSessions)
+			gUser.DELETE(":id/sessions/:sid", a.UserAPI.RevokeSession)
+			gUser.DELETE(":id/mfa", a.UserAPI.ResetMFA)
+			gUser.POST(":id/impersonate", middleware.ViewMiddleware(schema.ViewAdmin), middleware.NoImpersonationMiddleware(), a.UserAPI.Impersonate)
+			gUser.GET(":id/tokens", a.APITokenAPI.Query)
+			gUser.POST(":id/tokens", a.APITokenAPI.Create)
+			gUser.DELETE(":id/tokens/:tid", a.APITokenAPI.Delete)
+		}
+		gLock := v1.Group("users.locks", middleware.ViewMiddleware(schema.ViewAdmin))
+		{
+			gLock.GET("", a.UserAPI.QueryLoginLocks)
+			gLock.DELETE(":type/:name", a.UserAPI.UnlockLogin)
 		}
 	}
 	v2 := g.Group("/v2")
@@ -101,3 +179,11 @@ func (a *Router) RegisterAPI(app *gin.Engine) {
 		}
 	}
 }
+
+// RegisterWellKnown register well-known router
+func (a *Router) RegisterWellKnown(app *gin.Engine) {
+	g := app.Group("/.well-known")
+	{
+		g.GET("jwks.json", a.JWKSAPI.Get)
+	}
+}
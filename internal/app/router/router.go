@@ -3,6 +3,7 @@ package router
 import (
 	"github.com/wangwei518/gin-admin/internal/app/api"
 	"github.com/wangwei518/gin-admin/internal/app/api/mock"
+	"github.com/wangwei518/gin-admin/internal/app/bll"
 	"github.com/wangwei518/gin-admin/pkg/auth"
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
@@ -24,14 +25,25 @@ type IRouter interface {
 type Router struct {
 	Auth           auth.Auther
 	CasbinEnforcer *casbin.SyncedEnforcer
+	APITokenBll    bll.IAPIToken
+	APITokenAPI    *api.APIToken
+	APITokenMock   *mock.APIToken
 	DemoAPI        *api.Demo
 	DemoMock       *mock.Demo
+	JWKSAPI        *api.JWKS
+	JWKSMock       *mock.JWKS
 	LoginAPI       *api.Login
 	LoginMock      *mock.Login
 	MenuAPI        *api.Menu
 	MenuMock       *mock.Menu
+	OrgAPI         *api.Org
+	OrgMock        *mock.Org
+	PermissionAPI  *api.Permission
+	PermissionMock *mock.Permission
 	RoleAPI        *api.Role
 	RoleMock       *mock.Role
+	TenantAPI      *api.Tenant
+	TenantMock     *mock.Tenant
 	UserAPI        *api.User
 	UserMock       *mock.User
 }
@@ -39,6 +51,7 @@ type Router struct {
 // Register 注册路由
 func (a *Router) Register(app *gin.Engine) error {
 	a.RegisterAPI(app)
+	a.RegisterWellKnown(app)
 	return nil
 }
 
@@ -46,5 +59,6 @@ func (a *Router) Register(app *gin.Engine) error {
 func (a *Router) Prefixes() []string {
 	return []string{
 		"/api/",
+		"/.well-known/",
 	}
 }
@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"time"
+)
+
+// APITokenPrefix 访问令牌的固定前缀(用于区分JWT)
+const APITokenPrefix = "gat_"
+
+// APIToken 访问令牌对象
+type APIToken struct {
+	RecordID   string     `json:"record_id"`    // 记录ID
+	TenantID   string     `json:"tenant_id"`    // 租户ID
+	UserID     string     `json:"user_id"`      // 所属用户ID
+	Name       string     `json:"name"`         // 令牌名称
+	Prefix     string     `json:"prefix"`       // 令牌前缀(用于识别令牌)
+	TokenHash  string     `json:"-"`            // 令牌哈希值
+	ActionIDs  []string   `json:"action_ids"`   // 授权的菜单动作ID列表
+	ExpiresAt  *time.Time `json:"expires_at"`   // 过期时间(为空则永不过期)
+	LastUsedAt *time.Time `json:"last_used_at"` // 最后使用时间
+	Creator    string     `json:"creator"`      // 创建者
+	CreatedAt  time.Time  `json:"created_at"`   // 创建时间
+}
+
+// IsExpired 是否已过期
+func (a *APIToken) IsExpired() bool {
+	return a.ExpiresAt != nil && !a.ExpiresAt.After(time.Now())
+}
+
+// APITokenCreateParam 创建访问令牌参数
+type APITokenCreateParam struct {
+	Name      string   `json:"name" binding:"required"`            // 令牌名称
+	ActionIDs []string `json:"action_ids" binding:"required,gt=0"` // 授权的菜单动作ID列表(须为用户已授权的动作)
+	ExpiresIn int64    `json:"expires_in" binding:"min=0"`         // 有效期(单位秒，为0则永不过期)
+	Creator   string   `json:"-"`                                  // 创建者
+}
+
+// APITokenInfo 创建的访问令牌(令牌明文仅在创建时返回)
+type APITokenInfo struct {
+	*APIToken
+	Token string `json:"token"` // 访问令牌
+}
+
+// APITokenAuth 访问令牌认证结果
+type APITokenAuth struct {
+	TokenID   string              // 令牌ID
+	UserID    string              // 所属用户ID
+	TenantID  string              // 所属租户ID
+	Resources MenuActionResources // 令牌可访问的资源列表
+}
+
+// APITokenQueryParam 查询条件
+type APITokenQueryParam struct {
+	PaginationParam
+	UserID    string // 所属用户ID
+	TokenHash string // 令牌哈希值
+}
+
+// APITokenQueryOptions 查询可选参数项
+type APITokenQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// APITokenQueryResult 查询结果
+type APITokenQueryResult struct {
+	Data       APITokens
+	PageResult *PaginationResult
+}
+
+// APITokens 访问令牌列表
+type APITokens []*APIToken
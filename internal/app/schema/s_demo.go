@@ -5,6 +5,7 @@ import "time"
 // Demo 示例对象
 type Demo struct {
 	RecordID  string    `json:"record_id"`                             // 记录ID
+	TenantID  string    `json:"tenant_id"`                             // 租户ID
 	Code      string    `json:"code" binding:"required"`               // 编号
 	Name      string    `json:"name" binding:"required"`               // 名称
 	Memo      string    `json:"memo"`                                  // 备注
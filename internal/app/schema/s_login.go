@@ -2,27 +2,116 @@ package schema
 
 // LoginParam 登录参数
 type LoginParam struct {
-	UserName    string `json:"username" binding:"required"`     // 用户名
-	Password    string `json:"password" binding:"required"`     // 密码
+	UserName    string `json:"username" binding:"required"` // 用户名
+	Password    string `json:"password" binding:"required"` // 密码(明文)
+	CaptchaID   string `json:"captcha_id"`                  // 验证码ID(启用验证码时需要)
+	CaptchaCode string `json:"captcha_code"`                // 验证码(启用验证码时需要)
+	View        string `json:"view"`                        // 令牌视图(为空时使用默认视图)
+}
+
+// 令牌视图(令牌签发的客户端受众)
+const (
+	ViewAdmin   = "admin"   // 管理后台
+	ViewMobile  = "mobile"  // 移动端
+	ViewPartner = "partner" // 合作方接口(访问令牌固定使用该视图)
+
+	// ViewPasswordChange 修改密码(被要求修改密码或密码过期时签发的受限令牌，只能用于修改密码)
+	ViewPasswordChange = "password_change"
+)
+
+// LoginCaptcha 登录验证码
+type LoginCaptcha struct {
+	CaptchaID string `json:"captcha_id"` // 验证码ID
 }
 
 // UserLoginInfo 用户登录信息
 type UserLoginInfo struct {
-	UserID   string `json:"user_id"`   // 用户ID
-	UserName string `json:"user_name"` // 用户名
-	RealName string `json:"real_name"` // 真实姓名
-	Roles    Roles  `json:"roles"`     // 角色列表
+	UserID   string `json:"user_id"`            // 用户ID
+	UserName string `json:"user_name"`          // 用户名
+	RealName string `json:"real_name"`          // 真实姓名
+	Roles    Roles  `json:"roles"`              // 角色列表(当前租户中分配的角色)
+	TenantID string `json:"tenant_id"`          // 当前租户ID(为空时为默认租户)
+	ActorID  string `json:"actor_id,omitempty"` // 模拟登录的操作者ID(不是模拟登录时为空)
 }
 
 // UpdatePasswordParam 更新密码请求参数
 type UpdatePasswordParam struct {
-	OldPassword string `json:"old_password" binding:"required"` // 旧密码(md5加密)
-	NewPassword string `json:"new_password" binding:"required"` // 新密码(md5加密)
+	OldPassword string `json:"old_password" binding:"required"` // 旧密码(明文)
+	NewPassword string `json:"new_password" binding:"required"` // 新密码(明文)
 }
 
 // LoginTokenInfo 登录令牌信息
 type LoginTokenInfo struct {
-	AccessToken string `json:"access_token"` // 访问令牌
-	TokenType   string `json:"token_type"`   // 令牌类型
-	ExpiresAt   int64  `json:"expires_at"`   // 令牌到期时间戳
+	AccessToken            string   `json:"access_token"`                       // 访问令牌
+	TokenType              string   `json:"token_type"`                         // 令牌类型
+	ExpiresAt              int64    `json:"expires_at"`                         // 令牌到期时间戳
+	RefreshToken           string   `json:"refresh_token"`                      // 刷新令牌
+	RefreshExpiresAt       int64    `json:"refresh_expires_at"`                 // 刷新令牌到期时间戳
+	RecoveryCodes          []string `json:"recovery_codes,omitempty"`           // 登录时完成多因素认证绑定所生成的恢复码
+	PasswordChangeRequired bool     `json:"password_change_required,omitempty"` // 是否需要先修改密码(此时令牌只能用于修改密码)
+}
+
+// RefreshTokenParam 刷新令牌请求参数
+type RefreshTokenParam struct {
+	RefreshToken string `json:"refresh_token" binding:"required"` // 刷新令牌
+}
+
+// LoginMFAChallenge 多因素认证挑战(密码验证通过后返回)
+type LoginMFAChallenge struct {
+	MFARequired    bool   `json:"mfa_required"`    // 是否需要多因素认证
+	MFAToken       string `json:"mfa_token"`       // 挑战令牌
+	ExpiresAt      int64  `json:"expires_at"`      // 挑战令牌到期时间戳
+	EnrollRequired bool   `json:"enroll_required"` // 是否需要先绑定认证器(角色要求多因素认证但用户未启用)
+}
+
+// LoginMFAParam 多因素认证登录参数
+type LoginMFAParam struct {
+	MFAToken string `json:"mfa_token" binding:"required"` // 挑战令牌
+	Code     string `json:"code" binding:"required"`      // 动态验证码或恢复码
+	View     string `json:"view"`                         // 令牌视图(为空时使用默认视图)
+}
+
+// LoginMFAEnrollParam 登录时绑定认证器参数
+type LoginMFAEnrollParam struct {
+	MFAToken string `json:"mfa_token" binding:"required"` // 挑战令牌
+}
+
+// MFACodeParam 动态验证码参数
+type MFACodeParam struct {
+	Code string `json:"code" binding:"required"` // 动态验证码或恢复码
+}
+
+// MFAEnrollInfo 认证器绑定信息
+type MFAEnrollInfo struct {
+	Secret string `json:"secret"` // 密钥(base32编码)
+	URI    string `json:"uri"`    // 配置URI(otpauth://格式，用于生成二维码)
+}
+
+// MFARecoveryCodes 多因素认证恢复码(仅在生成时返回一次)
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"` // 一次性恢复码列表
+}
+
+// LoginLock 登录锁定信息
+type LoginLock struct {
+	Type      string `json:"type"`       // 锁定对象的类型(user:用户名 ip:客户端IP)
+	Name      string `json:"name"`       // 用户名或IP
+	ExpiresAt int64  `json:"expires_at"` // 解锁时间戳
+}
+
+// LoginLocks 登录锁定列表
+type LoginLocks []*LoginLock
+
+// LoginOIDCAuthorize OIDC授权请求信息
+type LoginOIDCAuthorize struct {
+	AuthURL string `json:"auth_url"` // 身份提供方的授权地址
+	State   string `json:"state"`    // 授权请求的state
+}
+
+// LoginOIDCCallbackParam OIDC授权回调参数
+type LoginOIDCCallbackParam struct {
+	Code             string `form:"code"`              // 授权码
+	State            string `form:"state"`             // 授权请求的state
+	Error            string `form:"error"`             // 身份提供方返回的错误码
+	ErrorDescription string `form:"error_description"` // 身份提供方返回的错误描述
 }
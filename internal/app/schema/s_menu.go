@@ -233,10 +233,29 @@ func (a MenuActions) ToMenuIDMap() map[string]MenuActions {
 
 // MenuActionResource 菜单动作关联资源对象
 type MenuActionResource struct {
-	RecordID string `json:"record_id"`                 // 记录ID
-	ActionID string `json:"action_id"`                 // 菜单动作ID
-	Method   string `json:"method" binding:"required"` // 资源请求方式(支持正则)
-	Path     string `json:"path" binding:"required"`   // 资源请求路径（支持/:id匹配）
+	RecordID   string `json:"record_id"`                                   // 记录ID
+	ActionID   string `json:"action_id"`                                   // 菜单动作ID
+	Method     string `json:"method" binding:"required"`                   // 资源请求方式(支持正则)
+	Path       string `json:"path" binding:"required"`                     // 资源请求路径（支持/:id匹配）
+	View       string `json:"view"`                                        // 资源允许的令牌视图(为空表示不限制)
+	Effect     string `json:"effect" binding:"omitempty,oneof=allow deny"` // 授权效果(allow:允许 deny:拒绝，为空表示允许，拒绝优先)
+	IPRanges   string `json:"ip_ranges"`                                   // 客户端IP范围条件(多个CIDR以逗号分隔，为空表示不限制)
+	TimeWindow string `json:"time_window"`                                 // 时间窗口条件(如09:00-18:00，为空表示不限制)
+	Attributes string `json:"attributes"`                                  // 请求属性条件(如header.X-Env=^prod$，多个以分号分隔，为空表示不限制；属性由客户端提供，不能作为安全边界，拒绝规则不支持)
+}
+
+// 资源的授权效果
+const (
+	ResourceEffectAllow = "allow"
+	ResourceEffectDeny  = "deny"
+)
+
+// GetEffect 获取授权效果(为空时为允许)
+func (a *MenuActionResource) GetEffect() string {
+	if a.Effect == "" {
+		return ResourceEffectAllow
+	}
+	return a.Effect
 }
 
 // MenuActionResourceQueryParam 查询条件
@@ -0,0 +1,126 @@
+package schema
+
+import "time"
+
+// Org 部门对象
+type Org struct {
+	RecordID   string    `json:"record_id"`                             // 记录ID
+	TenantID   string    `json:"tenant_id"`                             // 租户ID
+	Name       string    `json:"name" binding:"required"`               // 部门名称
+	Sequence   int       `json:"sequence"`                              // 排序值
+	ParentID   string    `json:"parent_id"`                             // 父级ID
+	ParentPath string    `json:"parent_path"`                           // 父级路径
+	Leader     string    `json:"leader"`                                // 负责人
+	Phone      string    `json:"phone"`                                 // 联系电话
+	Status     int       `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:禁用)
+	Memo       string    `json:"memo"`                                  // 备注
+	Creator    string    `json:"creator"`                               // 创建者
+	CreatedAt  time.Time `json:"created_at"`                            // 创建时间
+	UpdatedAt  time.Time `json:"updated_at"`                            // 更新时间
+}
+
+// OrgQueryParam 查询条件
+type OrgQueryParam struct {
+	PaginationParam
+	RecordIDs        []string `form:"-"`          // 记录ID列表
+	Name             string   `form:"-"`          // 部门名称
+	PrefixParentPath string   `form:"-"`          // 父级路径(前缀模糊查询)
+	QueryValue       string   `form:"queryValue"` // 模糊查询
+	ParentID         *string  `form:"parentID"`   // 父级内码
+	Status           int      `form:"status"`     // 状态(1:启用 2:禁用)
+}
+
+// OrgQueryOptions 查询可选参数项
+type OrgQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// OrgQueryResult 查询结果
+type OrgQueryResult struct {
+	Data       Orgs
+	PageResult *PaginationResult
+}
+
+// OrgMoveParam 移动部门的参数
+type OrgMoveParam struct {
+	ParentID string `json:"parent_id"` // 新的父级ID(为空时移动到顶级)
+}
+
+// Orgs 部门列表
+type Orgs []*Org
+
+// ToMap 转换为键值映射
+func (a Orgs) ToMap() map[string]*Org {
+	m := make(map[string]*Org)
+	for _, item := range a {
+		m[item.RecordID] = item
+	}
+	return m
+}
+
+// ToRecordIDs 转换为记录ID列表
+func (a Orgs) ToRecordIDs() []string {
+	idList := make([]string, len(a))
+	for i, item := range a {
+		idList[i] = item.RecordID
+	}
+	return idList
+}
+
+// ToTree 转换为部门树
+func (a Orgs) ToTree() OrgTrees {
+	list := make(OrgTrees, len(a))
+	for i, item := range a {
+		list[i] = &OrgTree{
+			RecordID:   item.RecordID,
+			Name:       item.Name,
+			ParentID:   item.ParentID,
+			ParentPath: item.ParentPath,
+			Sequence:   item.Sequence,
+			Leader:     item.Leader,
+			Status:     item.Status,
+		}
+	}
+	return list.ToTree()
+}
+
+// ----------------------------------------OrgTree--------------------------------------
+
+// OrgTree 部门树
+type OrgTree struct {
+	RecordID   string    `json:"record_id"`          // 记录ID
+	Name       string    `json:"name"`               // 部门名称
+	ParentID   string    `json:"parent_id"`          // 父级ID
+	ParentPath string    `json:"parent_path"`        // 父级路径
+	Sequence   int       `json:"sequence"`           // 排序值
+	Leader     string    `json:"leader"`             // 负责人
+	Status     int       `json:"status"`             // 状态(1:启用 2:禁用)
+	Children   *OrgTrees `json:"children,omitempty"` // 子级树
+}
+
+// OrgTrees 部门树列表
+type OrgTrees []*OrgTree
+
+// ToTree 转换为树形结构(父级不在列表中的节点作为顶级节点)
+func (a OrgTrees) ToTree() OrgTrees {
+	mi := make(map[string]*OrgTree)
+	for _, item := range a {
+		mi[item.RecordID] = item
+	}
+
+	var list OrgTrees
+	for _, item := range a {
+		pitem, ok := mi[item.ParentID]
+		if item.ParentID == "" || !ok {
+			list = append(list, item)
+			continue
+		}
+		if pitem.Children == nil {
+			children := OrgTrees{item}
+			pitem.Children = &children
+			continue
+		}
+		*pitem.Children = append(*pitem.Children, item)
+	}
+	return list
+}
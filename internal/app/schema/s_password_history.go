@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"time"
+)
+
+// PasswordHistory 用户历史密码对象
+type PasswordHistory struct {
+	RecordID  string    `json:"record_id"`  // 记录ID
+	UserID    string    `json:"user_id"`    // 用户ID
+	Password  string    `json:"-"`          // 密码(哈希值)
+	CreatedAt time.Time `json:"created_at"` // 创建时间
+}
+
+// PasswordHistoryQueryParam 查询条件
+type PasswordHistoryQueryParam struct {
+	PaginationParam
+	UserID string // 用户ID
+}
+
+// PasswordHistoryQueryOptions 查询可选参数项
+type PasswordHistoryQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// PasswordHistoryQueryResult 查询结果
+type PasswordHistoryQueryResult struct {
+	Data       PasswordHistories
+	PageResult *PaginationResult
+}
+
+// PasswordHistories 用户历史密码列表
+type PasswordHistories []*PasswordHistory
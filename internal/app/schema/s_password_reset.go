@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+)
+
+// PasswordReset 密码重置令牌对象
+type PasswordReset struct {
+	RecordID  string    `json:"record_id"`  // 记录ID
+	UserID    string    `json:"user_id"`    // 用户ID
+	TokenHash string    `json:"-"`          // 令牌哈希值
+	ExpiresAt time.Time `json:"expires_at"` // 过期时间
+	CreatedAt time.Time `json:"created_at"` // 创建时间
+}
+
+// IsExpired 是否已过期
+func (a *PasswordReset) IsExpired() bool {
+	return !a.ExpiresAt.After(time.Now())
+}
+
+// ForgotPasswordParam 找回密码请求参数
+type ForgotPasswordParam struct {
+	UserName string `json:"user_name" binding:"required"` // 用户名
+}
+
+// ResetPasswordParam 重置密码请求参数
+type ResetPasswordParam struct {
+	Token       string `json:"token" binding:"required"`        // 重置令牌(邮件中的重置链接携带)
+	NewPassword string `json:"new_password" binding:"required"` // 新密码(明文)
+}
+
+// PasswordResetQueryParam 查询条件
+type PasswordResetQueryParam struct {
+	PaginationParam
+	UserID    string // 用户ID
+	TokenHash string // 令牌哈希值
+}
+
+// PasswordResetQueryOptions 查询可选参数项
+type PasswordResetQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// PasswordResetQueryResult 查询结果
+type PasswordResetQueryResult struct {
+	Data       PasswordResets
+	PageResult *PaginationResult
+}
+
+// PasswordResets 密码重置令牌列表
+type PasswordResets []*PasswordReset
@@ -0,0 +1,150 @@
+package schema
+
+import "time"
+
+// PermissionExplainParam 权限解释参数
+type PermissionExplainParam struct {
+	UserID     string            `form:"userID" binding:"required"`              // 用户ID
+	Method     string            `form:"method" binding:"required"`              // 请求方法
+	Path       string            `form:"path" binding:"required"`                // 请求路径
+	View       string            `form:"view"`                                   // 令牌视图(为空时为admin)
+	IP         string            `form:"ip"`                                     // 客户端IP(用于判定访问条件)
+	Time       time.Time         `form:"time" time_format:"2006-01-02 15:04:05"` // 请求时间(用于判定访问条件，为空时为当前时间)
+	Attributes map[string]string `form:"-"`                                      // 请求属性(用于判定访问条件，如header.X-Env)
+}
+
+// PermissionExplain 权限解释结果
+type PermissionExplain struct {
+	UserID     string            `json:"user_id"`              // 用户ID
+	Method     string            `json:"method"`               // 请求方法
+	Path       string            `json:"path"`                 // 请求路径
+	View       string            `json:"view"`                 // 令牌视图
+	IP         string            `json:"ip"`                   // 客户端IP
+	Time       time.Time         `json:"time"`                 // 请求时间
+	Allowed    bool              `json:"allowed"`              // 是否允许访问
+	Reason     string            `json:"reason"`               // 判定说明
+	Matches    PermissionMatches `json:"matches"`              // 允许访问的授权链(角色、菜单动作及资源)
+	Candidates PermissionMatches `json:"candidates,omitempty"` // 拒绝访问时最接近的候选动作
+}
+
+// PermissionMatch 权限匹配项
+type PermissionMatch struct {
+	UserRoleID   string `json:"user_role_id,omitempty"`   // 用户直接拥有的角色ID
+	UserRoleName string `json:"user_role_name,omitempty"` // 用户直接拥有的角色名称
+	RoleID       string `json:"role_id,omitempty"`        // 授权动作的角色ID(继承时为上级角色)
+	RoleName     string `json:"role_name,omitempty"`      // 授权动作的角色名称
+	MenuID       string `json:"menu_id"`                  // 菜单ID
+	MenuName     string `json:"menu_name"`                // 菜单名称
+	ActionID     string `json:"action_id"`                // 动作ID
+	ActionCode   string `json:"action_code"`              // 动作编号
+	ActionName   string `json:"action_name"`              // 动作名称
+	ResourceID   string `json:"resource_id"`              // 资源ID
+	Method       string `json:"method"`                   // 资源请求方式
+	Path         string `json:"path"`                     // 资源请求路径
+	View         string `json:"view"`                     // 资源允许的令牌视图(*表示不限制)
+	Effect       string `json:"effect"`                   // 授权效果(allow:允许 deny:拒绝)
+	IPRanges     string `json:"ip_ranges,omitempty"`      // 客户端IP范围条件
+	TimeWindow   string `json:"time_window,omitempty"`    // 时间窗口条件
+	Attributes   string `json:"attributes,omitempty"`     // 请求属性条件
+	Hint         string `json:"hint,omitempty"`           // 候选动作与请求的差异说明
+}
+
+// PermissionMatches 权限匹配项列表
+type PermissionMatches []*PermissionMatch
+
+// PermissionCheckParam 批量权限检查参数
+type PermissionCheckParam struct {
+	UserID     string               `json:"user_id"`                       // 用户ID(为空时为当前用户)
+	View       string               `json:"view"`                          // 令牌视图(为空时为admin)
+	IP         string               `json:"ip"`                            // 客户端IP(用于判定访问条件)
+	Attributes map[string]string    `json:"attributes"`                    // 请求属性(用于判定访问条件，如header.X-Env)
+	Items      PermissionCheckItems `json:"items" binding:"required,gt=0"` // 检查项列表
+}
+
+// PermissionCheckItem 权限检查项
+type PermissionCheckItem struct {
+	Method  string `json:"method" binding:"required"` // 请求方法
+	Path    string `json:"path" binding:"required"`   // 请求路径
+	Allowed bool   `json:"allowed"`                   // 是否允许访问(检查结果)
+}
+
+// PermissionCheckItems 权限检查项列表
+type PermissionCheckItems []*PermissionCheckItem
+
+// PermissionDiff 权限变更的模拟结果
+type PermissionDiff struct {
+	Users   PermissionUserDiffs   `json:"users"`   // 按用户分组的变更
+	Actions PermissionActionDiffs `json:"actions"` // 按菜单动作分组的变更
+}
+
+// PermissionUserDiff 用户的权限变更
+type PermissionUserDiff struct {
+	UserID          string              `json:"user_id"`          // 用户ID
+	UserName        string              `json:"user_name"`        // 用户名
+	RealName        string              `json:"real_name"`        // 真实姓名
+	GainedMenus     PermissionMenus     `json:"gained_menus"`     // 新增的菜单
+	LostMenus       PermissionMenus     `json:"lost_menus"`       // 失去的菜单
+	GainedActions   PermissionActions   `json:"gained_actions"`   // 新增的菜单动作
+	LostActions     PermissionActions   `json:"lost_actions"`     // 失去的菜单动作
+	GainedResources PermissionResources `json:"gained_resources"` // 新增的接口
+	LostResources   PermissionResources `json:"lost_resources"`   // 失去的接口
+}
+
+// PermissionUserDiffs 用户的权限变更列表
+type PermissionUserDiffs []*PermissionUserDiff
+
+// PermissionActionDiff 菜单动作的权限变更
+type PermissionActionDiff struct {
+	PermissionAction
+	GainedUsers PermissionUsers `json:"gained_users"` // 新增该动作的用户
+	LostUsers   PermissionUsers `json:"lost_users"`   // 失去该动作的用户
+}
+
+// PermissionActionDiffs 菜单动作的权限变更列表
+type PermissionActionDiffs []*PermissionActionDiff
+
+// PermissionUser 用户项
+type PermissionUser struct {
+	UserID   string `json:"user_id"`   // 用户ID
+	UserName string `json:"user_name"` // 用户名
+	RealName string `json:"real_name"` // 真实姓名
+}
+
+// PermissionUsers 用户项列表
+type PermissionUsers []*PermissionUser
+
+// PermissionMenu 菜单项
+type PermissionMenu struct {
+	MenuID   string `json:"menu_id"`   // 菜单ID
+	MenuName string `json:"menu_name"` // 菜单名称
+}
+
+// PermissionMenus 菜单项列表
+type PermissionMenus []*PermissionMenu
+
+// PermissionAction 菜单动作项
+type PermissionAction struct {
+	MenuID     string              `json:"menu_id"`     // 菜单ID
+	MenuName   string              `json:"menu_name"`   // 菜单名称
+	ActionID   string              `json:"action_id"`   // 动作ID
+	ActionCode string              `json:"action_code"` // 动作编号
+	ActionName string              `json:"action_name"` // 动作名称
+	Resources  PermissionResources `json:"resources"`   // 动作的资源(接口)
+}
+
+// PermissionActions 菜单动作项列表
+type PermissionActions []*PermissionAction
+
+// PermissionResource 资源(接口)项
+type PermissionResource struct {
+	Method     string `json:"method"`                // 资源请求方式
+	Path       string `json:"path"`                  // 资源请求路径
+	View       string `json:"view"`                  // 资源允许的令牌视图(*表示不限制)
+	Effect     string `json:"effect"`                // 授权效果(allow:允许 deny:拒绝)
+	IPRanges   string `json:"ip_ranges,omitempty"`   // 客户端IP范围条件
+	TimeWindow string `json:"time_window,omitempty"` // 时间窗口条件
+	Attributes string `json:"attributes,omitempty"`  // 请求属性条件
+}
+
+// PermissionResources 资源(接口)项列表
+type PermissionResources []*PermissionResource
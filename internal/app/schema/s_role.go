@@ -4,15 +4,34 @@ import "time"
 
 // Role 角色对象
 type Role struct {
-	RecordID  string    `json:"record_id"`                             // 记录ID
-	Name      string    `json:"name" binding:"required"`               // 角色名称
-	Sequence  int       `json:"sequence"`                              // 排序值
-	Memo      string    `json:"memo"`                                  // 备注
-	Status    int       `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:禁用)
-	Creator   string    `json:"creator"`                               // 创建者
-	CreatedAt time.Time `json:"created_at"`                            // 创建时间
-	UpdatedAt time.Time `json:"updated_at"`                            // 更新时间
-	RoleMenus RoleMenus `json:"role_menus" binding:"required,gt=0"`    // 角色菜单列表
+	RecordID           string    `json:"record_id"`                                      // 记录ID
+	TenantID           string    `json:"tenant_id"`                                      // 租户ID
+	Name               string    `json:"name" binding:"required"`                        // 角色名称
+	Sequence           int       `json:"sequence"`                                       // 排序值
+	ParentID           string    `json:"parent_id"`                                      // 上级角色ID(继承上级角色的全部权限)
+	Memo               string    `json:"memo"`                                           // 备注
+	Status             int       `json:"status" binding:"required,max=2,min=1"`          // 状态(1:启用 2:禁用)
+	RequireMFA         int       `json:"require_mfa" binding:"max=2,min=0"`              // 是否要求多因素认证(1:要求 2:不要求)
+	DataScope          int       `json:"data_scope" binding:"max=4,min=0"`               // 数据范围(1:全部 2:本人创建 3:本部门 4:自定义部门，未设置时为全部)
+	DataOrgIDs         []string  `json:"data_org_ids"`                                   // 自定义数据范围的部门ID列表
+	Creator            string    `json:"creator"`                                        // 创建者
+	CreatedAt          time.Time `json:"created_at"`                                     // 创建时间
+	UpdatedAt          time.Time `json:"updated_at"`                                     // 更新时间
+	RoleMenus          RoleMenus `json:"role_menus" binding:"required_without=ParentID"` // 直接授权的角色菜单列表(未指定上级角色时必填)
+	EffectiveRoleMenus RoleMenus `json:"effective_role_menus,omitempty"`                 // 生效的角色菜单列表(包含继承的上级角色菜单，只读)
+}
+
+// 定义角色的数据范围
+const (
+	DataScopeAll    = 1 // 全部数据
+	DataScopeOwn    = 2 // 本人创建的数据
+	DataScopeOrg    = 3 // 本部门的数据
+	DataScopeCustom = 4 // 自定义部门的数据
+)
+
+// DataScope 当前用户的数据范围(由用户拥有的角色合并得出，为空时表示不限制)
+type DataScope struct {
+	OrgIDs []string // 可访问的部门ID列表(始终可访问本人创建的数据)
 }
 
 // RoleQueryParam 查询条件
@@ -22,6 +41,7 @@ type RoleQueryParam struct {
 	Name       string   `form:"-"`          // 角色名称
 	QueryValue string   `form:"queryValue"` // 模糊查询
 	UserID     string   `form:"-"`          // 用户ID
+	ParentID   *string  `form:"-"`          // 上级角色ID
 	Status     int      `form:"status"`     // 状态(1:启用 2:禁用)
 }
 
@@ -57,11 +77,37 @@ func (a Roles) ToMap() map[string]*Role {
 	return m
 }
 
+// ExpandInheritIDs 获取角色及其继承的全部上级角色ID列表
+// 只在当前列表中查找上级角色(例如只传入启用的角色，停用的角色及其上级不参与继承)
+func (a Roles) ExpandInheritIDs(roleIDs []string) []string {
+	mRoles := a.ToMap()
+	m := make(map[string]struct{})
+
+	var idList []string
+	for _, roleID := range roleIDs {
+		for id := roleID; id != ""; {
+			if _, ok := m[id]; ok {
+				break
+			}
+			m[id] = struct{}{}
+			idList = append(idList, id)
+
+			item, ok := mRoles[id]
+			if !ok {
+				break
+			}
+			id = item.ParentID
+		}
+	}
+	return idList
+}
+
 // ----------------------------------------RoleMenu--------------------------------------
 
 // RoleMenu 角色菜单对象
 type RoleMenu struct {
 	RecordID string `json:"record_id"`                    // 记录ID
+	TenantID string `json:"tenant_id"`                    // 租户ID
 	RoleID   string `json:"role_id" binding:"required"`   // 角色ID
 	MenuID   string `json:"menu_id" binding:"required"`   // 菜单ID
 	ActionID string `json:"action_id" binding:"required"` // 动作ID
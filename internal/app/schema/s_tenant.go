@@ -0,0 +1,52 @@
+package schema
+
+import "time"
+
+// Tenant 租户对象
+type Tenant struct {
+	RecordID  string    `json:"record_id"`                             // 记录ID
+	Code      string    `json:"code" binding:"required"`               // 编号
+	Name      string    `json:"name" binding:"required"`               // 名称
+	Memo      string    `json:"memo"`                                  // 备注
+	Status    int       `json:"status" binding:"required,max=2,min=1"` // 状态(1:启用 2:停用)
+	Creator   string    `json:"creator"`                               // 创建者
+	CreatedAt time.Time `json:"created_at"`                            // 创建时间
+	UpdatedAt time.Time `json:"updated_at"`                            // 更新时间
+}
+
+// TenantQueryParam 查询条件
+type TenantQueryParam struct {
+	PaginationParam
+	RecordIDs  []string `form:"-"`          // 记录ID列表
+	Code       string   `form:"-"`          // 编号
+	Status     int      `form:"status"`     // 状态(1:启用 2:停用)
+	QueryValue string   `form:"queryValue"` // 查询值
+}
+
+// TenantQueryOptions 租户对象查询可选参数项
+type TenantQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// TenantQueryResult 租户对象查询结果
+type TenantQueryResult struct {
+	Data       Tenants
+	PageResult *PaginationResult
+}
+
+// Tenants 租户对象列表
+type Tenants []*Tenant
+
+// ToMap 转换为键值存储
+func (a Tenants) ToMap() map[string]*Tenant {
+	m := make(map[string]*Tenant)
+	for _, item := range a {
+		m[item.RecordID] = item
+	}
+	return m
+}
+
+// TenantSwitchParam 切换租户参数
+type TenantSwitchParam struct {
+	TenantID string `json:"tenant_id"` // 租户ID(为空表示默认租户)
+}
@@ -8,16 +8,26 @@ import (
 
 // User 用户对象
 type User struct {
-	RecordID  string    `json:"record_id"`                             // 记录ID
-	UserName  string    `json:"user_name" binding:"required"`          // 用户名
-	RealName  string    `json:"real_name" binding:"required"`          // 真实姓名
-	Password  string    `json:"password"`                              // 密码
-	Phone     string    `json:"phone"`                                 // 手机号
-	Email     string    `json:"email"`                                 // 邮箱
-	Status    int       `json:"status" binding:"required,max=2,min=1"` // 用户状态(1:启用 2:停用)
-	Creator   string    `json:"creator"`                               // 创建者
-	CreatedAt time.Time `json:"created_at"`                            // 创建时间
-	UserRoles UserRoles `json:"user_roles" binding:"required,gt=0"`    // 角色授权
+	RecordID           string     `json:"record_id"`                             // 记录ID
+	UserName           string     `json:"user_name" binding:"required"`          // 用户名
+	RealName           string     `json:"real_name" binding:"required"`          // 真实姓名
+	Password           string     `json:"password"`                              // 密码(明文)
+	Phone              string     `json:"phone"`                                 // 手机号
+	Email              string     `json:"email"`                                 // 邮箱
+	Status             int        `json:"status" binding:"required,max=2,min=1"` // 用户状态(1:启用 2:停用)
+	Type               int        `json:"type" binding:"max=2"`                  // 用户类型(1:普通用户 2:服务账号)
+	OrgID              string     `json:"org_id"`                                // 所属部门ID
+	Provider           string     `json:"provider"`                              // 外部认证来源(ldap/oidc，为空表示本地用户)
+	Subject            string     `json:"-"`                                     // 外部认证的用户标识(如OIDC的sub)
+	MFAEnabled         int        `json:"mfa_enabled"`                           // 多因素认证状态(1:启用 2:未启用)
+	MFASecret          string     `json:"-"`                                     // 多因素认证密钥(未启用时为待激活的密钥)
+	MFARecoveryCodes   string     `json:"-"`                                     // 多因素认证恢复码(哈希值，逗号分隔)
+	MustChangePassword int        `json:"must_change_password" binding:"max=2"`  // 下次登录是否必须修改密码(1:是 2:否)
+	PasswordChangedAt  *time.Time `json:"password_changed_at"`                   // 密码修改时间
+	Creator            string     `json:"creator"`                               // 创建者
+	CreatedAt          time.Time  `json:"created_at"`                            // 创建时间
+	UserRoles          UserRoles  `json:"user_roles" binding:"required,gt=0"`    // 角色授权
+	UserOrgs           UserOrgs   `json:"user_orgs"`                             // 兼任部门
 }
 
 func (a *User) String() string {
@@ -27,16 +37,48 @@ func (a *User) String() string {
 // CleanSecure 清理安全数据
 func (a *User) CleanSecure() *User {
 	a.Password = ""
+	a.MFASecret = ""
+	a.MFARecoveryCodes = ""
 	return a
 }
 
+// 用户类型
+const (
+	UserTypeNormal  = 1 // 普通用户
+	UserTypeService = 2 // 服务账号(不能登录，只能使用访问令牌)
+)
+
+// IsService 是否是服务账号
+func (a *User) IsService() bool {
+	return a.Type == UserTypeService
+}
+
+// UserMFA 用户多因素认证信息
+type UserMFA struct {
+	Enabled       int    // 状态(1:启用 2:未启用)
+	Secret        string // 密钥
+	RecoveryCodes string // 恢复码(哈希值，逗号分隔)
+}
+
+// UserPassword 用户密码信息
+type UserPassword struct {
+	Password   string    // 密码(哈希值)
+	ChangedAt  time.Time // 修改时间
+	MustChange int       // 下次登录是否必须修改密码(1:是 2:否)
+}
+
 // UserQueryParam 查询条件
 type UserQueryParam struct {
 	PaginationParam
+	RecordIDs  []string `form:"-"`          // 记录ID列表
 	UserName   string   `form:"userName"`   // 用户名
 	QueryValue string   `form:"queryValue"` // 模糊查询
 	Status     int      `form:"status"`     // 用户状态(1:启用 2:停用)
+	Type       int      `form:"type"`       // 用户类型(1:普通用户 2:服务账号)
+	OrgID      string   `form:"orgID"`      // 部门ID(所属部门或兼任部门)
 	RoleIDs    []string `form:"-"`          // 角色ID列表
+	Provider   string   `form:"-"`          // 外部认证来源
+	Subject    string   `form:"-"`          // 外部认证的用户标识
 }
 
 // UserQueryOptions 查询可选参数项
@@ -92,6 +134,7 @@ func (a Users) ToUserShows(mUserRoles map[string]UserRoles, mRoles map[string]*R
 // UserRole 用户角色
 type UserRole struct {
 	RecordID string `json:"record_id"` // 记录ID
+	TenantID string `json:"tenant_id"` // 租户ID
 	UserID   string `json:"user_id"`   // 用户ID
 	RoleID   string `json:"role_id"`   // 角色ID
 }
@@ -144,18 +187,72 @@ func (a UserRoles) ToUserIDMap() map[string]UserRoles {
 	return m
 }
 
+// ----------------------------------------UserOrg--------------------------------------
+
+// UserOrg 用户兼任部门
+type UserOrg struct {
+	RecordID string `json:"record_id"` // 记录ID
+	TenantID string `json:"tenant_id"` // 租户ID
+	UserID   string `json:"user_id"`   // 用户ID
+	OrgID    string `json:"org_id"`    // 部门ID
+}
+
+// UserOrgQueryParam 查询条件
+type UserOrgQueryParam struct {
+	PaginationParam
+	UserID  string   // 用户ID
+	UserIDs []string // 用户ID列表
+	OrgIDs  []string // 部门ID列表
+}
+
+// UserOrgQueryOptions 查询可选参数项
+type UserOrgQueryOptions struct {
+	OrderFields []*OrderField // 排序字段
+}
+
+// UserOrgQueryResult 查询结果
+type UserOrgQueryResult struct {
+	Data       UserOrgs
+	PageResult *PaginationResult
+}
+
+// UserOrgs 用户兼任部门列表
+type UserOrgs []*UserOrg
+
+// ToMap 转换为map
+func (a UserOrgs) ToMap() map[string]*UserOrg {
+	m := make(map[string]*UserOrg)
+	for _, item := range a {
+		m[item.OrgID] = item
+	}
+	return m
+}
+
+// ToOrgIDs 转换为部门ID列表
+func (a UserOrgs) ToOrgIDs() []string {
+	list := make([]string, len(a))
+	for i, item := range a {
+		list[i] = item.OrgID
+	}
+	return list
+}
+
 // ----------------------------------------UserShow--------------------------------------
 
 // UserShow 用户显示项
 type UserShow struct {
-	RecordID  string    `json:"record_id"`  // 记录ID
-	UserName  string    `json:"user_name"`  // 用户名
-	RealName  string    `json:"real_name"`  // 真实姓名
-	Phone     string    `json:"phone"`      // 手机号
-	Email     string    `json:"email"`      // 邮箱
-	Status    int       `json:"status"`     // 用户状态(1:启用 2:停用)
-	CreatedAt time.Time `json:"created_at"` // 创建时间
-	Roles     []*Role   `json:"roles"`      // 授权角色列表
+	RecordID   string    `json:"record_id"`   // 记录ID
+	UserName   string    `json:"user_name"`   // 用户名
+	RealName   string    `json:"real_name"`   // 真实姓名
+	Phone      string    `json:"phone"`       // 手机号
+	Email      string    `json:"email"`       // 邮箱
+	Status     int       `json:"status"`      // 用户状态(1:启用 2:停用)
+	Type       int       `json:"type"`        // 用户类型(1:普通用户 2:服务账号)
+	OrgID      string    `json:"org_id"`      // 所属部门ID
+	Provider   string    `json:"provider"`    // 外部认证来源(ldap/oidc，为空表示本地用户)
+	MFAEnabled int       `json:"mfa_enabled"` // 多因素认证状态(1:启用 2:未启用)
+	CreatedAt  time.Time `json:"created_at"`  // 创建时间
+	Roles      []*Role   `json:"roles"`       // 授权角色列表
 }
 
 // UserShows 用户显示项列表
@@ -166,3 +263,25 @@ type UserShowQueryResult struct {
 	Data       UserShows
 	PageResult *PaginationResult
 }
+
+// ----------------------------------------UserSession--------------------------------------
+
+// UserSession 用户会话
+type UserSession struct {
+	ID        string `json:"id"`                 // 会话ID
+	ActorID   string `json:"actor_id,omitempty"` // 模拟登录的操作者ID(不是模拟登录会话时为空)
+	IP        string `json:"ip"`                 // 登录IP
+	UserAgent string `json:"user_agent"`         // 用户代理
+	IssuedAt  int64  `json:"issued_at"`          // 登录时间戳
+	ExpiresAt int64  `json:"expires_at"`         // 会话到期时间戳
+}
+
+// ImpersonateParam 模拟登录请求参数
+type ImpersonateParam struct {
+	View      string `json:"view"`                       // 令牌视图(为空则使用默认视图)
+	ExpiresIn int    `json:"expires_in" binding:"min=0"` // 有效期(单位秒，为0或超过配置的最长有效期时使用最长有效期)
+	Reason    string `json:"reason" binding:"required"`  // 模拟登录原因(记录到审计日志)
+}
+
+// UserSessions 用户会话列表
+type UserSessions []*UserSession
@@ -28,6 +28,19 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "查询令牌校验公钥(JSON Web Key Set)",
+                "responses": {
+                    "200": {
+                        "description": "{keys:[{kty,kid,use,alg,n,e,crv,x,y}]}"
+                    }
+                }
+            }
+        },
         "/api/v1/demos": {
             "get": {
                 "tags": [
@@ -781,25 +794,62 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/pub/current/menutree": {
+        "/api/v1/orgs": {
             "get": {
                 "tags": [
-                    "登录管理"
+                    "部门管理"
                 ],
-                "summary": "查询当前用户菜单树",
+                "summary": "查询数据",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer 用户令牌",
                         "name": "Authorization",
                         "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "父级ID",
+                        "name": "parentID",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询结果：{list:菜单树}",
+                        "description": "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}",
                         "schema": {
-                            "$ref": "#/definitions/schema.Menu"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.Org"
+                            }
                         }
                     },
                     "401": {
@@ -815,14 +865,12 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/pub/current/password": {
-            "put": {
+            },
+            "post": {
                 "tags": [
-                    "登录管理"
+                    "部门管理"
                 ],
-                "summary": "更新个人密码",
+                "summary": "创建数据",
                 "parameters": [
                     {
                         "type": "string",
@@ -831,20 +879,20 @@ var doc = `{
                         "in": "header"
                     },
                     {
-                        "description": "请求参数",
+                        "description": "创建数据",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.UpdatePasswordParam"
+                            "$ref": "#/definitions/schema.Org"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{status:OK}",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
+                            "$ref": "#/definitions/schema.RecordIDResult"
                         }
                     },
                     "400": {
@@ -868,25 +916,40 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/pub/current/user": {
+        "/api/v1/orgs.tree": {
             "get": {
                 "tags": [
-                    "登录管理"
+                    "部门管理"
                 ],
-                "summary": "获取当前用户信息",
+                "summary": "查询部门树",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer 用户令牌",
                         "name": "Authorization",
                         "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "父级ID",
+                        "name": "parentID",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询结果：{list:列表数据}",
                         "schema": {
-                            "$ref": "#/definitions/schema.UserLoginInfo"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.OrgTree"
+                            }
                         }
                     },
                     "401": {
@@ -904,32 +967,42 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/pub/login": {
-            "post": {
+        "/api/v1/orgs/{id}": {
+            "get": {
                 "tags": [
-                    "登录管理"
+                    "部门管理"
                 ],
-                "summary": "用户登录",
+                "summary": "查询指定数据",
                 "parameters": [
                     {
-                        "description": "请求参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.LoginParam"
-                        }
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                            "$ref": "#/definitions/schema.Org"
                         }
                     },
-                    "400": {
-                        "description": "{error:{code:0,message:无效的请求参数}}",
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
                         "schema": {
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
@@ -941,35 +1014,42 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/pub/login/captcha": {
-            "get": {
-                "produces": [
-                    "image/png"
-                ],
+            },
+            "put": {
                 "tags": [
-                    "登录管理"
+                    "部门管理"
                 ],
-                "summary": "响应图形验证码",
+                "summary": "更新数据",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "验证码ID",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "重新加载",
-                        "name": "reload",
-                        "in": "query"
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Org"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "图形验证码"
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
                     },
                     "400": {
                         "description": "{error:{code:0,message:无效的请求参数}}",
@@ -977,6 +1057,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -984,59 +1070,75 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/pub/login/captchaid": {
-            "get": {
+            },
+            "delete": {
                 "tags": [
-                    "登录管理"
+                    "部门管理"
                 ],
-                "summary": "获取验证码信息",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/schema.LoginCaptcha"
-                        }
+                "summary": "删除数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
-                }
-            }
-        },
-        "/api/v1/pub/login/exit": {
-            "post": {
-                "tags": [
-                    "登录管理"
                 ],
-                "summary": "用户登出",
                 "responses": {
                     "200": {
                         "description": "{status:OK}",
                         "schema": {
                             "$ref": "#/definitions/schema.StatusResult"
                         }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/pub/refresh-token": {
-            "post": {
+        "/api/v1/orgs/{id}/disable": {
+            "patch": {
                 "tags": [
-                    "登录管理"
+                    "部门管理"
                 ],
-                "summary": "刷新令牌",
+                "summary": "禁用数据",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer 用户令牌",
                         "name": "Authorization",
                         "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "{status:OK}",
                         "schema": {
-                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                            "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
                     "401": {
@@ -1054,12 +1156,12 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles": {
-            "get": {
+        "/api/v1/orgs/{id}/enable": {
+            "patch": {
                 "tags": [
-                    "角色管理"
+                    "部门管理"
                 ],
-                "summary": "查询数据",
+                "summary": "启用数据",
                 "parameters": [
                     {
                         "type": "string",
@@ -1067,43 +1169,19 @@ var doc = `{
                         "name": "Authorization",
                         "in": "header"
                     },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "分页索引",
-                        "name": "current",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "分页大小",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "查询值",
-                        "name": "queryValue",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "状态(1:启用 2:禁用)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}",
+                        "description": "{status:OK}",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/schema.Role"
-                            }
+                            "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
                     "401": {
@@ -1119,12 +1197,14 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/orgs/{id}/move": {
+            "put": {
                 "tags": [
-                    "角色管理"
+                    "部门管理"
                 ],
-                "summary": "创建数据",
+                "summary": "移动部门",
                 "parameters": [
                     {
                         "type": "string",
@@ -1133,20 +1213,27 @@ var doc = `{
                         "in": "header"
                     },
                     {
-                        "description": "创建数据",
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "移动参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.Role"
+                            "$ref": "#/definitions/schema.OrgMoveParam"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "{status:OK}",
                         "schema": {
-                            "$ref": "#/definitions/schema.RecordIDResult"
+                            "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
                     "400": {
@@ -1170,12 +1257,12 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles.select": {
-            "get": {
+        "/api/v1/permissions/check": {
+            "post": {
                 "tags": [
-                    "角色管理"
+                    "权限诊断"
                 ],
-                "summary": "查询选择数据",
+                "summary": "批量检查用户在当前租户下对请求的访问权限(结果与权限校验一致，用户ID为空时为当前用户)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1184,30 +1271,27 @@ var doc = `{
                         "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "查询值",
-                        "name": "queryValue",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "状态(1:启用 2:禁用)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "检查参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.PermissionCheckParam"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询结果：{list:角色列表}",
+                        "description": "查询结果：{list:检查结果}",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/schema.Role"
+                                "$ref": "#/definitions/schema.PermissionCheckItem"
                             }
                         }
                     },
                     "400": {
-                        "description": "{error:{code:0,message:未知的查询类型}}",
+                        "description": "{error:{code:0,message:无效的请求参数}}",
                         "schema": {
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
@@ -1218,6 +1302,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1227,12 +1317,12 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles/{id}": {
+        "/api/v1/permissions/explain": {
             "get": {
                 "tags": [
-                    "角色管理"
+                    "权限诊断"
                 ],
-                "summary": "查询指定数据",
+                "summary": "解释用户在当前租户下对指定请求的访问权限(结果与权限校验一致，返回授权链，拒绝时返回最接近的候选动作)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1242,17 +1332,61 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "用户ID",
+                        "name": "userID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求方法",
+                        "name": "method",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求路径",
+                        "name": "path",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "令牌视图(为空时为admin)",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端IP(用于判定访问条件)",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求时间(格式为2006-01-02 15:04:05，为空时为当前时间)",
+                        "name": "time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求属性(格式为attrs[header.X-Env]=prod)",
+                        "name": "attrs",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.Role"
+                            "$ref": "#/definitions/schema.PermissionExplain"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
                     "401": {
@@ -1274,45 +1408,31 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/pub/current/impersonation": {
+            "delete": {
                 "tags": [
-                    "角色管理"
+                    "登录管理"
                 ],
-                "summary": "更新数据",
+                "summary": "结束模拟登录(销毁当前的模拟登录令牌)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer 用户令牌",
+                        "description": "Bearer 模拟登录令牌",
                         "name": "Authorization",
                         "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "更新数据",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schema.Role"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "{status:OK}",
                         "schema": {
-                            "$ref": "#/definitions/schema.Role"
+                            "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
                     "400": {
-                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "description": "{error:{code:0,message:当前不是模拟登录}}",
                         "schema": {
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
@@ -1330,32 +1450,27 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/pub/current/menutree": {
+            "get": {
                 "tags": [
-                    "角色管理"
+                    "登录管理"
                 ],
-                "summary": "删除数据",
+                "summary": "查询当前用户菜单树",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer 用户令牌",
                         "name": "Authorization",
                         "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{status:OK}",
+                        "description": "查询结果：{list:菜单树}",
                         "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
+                            "$ref": "#/definitions/schema.Menu"
                         }
                     },
                     "401": {
@@ -1373,12 +1488,12 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/roles/{id}/disable": {
-            "patch": {
+        "/api/v1/pub/current/mfa": {
+            "put": {
                 "tags": [
-                    "角色管理"
+                    "登录管理"
                 ],
-                "summary": "禁用数据",
+                "summary": "启用多因素认证",
                 "parameters": [
                     {
                         "type": "string",
@@ -1387,18 +1502,26 @@ var doc = `{
                         "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.MFACodeParam"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{status:OK}",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
+                            "$ref": "#/definitions/schema.MFARecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
                     "401": {
@@ -1414,27 +1537,67 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/roles/{id}/enable": {
-            "patch": {
+            },
+            "post": {
                 "tags": [
-                    "角色管理"
+                    "登录管理"
                 ],
-                "summary": "启用数据",
+                "summary": "绑定认证器(生成待激活的密钥)",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer 用户令牌",
                         "name": "Authorization",
                         "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.MFAEnrollInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "停用多因素认证",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.MFACodeParam"
+                        }
                     }
                 ],
                 "responses": {
@@ -1444,6 +1607,12 @@ var doc = `{
                             "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "401": {
                         "description": "{error:{code:0,message:未授权}}",
                         "schema": {
@@ -1459,12 +1628,12 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/users": {
-            "get": {
+        "/api/v1/pub/current/mfa/recovery-codes": {
+            "post": {
                 "tags": [
-                    "用户管理"
+                    "登录管理"
                 ],
-                "summary": "查询数据",
+                "summary": "重新生成恢复码(原有恢复码全部作废)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1473,48 +1642,77 @@ var doc = `{
                         "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "分页索引",
-                        "name": "current",
-                        "in": "query",
-                        "required": true
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.MFACodeParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.MFARecoveryCodes"
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "分页大小",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "查询值",
-                        "name": "queryValue",
-                        "in": "query"
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/password": {
+            "put": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "更新个人密码",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "角色ID(多个以英文逗号分隔)",
-                        "name": "roleIDs",
-                        "in": "query"
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "description": "状态(1:启用 2:停用)",
-                        "name": "status",
-                        "in": "query"
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.UpdatePasswordParam"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}",
+                        "description": "{status:OK}",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/schema.UserShow"
-                            }
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
                     "401": {
@@ -1530,12 +1728,14 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/pub/current/permissions/check": {
             "post": {
                 "tags": [
-                    "用户管理"
+                    "权限诊断"
                 ],
-                "summary": "创建数据",
+                "summary": "批量检查当前用户对请求的访问权限(用于前端路由守卫)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1544,20 +1744,23 @@ var doc = `{
                         "in": "header"
                     },
                     {
-                        "description": "创建数据",
+                        "description": "检查参数(忽略用户ID、令牌视图、客户端IP及请求属性)",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.User"
+                            "$ref": "#/definitions/schema.PermissionCheckParam"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询结果：{list:检查结果}",
                         "schema": {
-                            "$ref": "#/definitions/schema.RecordIDResult"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.PermissionCheckItem"
+                            }
                         }
                     },
                     "400": {
@@ -1581,12 +1784,51 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/users/{id}": {
+        "/api/v1/pub/current/sessions": {
             "get": {
                 "tags": [
-                    "用户管理"
+                    "登录管理"
                 ],
-                "summary": "查询指定数据",
+                "summary": "查询当前用户的有效会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:会话列表}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.UserSession"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/sessions/{sid}": {
+            "delete": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "撤销当前用户的指定会话",
                 "parameters": [
                     {
                         "type": "string",
@@ -1596,17 +1838,17 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
+                        "description": "会话ID",
+                        "name": "sid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "{status:OK}",
                         "schema": {
-                            "$ref": "#/definitions/schema.User"
+                            "$ref": "#/definitions/schema.StatusResult"
                         }
                     },
                     "401": {
@@ -1615,12 +1857,6 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
-                    "404": {
-                        "description": "{error:{code:0,message:资源不存在}}",
-                        "schema": {
-                            "$ref": "#/definitions/schema.ErrorResult"
-                        }
-                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1628,12 +1864,14 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/pub/current/tenant": {
             "put": {
                 "tags": [
-                    "用户管理"
+                    "登录管理"
                 ],
-                "summary": "更新数据",
+                "summary": "切换租户(签发指定租户的新令牌，并销毁当前令牌)",
                 "parameters": [
                     {
                         "type": "string",
@@ -1642,19 +1880,12 @@ var doc = `{
                         "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "更新数据",
+                        "description": "请求参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schema.User"
+                            "$ref": "#/definitions/schema.TenantSwitchParam"
                         }
                     }
                 ],
@@ -1662,11 +1893,11 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.User"
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
                         }
                     },
                     "400": {
-                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "description": "{error:{code:0,message:租户不存在或已停用}}",
                         "schema": {
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
@@ -1684,32 +1915,30 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/pub/current/tenants": {
+            "get": {
                 "tags": [
-                    "用户管理"
+                    "登录管理"
                 ],
-                "summary": "删除数据",
+                "summary": "查询当前用户可以切换的租户(不包括默认租户)",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer 用户令牌",
                         "name": "Authorization",
                         "in": "header"
-                    },
-                    {
-                        "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{status:OK}",
+                        "description": "查询结果：{list:租户列表}",
                         "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.Tenant"
+                            }
                         }
                     },
                     "401": {
@@ -1727,32 +1956,78 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/users/{id}/disable": {
-            "patch": {
+        "/api/v1/pub/current/tokens": {
+            "get": {
                 "tags": [
-                    "用户管理"
+                    "访问令牌管理"
                 ],
-                "summary": "禁用数据",
+                "summary": "查询当前用户的访问令牌",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Bearer 用户令牌",
                         "name": "Authorization",
                         "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:令牌列表}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.APIToken"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
                     },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "令牌明文仅在创建时返回，授权动作必须是当前用户已授权的菜单动作",
+                "tags": [
+                    "访问令牌管理"
+                ],
+                "summary": "为当前用户创建访问令牌",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.APITokenCreateParam"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "{status:OK}",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/schema.StatusResult"
+                            "$ref": "#/definitions/schema.APITokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的令牌授权动作}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
                     "401": {
@@ -1770,12 +2045,12 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/users/{id}/enable": {
-            "patch": {
+        "/api/v1/pub/current/tokens/{tid}": {
+            "delete": {
                 "tags": [
-                    "用户管理"
+                    "访问令牌管理"
                 ],
-                "summary": "启用数据",
+                "summary": "删除当前用户的访问令牌",
                 "parameters": [
                     {
                         "type": "string",
@@ -1785,8 +2060,8 @@ var doc = `{
                     },
                     {
                         "type": "string",
-                        "description": "记录ID",
-                        "name": "id",
+                        "description": "令牌ID",
+                        "name": "tid",
                         "in": "path",
                         "required": true
                     }
@@ -1804,6 +2079,2271 @@ var doc = `{
                             "$ref": "#/definitions/schema.ErrorResult"
                         }
                     },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/current/user": {
+            "get": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "获取当前用户信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.UserLoginInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login": {
+            "post": {
+                "description": "需要多因素认证时返回schema.LoginMFAChallenge，需继续调用 /api/v1/pub/login/mfa 完成登录",
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "429": {
+                        "description": "{error:{code:9997,message:登录失败次数过多，已被临时锁定}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/captcha": {
+            "get": {
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "响应图形验证码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "验证码ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "重新加载",
+                        "name": "reload",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "图形验证码"
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/captchaid": {
+            "get": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "获取验证码信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginCaptcha"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/exit": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "用户登出",
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/mfa": {
+            "post": {
+                "description": "挑战令牌只能提交一次，验证失败需要重新登录；每个动态验证码只能使用一次，验证失败计入登录失败次数",
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "提交动态验证码(或恢复码)完成登录",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginMFAParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:9999,message:令牌失效}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "429": {
+                        "description": "{error:{code:9997,message:登录失败次数过多，已被临时锁定}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/mfa/enroll": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "登录时绑定认证器(角色要求多因素认证但用户未启用)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginMFAEnrollParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.MFAEnrollInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:9999,message:令牌失效}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/oidc": {
+            "get": {
+                "description": "指定redirect参数时直接重定向到身份提供方的授权地址",
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "发起OIDC登录(授权码模式+PKCE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "是否重定向(任意非空值)",
+                        "name": "redirect",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "登录完成后签发令牌的视图(为空时使用默认视图)",
+                        "name": "view",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginOIDCAuthorize"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:未启用OIDC登录}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/login/oidc/callback": {
+            "get": {
+                "description": "用户需要进行多因素认证时返回挑战信息",
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "OIDC授权回调(校验ID令牌并签发令牌)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "授权码",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权请求的state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "身份提供方返回的错误码",
+                        "name": "error",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "身份提供方返回的错误描述",
+                        "name": "error_description",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的OIDC授权请求}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/password/forgot": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "找回密码(向用户邮箱发送一次性的重置链接，用户不存在时同样返回成功)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ForgotPasswordParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/password/reset": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "重置密码(重置成功后撤销用户的全部会话)",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ResetPasswordParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:重置链接无效或已过期}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pub/refresh-token": {
+            "post": {
+                "tags": [
+                    "登录管理"
+                ],
+                "summary": "刷新令牌",
+                "parameters": [
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.RefreshTokenParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles": {
+            "get": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "查询数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.Role"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "创建数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "创建数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.RecordIDResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles.select": {
+            "get": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "查询选择数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:角色列表}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.Role"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:未知的查询类型}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}": {
+            "get": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "查询指定数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "更新数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "删除数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/disable": {
+            "patch": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "禁用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/dry-run": {
+            "put": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "模拟更新数据(不保存)，返回受影响用户获得及失去的菜单、动作及接口",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.PermissionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/roles/{id}/enable": {
+            "patch": {
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "启用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants": {
+            "get": {
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "查询数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:禁用)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.Tenant"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "创建数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "创建数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Tenant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.RecordIDResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}": {
+            "get": {
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "查询指定数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.Tenant"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "更新数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.Tenant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "删除数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/disable": {
+            "patch": {
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "禁用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tenants/{id}/enable": {
+            "patch": {
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "启用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "分页索引",
+                        "name": "current",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "分页大小",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "查询值",
+                        "name": "queryValue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色ID(多个以英文逗号分隔)",
+                        "name": "roleIDs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "状态(1:启用 2:停用)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "类型(1:普通用户 2:服务账号)",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:列表数据,pagination:{current:页索引,pageSize:页大小,total:总数量}}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.UserShow"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "创建数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "description": "创建数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.RecordIDResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users.locks": {
+            "get": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询因登录失败次数过多而被锁定的用户名及IP",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:锁定列表}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.LoginLock"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users.locks/{type}/{name}": {
+            "delete": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "解除登录锁定",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "锁定类型(user:用户名 ip:客户端IP)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名或IP",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的锁定类型}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}": {
+            "get": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询指定数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/disable": {
+            "patch": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "禁用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/dry-run": {
+            "put": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "模拟更新数据(不保存)，返回用户获得及失去的菜单、动作及接口",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新数据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.PermissionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/enable": {
+            "patch": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "启用数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/impersonate": {
+            "post": {
+                "description": "被模拟的用户必须在当前用户的数据范围内并且属于当前租户，非root用户只能模拟角色不超出自己的用户；模拟登录期间的日志同时记录操作者ID",
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "模拟登录(以指定用户的身份访问，只签发访问令牌，到期后不能刷新)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.ImpersonateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.LoginTokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的请求参数}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/mfa": {
+            "delete": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "重置多因素认证(用户丢失认证器时使用)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/sessions": {
+            "get": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "查询用户的有效会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:会话列表}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.UserSession"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "撤销用户的全部会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/sessions/{sid}": {
+            "delete": {
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "撤销用户的指定会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "sid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/tokens": {
+            "get": {
+                "description": "不能查询root用户的令牌，用户必须在当前用户的数据范围内并且属于当前租户",
+                "tags": [
+                    "访问令牌管理"
+                ],
+                "summary": "查询用户(或服务账号)的访问令牌",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询结果：{list:令牌列表}",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/schema.APIToken"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:不能管理root用户的访问令牌}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "令牌明文仅在创建时返回，授权动作必须是用户已授权的菜单动作；不能为root用户创建令牌，为普通用户创建令牌时当前用户必须拥有该用户的模拟登录权限，否则只能为服务账号创建令牌",
+                "tags": [
+                    "访问令牌管理"
+                ],
+                "summary": "为用户(或服务账号)创建访问令牌",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "请求参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schema.APITokenCreateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schema.APITokenInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:无效的令牌授权动作}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "500": {
+                        "description": "{error:{code:0,message:服务器错误}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}/tokens/{tid}": {
+            "delete": {
+                "tags": [
+                    "访问令牌管理"
+                ],
+                "summary": "删除用户(或服务账号)的访问令牌",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer 用户令牌",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "令牌ID",
+                        "name": "tid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{status:OK}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.StatusResult"
+                        }
+                    },
+                    "400": {
+                        "description": "{error:{code:0,message:不能管理root用户的访问令牌}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "401": {
+                        "description": "{error:{code:0,message:未授权}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
+                    "404": {
+                        "description": "{error:{code:0,message:资源不存在}}",
+                        "schema": {
+                            "$ref": "#/definitions/schema.ErrorResult"
+                        }
+                    },
                     "500": {
                         "description": "{error:{code:0,message:服务器错误}}",
                         "schema": {
@@ -1815,314 +4355,1149 @@ var doc = `{
         }
     },
     "definitions": {
+        "schema.APIToken": {
+            "type": "object",
+            "properties": {
+                "action_ids": {
+                    "description": "授权的菜单动作ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "过期时间(为空则永不过期)",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "最后使用时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "令牌名称",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "令牌前缀(用于识别令牌)",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "所属用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.APITokenCreateParam": {
+            "type": "object",
+            "required": [
+                "action_ids",
+                "name"
+            ],
+            "properties": {
+                "action_ids": {
+                    "description": "授权的菜单动作ID列表(须为用户已授权的动作)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_in": {
+                    "description": "有效期(单位秒，为0则永不过期)",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "令牌名称",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.APITokenInfo": {
+            "type": "object",
+            "properties": {
+                "action_ids": {
+                    "description": "授权的菜单动作ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "过期时间(为空则永不过期)",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "最后使用时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "令牌名称",
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "令牌前缀(用于识别令牌)",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "访问令牌",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "所属用户ID",
+                    "type": "string"
+                }
+            }
+        },
         "schema.Demo": {
             "type": "object",
-            "required": [
-                "code",
-                "name",
-                "status"
-            ],
+            "required": [
+                "code",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "code": {
+                    "description": "编号",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "memo": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:停用)",
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ErrorItem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "错误码",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "错误信息",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ErrorResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "错误项",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.ErrorItem"
+                }
+            }
+        },
+        "schema.ForgotPasswordParam": {
+            "type": "object",
+            "required": [
+                "user_name"
+            ],
+            "properties": {
+                "user_name": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ImpersonateParam": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "expires_in": {
+                    "description": "有效期(单位秒，为0或超过配置的最长有效期时使用最长有效期)",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "模拟登录原因(记录到审计日志)",
+                    "type": "string"
+                },
+                "view": {
+                    "description": "令牌视图(为空则使用默认视图)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.LoginCaptcha": {
+            "type": "object",
+            "properties": {
+                "captcha_id": {
+                    "description": "验证码ID",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.LoginLock": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "解锁时间戳",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "用户名或IP",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "锁定对象的类型(user:用户名 ip:客户端IP)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.LoginMFAEnrollParam": {
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "mfa_token": {
+                    "description": "挑战令牌",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.LoginMFAParam": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "动态验证码或恢复码",
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "description": "挑战令牌",
+                    "type": "string"
+                },
+                "view": {
+                    "description": "令牌视图(为空时使用默认视图)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.LoginOIDCAuthorize": {
+            "type": "object",
+            "properties": {
+                "auth_url": {
+                    "description": "身份提供方的授权地址",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "授权请求的state",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.LoginParam": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "description": "验证码(启用验证码时需要)",
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "description": "验证码ID(启用验证码时需要)",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码(明文)",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                },
+                "view": {
+                    "description": "令牌视图(为空时使用默认视图)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.LoginTokenInfo": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "访问令牌",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "令牌到期时间戳",
+                    "type": "integer"
+                },
+                "password_change_required": {
+                    "description": "是否需要先修改密码(此时令牌只能用于修改密码)",
+                    "type": "boolean"
+                },
+                "recovery_codes": {
+                    "description": "登录时完成多因素认证绑定所生成的恢复码",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_expires_at": {
+                    "description": "刷新令牌到期时间戳",
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "description": "刷新令牌",
+                    "type": "string"
+                },
+                "token_type": {
+                    "description": "令牌类型",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.MFACodeParam": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "动态验证码或恢复码",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.MFAEnrollInfo": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "description": "密钥(base32编码)",
+                    "type": "string"
+                },
+                "uri": {
+                    "description": "配置URI(otpauth://格式，用于生成二维码)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.MFARecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "一次性恢复码列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schema.Menu": {
+            "type": "object",
+            "required": [
+                "name",
+                "show_status",
+                "status"
+            ],
+            "properties": {
+                "actions": {
+                    "description": "动作列表",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.MenuActions"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
+                },
+                "memo": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "菜单名称",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父级ID",
+                    "type": "string"
+                },
+                "parent_path": {
+                    "description": "父级路径",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "router": {
+                    "description": "访问路由",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "排序值",
+                    "type": "integer"
+                },
+                "show_status": {
+                    "description": "显示状态(1:显示 2:隐藏)",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:禁用)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.MenuAction": {
+            "type": "object",
+            "required": [
+                "code",
+                "menu_id",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "description": "动作编号",
+                    "type": "string"
+                },
+                "menu_id": {
+                    "description": "菜单ID",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "动作名称",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "resources": {
+                    "description": "资源列表",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.MenuActionResources"
+                }
+            }
+        },
+        "schema.MenuActionResource": {
+            "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
+            "properties": {
+                "action_id": {
+                    "description": "菜单动作ID",
+                    "type": "string"
+                },
+                "attributes": {
+                    "description": "请求属性条件(如header.X-Env=^prod$，多个以分号分隔，为空表示不限制；属性由客户端提供，不能作为安全边界，拒绝规则不支持)",
+                    "type": "string"
+                },
+                "effect": {
+                    "description": "授权效果(allow:允许 deny:拒绝，为空表示允许，拒绝优先)",
+                    "type": "string"
+                },
+                "ip_ranges": {
+                    "description": "客户端IP范围条件(多个CIDR以逗号分隔，为空表示不限制)",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "资源请求方式(支持正则)",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "资源请求路径（支持/:id匹配）",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "time_window": {
+                    "description": "时间窗口条件(如09:00-18:00，为空表示不限制)",
+                    "type": "string"
+                },
+                "view": {
+                    "description": "资源允许的令牌视图(为空表示不限制)",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.MenuActionResources": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.MenuActionResource"
+            }
+        },
+        "schema.MenuActions": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.MenuAction"
+            }
+        },
+        "schema.MenuTree": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "动作列表",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.MenuActions"
+                },
+                "children": {
+                    "description": "子级树",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.MenuTrees"
+                },
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "菜单名称",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父级ID",
+                    "type": "string"
+                },
+                "parent_path": {
+                    "description": "父级路径",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "router": {
+                    "description": "访问路由",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "排序值",
+                    "type": "integer"
+                },
+                "show_status": {
+                    "description": "显示状态(1:显示 2:隐藏)",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:禁用)",
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.MenuTrees": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.MenuTree"
+            }
+        },
+        "schema.Org": {
+            "type": "object",
+            "required": [
+                "name",
+                "status"
+            ],
+            "properties": {
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "leader": {
+                    "description": "负责人",
+                    "type": "string"
+                },
+                "memo": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "部门名称",
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "父级ID",
+                    "type": "string"
+                },
+                "parent_path": {
+                    "description": "父级路径",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "联系电话",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "排序值",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:禁用)",
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.OrgMoveParam": {
+            "type": "object",
             "properties": {
-                "code": {
-                    "description": "编号",
+                "parent_id": {
+                    "description": "新的父级ID(为空时移动到顶级)",
                     "type": "string"
+                }
+            }
+        },
+        "schema.OrgTree": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "description": "子级树",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.OrgTrees"
                 },
-                "created_at": {
-                    "description": "创建时间",
+                "leader": {
+                    "description": "负责人",
                     "type": "string"
                 },
-                "creator": {
-                    "description": "创建者",
+                "name": {
+                    "description": "部门名称",
                     "type": "string"
                 },
-                "memo": {
-                    "description": "备注",
+                "parent_id": {
+                    "description": "父级ID",
                     "type": "string"
                 },
-                "name": {
-                    "description": "名称",
+                "parent_path": {
+                    "description": "父级路径",
                     "type": "string"
                 },
                 "record_id": {
                     "description": "记录ID",
                     "type": "string"
                 },
-                "status": {
-                    "description": "状态(1:启用 2:停用)",
+                "sequence": {
+                    "description": "排序值",
                     "type": "integer"
                 },
-                "updated_at": {
-                    "description": "更新时间",
-                    "type": "string"
+                "status": {
+                    "description": "状态(1:启用 2:禁用)",
+                    "type": "integer"
                 }
             }
         },
-        "schema.ErrorItem": {
+        "schema.OrgTrees": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.OrgTree"
+            }
+        },
+        "schema.PermissionAction": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "错误码",
-                    "type": "integer"
+                "action_code": {
+                    "description": "动作编号",
+                    "type": "string"
                 },
-                "message": {
-                    "description": "错误信息",
+                "action_id": {
+                    "description": "动作ID",
+                    "type": "string"
+                },
+                "action_name": {
+                    "description": "动作名称",
+                    "type": "string"
+                },
+                "menu_id": {
+                    "description": "菜单ID",
+                    "type": "string"
+                },
+                "menu_name": {
+                    "description": "菜单名称",
                     "type": "string"
+                },
+                "resources": {
+                    "description": "动作的资源(接口)",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionResources"
                 }
             }
         },
-        "schema.ErrorResult": {
+        "schema.PermissionActionDiff": {
             "type": "object",
             "properties": {
-                "error": {
-                    "description": "错误项",
+                "action_code": {
+                    "description": "动作编号",
+                    "type": "string"
+                },
+                "action_id": {
+                    "description": "动作ID",
+                    "type": "string"
+                },
+                "action_name": {
+                    "description": "动作名称",
+                    "type": "string"
+                },
+                "gained_users": {
+                    "description": "新增该动作的用户",
                     "type": "object",
-                    "$ref": "#/definitions/schema.ErrorItem"
+                    "$ref": "#/definitions/schema.PermissionUsers"
+                },
+                "lost_users": {
+                    "description": "失去该动作的用户",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionUsers"
+                },
+                "menu_id": {
+                    "description": "菜单ID",
+                    "type": "string"
+                },
+                "menu_name": {
+                    "description": "菜单名称",
+                    "type": "string"
+                },
+                "resources": {
+                    "description": "动作的资源(接口)",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionResources"
                 }
             }
         },
-        "schema.LoginCaptcha": {
+        "schema.PermissionActionDiffs": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.PermissionActionDiff"
+            }
+        },
+        "schema.PermissionActions": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.PermissionAction"
+            }
+        },
+        "schema.PermissionCheckItem": {
             "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
             "properties": {
-                "captcha_id": {
-                    "description": "验证码ID",
+                "allowed": {
+                    "description": "是否允许访问(检查结果)",
+                    "type": "boolean"
+                },
+                "method": {
+                    "description": "请求方法",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "请求路径",
                     "type": "string"
                 }
             }
         },
-        "schema.LoginParam": {
+        "schema.PermissionCheckItems": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.PermissionCheckItem"
+            }
+        },
+        "schema.PermissionCheckParam": {
             "type": "object",
             "required": [
-                "captcha_code",
-                "captcha_id",
-                "password",
-                "user_name"
+                "items"
             ],
             "properties": {
-                "captcha_code": {
-                    "description": "验证码",
-                    "type": "string"
+                "attributes": {
+                    "description": "请求属性(用于判定访问条件，如header.X-Env)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "captcha_id": {
-                    "description": "验证码ID",
+                "ip": {
+                    "description": "客户端IP(用于判定访问条件)",
                     "type": "string"
                 },
-                "password": {
-                    "description": "密码(md5加密)",
+                "items": {
+                    "description": "检查项列表",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionCheckItems"
+                },
+                "user_id": {
+                    "description": "用户ID(为空时为当前用户)",
                     "type": "string"
                 },
-                "user_name": {
-                    "description": "用户名",
+                "view": {
+                    "description": "令牌视图(为空时为admin)",
                     "type": "string"
                 }
             }
         },
-        "schema.LoginTokenInfo": {
+        "schema.PermissionDiff": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "description": "访问令牌",
+                "actions": {
+                    "description": "按菜单动作分组的变更",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionActionDiffs"
+                },
+                "users": {
+                    "description": "按用户分组的变更",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionUserDiffs"
+                }
+            }
+        },
+        "schema.PermissionExplain": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "是否允许访问",
+                    "type": "boolean"
+                },
+                "candidates": {
+                    "description": "拒绝访问时最接近的候选动作",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionMatches"
+                },
+                "ip": {
+                    "description": "客户端IP",
                     "type": "string"
                 },
-                "expires_at": {
-                    "description": "令牌到期时间戳",
-                    "type": "integer"
+                "matches": {
+                    "description": "允许访问的授权链(角色、菜单动作及资源)",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionMatches"
                 },
-                "token_type": {
-                    "description": "令牌类型",
+                "method": {
+                    "description": "请求方法",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "请求路径",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "判定说明",
+                    "type": "string"
+                },
+                "time": {
+                    "description": "请求时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "view": {
+                    "description": "令牌视图",
                     "type": "string"
                 }
             }
         },
-        "schema.Menu": {
+        "schema.PermissionMatch": {
             "type": "object",
-            "required": [
-                "name",
-                "show_status",
-                "status"
-            ],
             "properties": {
-                "actions": {
-                    "description": "动作列表",
-                    "type": "object",
-                    "$ref": "#/definitions/schema.MenuActions"
+                "action_code": {
+                    "description": "动作编号",
+                    "type": "string"
                 },
-                "created_at": {
-                    "description": "创建时间",
+                "action_id": {
+                    "description": "动作ID",
                     "type": "string"
                 },
-                "creator": {
-                    "description": "创建者",
+                "action_name": {
+                    "description": "动作名称",
                     "type": "string"
                 },
-                "icon": {
-                    "description": "菜单图标",
+                "attributes": {
+                    "description": "请求属性条件",
                     "type": "string"
                 },
-                "memo": {
-                    "description": "备注",
+                "effect": {
+                    "description": "授权效果(allow:允许 deny:拒绝)",
                     "type": "string"
                 },
-                "name": {
+                "hint": {
+                    "description": "候选动作与请求的差异说明",
+                    "type": "string"
+                },
+                "ip_ranges": {
+                    "description": "客户端IP范围条件",
+                    "type": "string"
+                },
+                "menu_id": {
+                    "description": "菜单ID",
+                    "type": "string"
+                },
+                "menu_name": {
                     "description": "菜单名称",
                     "type": "string"
                 },
-                "parent_id": {
-                    "description": "父级ID",
+                "method": {
+                    "description": "资源请求方式",
                     "type": "string"
                 },
-                "parent_path": {
-                    "description": "父级路径",
+                "path": {
+                    "description": "资源请求路径",
                     "type": "string"
                 },
-                "record_id": {
-                    "description": "记录ID",
+                "resource_id": {
+                    "description": "资源ID",
                     "type": "string"
                 },
-                "router": {
-                    "description": "访问路由",
+                "role_id": {
+                    "description": "授权动作的角色ID(继承时为上级角色)",
                     "type": "string"
                 },
-                "sequence": {
-                    "description": "排序值",
-                    "type": "integer"
+                "role_name": {
+                    "description": "授权动作的角色名称",
+                    "type": "string"
                 },
-                "show_status": {
-                    "description": "显示状态(1:显示 2:隐藏)",
-                    "type": "integer"
+                "time_window": {
+                    "description": "时间窗口条件",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "状态(1:启用 2:禁用)",
-                    "type": "integer"
+                "user_role_id": {
+                    "description": "用户直接拥有的角色ID",
+                    "type": "string"
                 },
-                "updated_at": {
-                    "description": "更新时间",
+                "user_role_name": {
+                    "description": "用户直接拥有的角色名称",
+                    "type": "string"
+                },
+                "view": {
+                    "description": "资源允许的令牌视图(*表示不限制)",
                     "type": "string"
                 }
             }
         },
-        "schema.MenuAction": {
-            "type": "object",
-            "required": [
-                "code",
-                "menu_id",
-                "name"
-            ],
-            "properties": {
-                "code": {
-                    "description": "动作编号",
-                    "type": "string"
-                },
+        "schema.PermissionMatches": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.PermissionMatch"
+            }
+        },
+        "schema.PermissionMenu": {
+            "type": "object",
+            "properties": {
                 "menu_id": {
                     "description": "菜单ID",
                     "type": "string"
                 },
-                "name": {
-                    "description": "动作名称",
-                    "type": "string"
-                },
-                "record_id": {
-                    "description": "记录ID",
+                "menu_name": {
+                    "description": "菜单名称",
                     "type": "string"
-                },
-                "resources": {
-                    "description": "资源列表",
-                    "type": "object",
-                    "$ref": "#/definitions/schema.MenuActionResources"
                 }
             }
         },
-        "schema.MenuActionResource": {
+        "schema.PermissionMenus": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.PermissionMenu"
+            }
+        },
+        "schema.PermissionResource": {
             "type": "object",
-            "required": [
-                "method",
-                "path"
-            ],
             "properties": {
-                "action_id": {
-                    "description": "菜单动作ID",
+                "attributes": {
+                    "description": "请求属性条件",
+                    "type": "string"
+                },
+                "effect": {
+                    "description": "授权效果(allow:允许 deny:拒绝)",
+                    "type": "string"
+                },
+                "ip_ranges": {
+                    "description": "客户端IP范围条件",
                     "type": "string"
                 },
                 "method": {
-                    "description": "资源请求方式(支持正则)",
+                    "description": "资源请求方式",
                     "type": "string"
                 },
                 "path": {
-                    "description": "资源请求路径（支持/:id匹配）",
+                    "description": "资源请求路径",
                     "type": "string"
                 },
-                "record_id": {
-                    "description": "记录ID",
+                "time_window": {
+                    "description": "时间窗口条件",
+                    "type": "string"
+                },
+                "view": {
+                    "description": "资源允许的令牌视图(*表示不限制)",
                     "type": "string"
                 }
             }
         },
-        "schema.MenuActionResources": {
+        "schema.PermissionResources": {
             "type": "array",
             "items": {
-                "$ref": "#/definitions/schema.MenuActionResource"
+                "$ref": "#/definitions/schema.PermissionResource"
             }
         },
-        "schema.MenuActions": {
-            "type": "array",
-            "items": {
-                "$ref": "#/definitions/schema.MenuAction"
+        "schema.PermissionUser": {
+            "type": "object",
+            "properties": {
+                "real_name": {
+                    "description": "真实姓名",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "user_name": {
+                    "description": "用户名",
+                    "type": "string"
+                }
             }
         },
-        "schema.MenuTree": {
+        "schema.PermissionUserDiff": {
             "type": "object",
             "properties": {
-                "actions": {
-                    "description": "动作列表",
+                "gained_actions": {
+                    "description": "新增的菜单动作",
                     "type": "object",
-                    "$ref": "#/definitions/schema.MenuActions"
+                    "$ref": "#/definitions/schema.PermissionActions"
                 },
-                "children": {
-                    "description": "子级树",
+                "gained_menus": {
+                    "description": "新增的菜单",
                     "type": "object",
-                    "$ref": "#/definitions/schema.MenuTrees"
+                    "$ref": "#/definitions/schema.PermissionMenus"
                 },
-                "icon": {
-                    "description": "菜单图标",
-                    "type": "string"
+                "gained_resources": {
+                    "description": "新增的接口",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionResources"
                 },
-                "name": {
-                    "description": "菜单名称",
-                    "type": "string"
+                "lost_actions": {
+                    "description": "失去的菜单动作",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionActions"
                 },
-                "parent_id": {
-                    "description": "父级ID",
-                    "type": "string"
+                "lost_menus": {
+                    "description": "失去的菜单",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionMenus"
                 },
-                "parent_path": {
-                    "description": "父级路径",
-                    "type": "string"
+                "lost_resources": {
+                    "description": "失去的接口",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.PermissionResources"
                 },
-                "record_id": {
-                    "description": "记录ID",
+                "real_name": {
+                    "description": "真实姓名",
                     "type": "string"
                 },
-                "router": {
-                    "description": "访问路由",
+                "user_id": {
+                    "description": "用户ID",
                     "type": "string"
                 },
-                "sequence": {
-                    "description": "排序值",
-                    "type": "integer"
-                },
-                "show_status": {
-                    "description": "显示状态(1:显示 2:隐藏)",
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "状态(1:启用 2:禁用)",
-                    "type": "integer"
+                "user_name": {
+                    "description": "用户名",
+                    "type": "string"
                 }
             }
         },
-        "schema.MenuTrees": {
+        "schema.PermissionUserDiffs": {
             "type": "array",
             "items": {
-                "$ref": "#/definitions/schema.MenuTree"
+                "$ref": "#/definitions/schema.PermissionUserDiff"
+            }
+        },
+        "schema.PermissionUsers": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.PermissionUser"
             }
         },
         "schema.RecordIDResult": {
@@ -2133,11 +5508,39 @@ var doc = `{
                 }
             }
         },
+        "schema.RefreshTokenParam": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "刷新令牌",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.ResetPasswordParam": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "新密码(明文)",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "重置令牌(邮件中的重置链接携带)",
+                    "type": "string"
+                }
+            }
+        },
         "schema.Role": {
             "type": "object",
             "required": [
                 "name",
-                "role_menus",
                 "status"
             ],
             "properties": {
@@ -2149,6 +5552,22 @@ var doc = `{
                     "description": "创建者",
                     "type": "string"
                 },
+                "data_org_ids": {
+                    "description": "自定义数据范围的部门ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "data_scope": {
+                    "description": "数据范围(1:全部 2:本人创建 3:本部门 4:自定义部门，未设置时为全部)",
+                    "type": "integer"
+                },
+                "effective_role_menus": {
+                    "description": "生效的角色菜单列表(包含继承的上级角色菜单，只读)",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.RoleMenus"
+                },
                 "memo": {
                     "description": "备注",
                     "type": "string"
@@ -2157,12 +5576,20 @@ var doc = `{
                     "description": "角色名称",
                     "type": "string"
                 },
+                "parent_id": {
+                    "description": "上级角色ID(继承上级角色的全部权限)",
+                    "type": "string"
+                },
                 "record_id": {
                     "description": "记录ID",
                     "type": "string"
                 },
+                "require_mfa": {
+                    "description": "是否要求多因素认证(1:要求 2:不要求)",
+                    "type": "integer"
+                },
                 "role_menus": {
-                    "description": "角色菜单列表",
+                    "description": "直接授权的角色菜单列表(未指定上级角色时必填)",
                     "type": "object",
                     "$ref": "#/definitions/schema.RoleMenus"
                 },
@@ -2174,6 +5601,10 @@ var doc = `{
                     "description": "状态(1:启用 2:禁用)",
                     "type": "integer"
                 },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
                 "updated_at": {
                     "description": "更新时间",
                     "type": "string"
@@ -2203,6 +5634,10 @@ var doc = `{
                 "role_id": {
                     "description": "角色ID",
                     "type": "string"
+                },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
                 }
             }
         },
@@ -2227,6 +5662,57 @@ var doc = `{
                 }
             }
         },
+        "schema.Tenant": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "status"
+            ],
+            "properties": {
+                "code": {
+                    "description": "编号",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "创建者",
+                    "type": "string"
+                },
+                "memo": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态(1:启用 2:停用)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.TenantSwitchParam": {
+            "type": "object",
+            "properties": {
+                "tenant_id": {
+                    "description": "租户ID(为空表示默认租户)",
+                    "type": "string"
+                }
+            }
+        },
         "schema.UpdatePasswordParam": {
             "type": "object",
             "required": [
@@ -2235,11 +5721,11 @@ var doc = `{
             ],
             "properties": {
                 "new_password": {
-                    "description": "新密码(md5加密)",
+                    "description": "新密码(明文)",
                     "type": "string"
                 },
                 "old_password": {
-                    "description": "旧密码(md5加密)",
+                    "description": "旧密码(明文)",
                     "type": "string"
                 }
             }
@@ -2265,14 +5751,34 @@ var doc = `{
                     "description": "邮箱",
                     "type": "string"
                 },
+                "mfa_enabled": {
+                    "description": "多因素认证状态(1:启用 2:未启用)",
+                    "type": "integer"
+                },
+                "must_change_password": {
+                    "description": "下次登录是否必须修改密码(1:是 2:否)",
+                    "type": "integer"
+                },
+                "org_id": {
+                    "description": "所属部门ID",
+                    "type": "string"
+                },
                 "password": {
-                    "description": "密码",
+                    "description": "密码(明文)",
+                    "type": "string"
+                },
+                "password_changed_at": {
+                    "description": "密码修改时间",
                     "type": "string"
                 },
                 "phone": {
                     "description": "手机号",
                     "type": "string"
                 },
+                "provider": {
+                    "description": "外部认证来源(ldap/oidc，为空表示本地用户)",
+                    "type": "string"
+                },
                 "real_name": {
                     "description": "真实姓名",
                     "type": "string"
@@ -2285,10 +5791,19 @@ var doc = `{
                     "description": "用户状态(1:启用 2:停用)",
                     "type": "integer"
                 },
+                "type": {
+                    "description": "用户类型(1:普通用户 2:服务账号)",
+                    "type": "integer"
+                },
                 "user_name": {
                     "description": "用户名",
                     "type": "string"
                 },
+                "user_orgs": {
+                    "description": "兼任部门",
+                    "type": "object",
+                    "$ref": "#/definitions/schema.UserOrgs"
+                },
                 "user_roles": {
                     "description": "角色授权",
                     "type": "object",
@@ -2299,15 +5814,23 @@ var doc = `{
         "schema.UserLoginInfo": {
             "type": "object",
             "properties": {
+                "actor_id": {
+                    "description": "模拟登录的操作者ID(不是模拟登录时为空)",
+                    "type": "string"
+                },
                 "real_name": {
                     "description": "真实姓名",
                     "type": "string"
                 },
                 "roles": {
-                    "description": "角色列表",
+                    "description": "角色列表(当前租户中分配的角色)",
                     "type": "object",
                     "$ref": "#/definitions/schema.Roles"
                 },
+                "tenant_id": {
+                    "description": "当前租户ID(为空时为默认租户)",
+                    "type": "string"
+                },
                 "user_id": {
                     "description": "用户ID",
                     "type": "string"
@@ -2318,6 +5841,33 @@ var doc = `{
                 }
             }
         },
+        "schema.UserOrg": {
+            "type": "object",
+            "properties": {
+                "org_id": {
+                    "description": "部门ID",
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "记录ID",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.UserOrgs": {
+            "type": "array",
+            "items": {
+                "$ref": "#/definitions/schema.UserOrg"
+            }
+        },
         "schema.UserRole": {
             "type": "object",
             "properties": {
@@ -2329,6 +5879,10 @@ var doc = `{
                     "description": "角色ID",
                     "type": "string"
                 },
+                "tenant_id": {
+                    "description": "租户ID",
+                    "type": "string"
+                },
                 "user_id": {
                     "description": "用户ID",
                     "type": "string"
@@ -2341,6 +5895,35 @@ var doc = `{
                 "$ref": "#/definitions/schema.UserRole"
             }
         },
+        "schema.UserSession": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "模拟登录的操作者ID(不是模拟登录会话时为空)",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "会话到期时间戳",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "会话ID",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "登录IP",
+                    "type": "string"
+                },
+                "issued_at": {
+                    "description": "登录时间戳",
+                    "type": "integer"
+                },
+                "user_agent": {
+                    "description": "用户代理",
+                    "type": "string"
+                }
+            }
+        },
         "schema.UserShow": {
             "type": "object",
             "properties": {
@@ -2352,10 +5935,22 @@ var doc = `{
                     "description": "邮箱",
                     "type": "string"
                 },
+                "mfa_enabled": {
+                    "description": "多因素认证状态(1:启用 2:未启用)",
+                    "type": "integer"
+                },
+                "org_id": {
+                    "description": "所属部门ID",
+                    "type": "string"
+                },
                 "phone": {
                     "description": "手机号",
                     "type": "string"
                 },
+                "provider": {
+                    "description": "外部认证来源(ldap/oidc，为空表示本地用户)",
+                    "type": "string"
+                },
                 "real_name": {
                     "description": "真实姓名",
                     "type": "string"
@@ -2375,6 +5970,10 @@ var doc = `{
                     "description": "用户状态(1:启用 2:停用)",
                     "type": "integer"
                 },
+                "type": {
+                    "description": "用户类型(1:普通用户 2:服务账号)",
+                    "type": "integer"
+                },
                 "user_name": {
                     "description": "用户名",
                     "type": "string"
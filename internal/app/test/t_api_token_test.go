@@ -0,0 +1,268 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func withToken(req *http.Request, token string) *http.Request {
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestAPIToken(t *testing.T) {
+	const router = apiPrefix + "v1/users"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: "/api/v1/menus/:id"},
+				},
+			},
+			&schema.MenuAction{
+				Code: "del",
+				Name: "删除",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "DELETE", Path: "/api/v1/menus/:id"},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// get /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	var queryAction, delAction *schema.MenuAction
+	for _, item := range menuItem.Actions {
+		switch item.Code {
+		case "query":
+			queryAction = item
+		case "del":
+			delAction = item
+		}
+	}
+	if !assert.NotNil(t, queryAction) || !assert.NotNil(t, delAction) {
+		return
+	}
+
+	// post /roles (only the query action)
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID:   addMenuItemRes.RecordID,
+				ActionID: queryAction.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users (service account without password)
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Type:     schema.UserTypeService,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// post /pub/login (service accounts can not log in)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/login", schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: "foo",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users/:id/tokens (action not granted to the user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "ci",
+		ActionIDs: []string{delAction.RecordID},
+	}, router, addUserItemRes.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users/:id/tokens
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "ci",
+		ActionIDs: []string{queryAction.RecordID},
+		ExpiresIn: 3600,
+	}, router, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.APITokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tokenInfo.Token)
+	assert.NotNil(t, tokenInfo.ExpiresAt)
+
+	// get /menus/:id (within the token scope)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 200, w.Code)
+
+	// get /menus.tree (within the token scope, but the route does not accept the partner view)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest(apiPrefix+"v1/menus.tree", nil), tokenInfo.Token))
+	assert.Equal(t, 401, w.Code)
+
+	// delete /menus/:id (outside the token scope)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newDeleteRequest("%s/%s", apiPrefix+"v1/menus", addMenuItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 401, w.Code)
+
+	// get /pub/current/tokens (tokens can not manage tokens)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest(apiPrefix+"v1/pub/current/tokens", nil), tokenInfo.Token))
+	assert.Equal(t, 401, w.Code)
+
+	// get /menus/:id (unknown token)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID), schema.APITokenPrefix+"foo"))
+	assert.Equal(t, 401, w.Code)
+
+	// get /users/:id/tokens
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s/tokens", nil, router, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var tokens schema.APITokens
+	err = parsePageReader(w.Body, &tokens)
+	assert.Nil(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, tokenInfo.RecordID, tokens[0].RecordID)
+		assert.Equal(t, tokenInfo.Prefix, tokens[0].Prefix)
+		assert.Equal(t, []string{queryAction.RecordID}, tokens[0].ActionIDs)
+		assert.NotNil(t, tokens[0].LastUsedAt)
+	}
+
+	// patch /users/:id/disable
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest("%s/%s/disable", router, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// get /menus/:id (owner disabled)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 401, w.Code)
+
+	// patch /users/:id/enable
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest("%s/%s/enable", router, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// post /users/:id/tokens (root user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "ci",
+		ActionIDs: []string{queryAction.RecordID},
+	}, router, config.C.Root.UserName))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users (human user)
+	addHumanItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Password: "test-1234",
+		Status:   1,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, addHumanItem))
+	assert.Equal(t, 200, w.Code)
+	var addHumanItemRes ResRecordID
+	err = parseReader(w.Body, &addHumanItemRes)
+	assert.Nil(t, err)
+
+	// post /users/:id/tokens (human user without the impersonation permission)
+	config.C.Impersonation.Enable = false
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "ci",
+		ActionIDs: []string{queryAction.RecordID},
+	}, router, addHumanItemRes.RecordID))
+	config.C.Impersonation.Enable = true
+	assert.Equal(t, 400, w.Code)
+
+	// post /users/:id/tokens (human user with the impersonation permission)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "ci",
+		ActionIDs: []string{queryAction.RecordID},
+	}, router, addHumanItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, addHumanItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /users/:id/tokens/:tid
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s/tokens/%s", router, addUserItemRes.RecordID, tokenInfo.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// get /menus/:id (revoked token)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 401, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/roles", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
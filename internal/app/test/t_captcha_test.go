@@ -0,0 +1,175 @@
+package test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LyricTian/captcha"
+	"github.com/LyricTian/captcha/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestLoginCaptcha(t *testing.T) {
+	const router = apiPrefix + "v1/pub/login"
+	var err error
+
+	oldCaptcha := config.C.Captcha
+	defer func() { config.C.Captcha = oldCaptcha }()
+	config.C.Captcha.Enable = true
+	config.C.Captcha.FailureThreshold = 2
+	config.C.Captcha.FailureExpired = 60
+
+	captchaStore := store.NewMemoryStore(time.Minute, captcha.Expiration)
+	captcha.SetCustomStore(captchaStore)
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	password := "test-1234"
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Password: password,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// post /pub/login (wrong password, reach failure threshold even with forged X-Forwarded-For)
+	for i := 0; i < config.C.Captcha.FailureThreshold; i++ {
+		w = httptest.NewRecorder()
+		req := newPostRequest(router, schema.LoginParam{
+			UserName: addUserItem.UserName,
+			Password: "foo",
+		})
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i+1))
+		engine.ServeHTTP(w, req)
+		assert.Equal(t, 400, w.Code)
+	}
+
+	// post /pub/login (captcha required)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+	}))
+	assert.Equal(t, 400, w.Code)
+	var errResult schema.ErrorResult
+	err = parseReader(w.Body, &errResult)
+	assert.Nil(t, err)
+	assert.Equal(t, 9998, errResult.Error.Code)
+
+	// get /pub/login/captchaid
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/captchaid", nil))
+	assert.Equal(t, 200, w.Code)
+	var captchaItem schema.LoginCaptcha
+	err = parseReader(w.Body, &captchaItem)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, captchaItem.CaptchaID)
+
+	// get /pub/login/captcha
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/captcha", map[string]string{"id": captchaItem.CaptchaID}))
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+
+	digits := captchaStore.Get(captchaItem.CaptchaID, false)
+	code := make([]byte, len(digits))
+	for i, d := range digits {
+		code[i] = '0' + d
+	}
+
+	// post /pub/login (wrong captcha)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName:    addUserItem.UserName,
+		Password:    password,
+		CaptchaID:   captchaItem.CaptchaID,
+		CaptchaCode: "x",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// get /pub/login/captcha (reload unknown id)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/captcha", map[string]string{"id": util.MustUUID(), "reload": "1"}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /pub/login (valid captcha)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName:    addUserItem.UserName,
+		Password:    password,
+		CaptchaID:   captchaItem.CaptchaID,
+		CaptchaCode: string(code),
+	}))
+	assert.Equal(t, 200, w.Code)
+
+	// post /pub/login (failure count reset)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+	}))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /roles/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /menus/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
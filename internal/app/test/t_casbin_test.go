@@ -0,0 +1,417 @@
+package test
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/wangwei518/gin-admin/pkg/util"
+	fileWatcher "github.com/wangwei518/gin-admin/pkg/watcher/file"
+)
+
+func TestCasbinPolicy(t *testing.T) {
+	var err error
+
+	// 只同步策略，不启用权限校验
+	config.C.Casbin.Enable = true
+	defer func() { config.C.Casbin.Enable = false }()
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: "/api/v1/casbin-test"},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// get /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	if !assert.Len(t, menuItem.Actions, 1) {
+		return
+	}
+	roleMenus := schema.RoleMenus{
+		&schema.RoleMenu{
+			MenuID:   addMenuItemRes.RecordID,
+			ActionID: menuItem.Actions[0].RecordID,
+		},
+	}
+
+	// post /roles (two roles sharing the same resource)
+	var roleIDs []string
+	for i := 0; i < 2; i++ {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", &schema.Role{
+			Name:      util.MustUUID(),
+			Status:    1,
+			RoleMenus: roleMenus,
+		}))
+		assert.Equal(t, 200, w.Code)
+		var addRoleItemRes ResRecordID
+		err = parseReader(w.Body, &addRoleItemRes)
+		assert.Nil(t, err)
+		roleIDs = append(roleIDs, addRoleItemRes.RecordID)
+	}
+	for _, roleID := range roleIDs {
+		assert.True(t, enforcer.HasPolicy(roleID, "", "/api/v1/casbin-test", "GET", "*", schema.ResourceEffectAllow, ""))
+	}
+
+	// post /users
+	addItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Password: "test-1234",
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{RoleID: roleIDs[0]},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addItem))
+	assert.Equal(t, 200, w.Code)
+	var addItemRes ResRecordID
+	err = parseReader(w.Body, &addItemRes)
+	assert.Nil(t, err)
+	assert.True(t, enforcer.HasGroupingPolicy(addItemRes.RecordID, roleIDs[0], ""))
+
+	// put /users/:id (switch role)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/users", addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var putItem schema.User
+	err = parseReader(w.Body, &putItem)
+	assert.Nil(t, err)
+	putItem.UserRoles = schema.UserRoles{
+		&schema.UserRole{RoleID: roleIDs[1]},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", putItem, apiPrefix+"v1/users", addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	assert.False(t, enforcer.HasGroupingPolicy(addItemRes.RecordID, roleIDs[0], ""))
+	assert.True(t, enforcer.HasGroupingPolicy(addItemRes.RecordID, roleIDs[1], ""))
+
+	// patch /users/:id/disable
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest(apiPrefix+"v1/users/%s/disable", addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	assert.Empty(t, enforcer.GetFilteredGroupingPolicy(0, addItemRes.RecordID))
+
+	// patch /users/:id/enable
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest(apiPrefix+"v1/users/%s/enable", addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	assert.True(t, enforcer.HasGroupingPolicy(addItemRes.RecordID, roleIDs[1], ""))
+
+	// patch /roles/:id/disable
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest(apiPrefix+"v1/roles/%s/disable", roleIDs[0]))
+	assert.Equal(t, 200, w.Code)
+	assert.Empty(t, enforcer.GetFilteredPolicy(0, roleIDs[0]))
+	assert.True(t, enforcer.HasPolicy(roleIDs[1], "", "/api/v1/casbin-test", "GET", "*", schema.ResourceEffectAllow, ""))
+
+	// put /menus/:id (resources changed, reload in background)
+	menuItem.Actions[0].Resources[0].View = schema.ViewAdmin
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", menuItem, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	assert.Eventually(t, func() bool {
+		return enforcer.HasPolicy(roleIDs[1], "", "/api/v1/casbin-test", "GET", schema.ViewAdmin, schema.ResourceEffectAllow, "")
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.False(t, enforcer.HasPolicy(roleIDs[1], "", "/api/v1/casbin-test", "GET", "*", schema.ResourceEffectAllow, ""))
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	assert.Empty(t, enforcer.GetFilteredGroupingPolicy(0, addItemRes.RecordID))
+
+	// delete /roles/:id
+	for _, roleID := range roleIDs {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", roleID))
+		assert.Equal(t, 200, w.Code)
+		assert.Empty(t, enforcer.GetFilteredPolicy(0, roleID))
+	}
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
+
+func TestCasbinWatcher(t *testing.T) {
+	var err error
+
+	config.C.Casbin.Enable = true
+	defer func() { config.C.Casbin.Enable = false }()
+
+	dir, err := ioutil.TempDir("", "casbin")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// 使用文件通知模拟同一主机上的两个实例
+	path := filepath.Join(dir, "casbin_watcher.log")
+	local, err := fileWatcher.NewWatcher(path, 10*time.Millisecond)
+	assert.Nil(t, err)
+	defer local.Close()
+	peer, err := fileWatcher.NewWatcher(path, 10*time.Millisecond)
+	assert.Nil(t, err)
+	defer peer.Close()
+
+	casbinPolicy.Watcher = local
+	defer func() { casbinPolicy.Watcher = nil }()
+	err = casbinPolicy.Watch()
+	assert.Nil(t, err)
+
+	var lock sync.Mutex
+	var payloads []string
+	peer.SetUpdateCallback(func(payload string) {
+		lock.Lock()
+		payloads = append(payloads, payload)
+		lock.Unlock()
+	})
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: "/api/v1/casbin-watcher"},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	if !assert.Len(t, menuItem.Actions, 1) {
+		return
+	}
+
+	// post /roles (peer is notified with the changed role)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID:   addMenuItemRes.RecordID,
+				ActionID: menuItem.Actions[0].RecordID,
+			},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(payloads) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"roles:" + addRoleItemRes.RecordID}, payloads)
+
+	// peer changed the role, local policy is synchronized incrementally
+	_, err = enforcer.RemovePolicy(addRoleItemRes.RecordID, "", "/api/v1/casbin-watcher", "GET", "*", schema.ResourceEffectAllow, "")
+	assert.Nil(t, err)
+	err = peer.Publish("roles:" + addRoleItemRes.RecordID)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return enforcer.HasPolicy(addRoleItemRes.RecordID, "", "/api/v1/casbin-watcher", "GET", "*", schema.ResourceEffectAllow, "")
+	}, time.Second, 10*time.Millisecond)
+
+	// peer requested a full reload
+	_, err = enforcer.RemovePolicy(addRoleItemRes.RecordID, "", "/api/v1/casbin-watcher", "GET", "*", schema.ResourceEffectAllow, "")
+	assert.Nil(t, err)
+	err = peer.Update()
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return enforcer.HasPolicy(addRoleItemRes.RecordID, "", "/api/v1/casbin-watcher", "GET", "*", schema.ResourceEffectAllow, "")
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
+
+func TestCasbinDeny(t *testing.T) {
+	var err error
+
+	config.C.Casbin.Enable = true
+	defer func() { config.C.Casbin.Enable = false }()
+
+	resPath := "/api/v1/casbin-deny-" + util.MustUUID()
+
+	// post /menus (deny rule with request attributes)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: resPath + "/secret", Effect: schema.ResourceEffectDeny, Attributes: "header.X-Env=^prod$"},
+				},
+			},
+		},
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /menus (deny rule and conditional rule)
+	w = httptest.NewRecorder()
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: resPath + "/:id"},
+					&schema.MenuActionResource{Method: "GET", Path: resPath + "/secret", Effect: schema.ResourceEffectDeny},
+					&schema.MenuActionResource{Method: "POST", Path: resPath, IPRanges: "10.0.0.0/8", Attributes: "header.X-Env=^prod$"},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	if !assert.Len(t, menuItem.Actions, 1) {
+		return
+	}
+
+	// post /roles
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID:   addMenuItemRes.RecordID,
+				ActionID: menuItem.Actions[0].RecordID,
+			},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Password: "test-1234",
+		Status:   1,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{RoleID: addRoleItemRes.RecordID},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	enforcer.EnableEnforce(true)
+	defer enforcer.EnableEnforce(false)
+
+	enforce := func(method, path string, env *abac.Env) bool {
+		ok, err := enforcer.Enforce(addUserItemRes.RecordID, "", path, method, schema.ViewAdmin, env)
+		assert.Nil(t, err)
+		return ok
+	}
+	prod := map[string]string{"header.X-Env": "prod"}
+
+	// 拒绝优先于允许
+	assert.True(t, enforce("GET", resPath+"/abc", abac.NewEnv("", time.Now(), nil)))
+	assert.False(t, enforce("GET", resPath+"/secret", abac.NewEnv("", time.Now(), nil)))
+
+	// 访问条件
+	assert.True(t, enforce("POST", resPath, abac.NewEnv("10.1.2.3", time.Now(), prod)))
+	assert.False(t, enforce("POST", resPath, abac.NewEnv("192.168.1.1", time.Now(), prod)))
+	assert.False(t, enforce("POST", resPath, abac.NewEnv("10.1.2.3", time.Now(), nil)))
+
+	// 超级管理员
+	ok, err := enforcer.Enforce(config.C.Root.UserName, "", resPath+"/secret", "GET", schema.ViewAdmin, abac.NewEnv("", time.Now(), nil))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
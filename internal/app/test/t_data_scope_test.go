@@ -0,0 +1,283 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestDataScope(t *testing.T) {
+	const router = apiPrefix + "v1/demos"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: "/api/v1/demos"},
+					&schema.MenuActionResource{Method: "GET", Path: "/api/v1/demos/:id"},
+					&schema.MenuActionResource{Method: "GET", Path: "/api/v1/users"},
+				},
+			},
+			&schema.MenuAction{
+				Code: "add",
+				Name: "新增",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "POST", Path: "/api/v1/demos"},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// get /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	var actionIDs []string
+	roleMenus := make(schema.RoleMenus, 0, len(menuItem.Actions))
+	for _, item := range menuItem.Actions {
+		actionIDs = append(actionIDs, item.RecordID)
+		roleMenus = append(roleMenus, &schema.RoleMenu{
+			MenuID:   addMenuItemRes.RecordID,
+			ActionID: item.RecordID,
+		})
+	}
+
+	// post /roles (custom data scope without departments)
+	addRoleItem := &schema.Role{
+		Name:      util.MustUUID(),
+		Status:    1,
+		DataScope: schema.DataScopeCustom,
+		RoleMenus: roleMenus,
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 400, w.Code)
+
+	// post /roles (own records)
+	addRoleItem.DataScope = schema.DataScopeOwn
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /orgs
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/orgs", &schema.Org{
+		Name:   util.MustUUID(),
+		Status: 1,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addOrgItemRes ResRecordID
+	err = parseReader(w.Body, &addOrgItemRes)
+	assert.Nil(t, err)
+	orgID := addOrgItemRes.RecordID
+
+	// post /users
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Type:     schema.UserTypeService,
+		OrgID:    orgID,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// post /users/:id/tokens
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "data-scope",
+		ActionIDs: actionIDs,
+	}, apiPrefix+"v1/users", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.APITokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+
+	// post /demos (created by root)
+	prefix := util.MustUUID()
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, &schema.Demo{
+		Code:   prefix + "-root",
+		Name:   util.MustUUID(),
+		Status: 1,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var rootItemRes ResRecordID
+	err = parseReader(w.Body, &rootItemRes)
+	assert.Nil(t, err)
+
+	// post /demos (created by the user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newPostRequest(router, &schema.Demo{
+		Code:   prefix + "-user",
+		Name:   util.MustUUID(),
+		Status: 1,
+	}), tokenInfo.Token))
+	assert.Equal(t, 200, w.Code)
+	var userItemRes ResRecordID
+	err = parseReader(w.Body, &userItemRes)
+	assert.Nil(t, err)
+
+	// get /demos (own records only)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest(router, newPageParam(map[string]string{
+		"queryValue": prefix,
+		"pageSize":   "10",
+	})), tokenInfo.Token))
+	assert.Equal(t, 200, w.Code)
+	var demos []*schema.Demo
+	err = parsePageReader(w.Body, &demos)
+	assert.Nil(t, err)
+	if assert.Len(t, demos, 1) {
+		assert.Equal(t, userItemRes.RecordID, demos[0].RecordID)
+	}
+
+	// get /demos/:id (created by root)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, router, rootItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 404, w.Code)
+
+	// get /demos (root is not restricted)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router, newPageParam(map[string]string{
+		"queryValue": prefix,
+		"pageSize":   "10",
+	})))
+	assert.Equal(t, 200, w.Code)
+	demos = nil
+	err = parsePageReader(w.Body, &demos)
+	assert.Nil(t, err)
+	assert.Len(t, demos, 2)
+
+	// post /users (another user of the same department)
+	orgUserItem := &schema.User{
+		UserName: prefix + "-org",
+		RealName: util.MustUUID(),
+		Status:   1,
+		Type:     schema.UserTypeService,
+		OrgID:    orgID,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", orgUserItem))
+	assert.Equal(t, 200, w.Code)
+
+	// get /users (own records only)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest(apiPrefix+"v1/users", newPageParam(map[string]string{
+		"queryValue": prefix,
+		"pageSize":   "10",
+	})), tokenInfo.Token))
+	assert.Equal(t, 200, w.Code)
+	var users []*schema.UserShow
+	err = parsePageReader(w.Body, &users)
+	assert.Nil(t, err)
+	assert.Len(t, users, 0)
+
+	// put /roles/:id (own department)
+	addRoleItem.DataScope = schema.DataScopeOrg
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", addRoleItem, apiPrefix+"v1/roles", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// get /users (users of the same department)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest(apiPrefix+"v1/users", newPageParam(map[string]string{
+		"queryValue": prefix,
+		"pageSize":   "10",
+	})), tokenInfo.Token))
+	assert.Equal(t, 200, w.Code)
+	users = nil
+	err = parsePageReader(w.Body, &users)
+	assert.Nil(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, orgUserItem.UserName, users[0].UserName)
+	}
+
+	// put /roles/:id (all records)
+	addRoleItem.DataScope = schema.DataScopeAll
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", addRoleItem, apiPrefix+"v1/roles", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// get /demos/:id (created by root)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, router, rootItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 200, w.Code)
+
+	// post /roles (own records)
+	ownRoleItem := &schema.Role{
+		Name:      util.MustUUID(),
+		Status:    1,
+		DataScope: schema.DataScopeOwn,
+		RoleMenus: roleMenus,
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", ownRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var ownRoleItemRes ResRecordID
+	err = parseReader(w.Body, &ownRoleItemRes)
+	assert.Nil(t, err)
+
+	// put /users/:id (only has the role of own records)
+	addUserItem.UserRoles = schema.UserRoles{
+		&schema.UserRole{
+			RoleID: ownRoleItemRes.RecordID,
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", addUserItem, apiPrefix+"v1/users", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// get /demos/:id (created by root, own records only)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, router, rootItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 404, w.Code)
+
+	// put /roles/:id (inherit the role with all records)
+	ownRoleItem.ParentID = addRoleItemRes.RecordID
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", ownRoleItem, apiPrefix+"v1/roles", ownRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// get /demos/:id (created by root, all records of the parent role)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, withToken(newGetRequest("%s/%s", nil, router, rootItemRes.RecordID), tokenInfo.Token))
+	assert.Equal(t, 200, w.Code)
+}
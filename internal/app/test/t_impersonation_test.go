@@ -0,0 +1,222 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestImpersonation(t *testing.T) {
+	const router = apiPrefix + "v1/users"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	addItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Password: "test-1234",
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, addItem))
+	assert.Equal(t, 200, w.Code)
+	var addItemRes ResRecordID
+	err = parseReader(w.Body, &addItemRes)
+	assert.Nil(t, err)
+
+	// post /users/:id/impersonate (missing reason)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/impersonate", schema.ImpersonateParam{}, router, addItemRes.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users/:id/impersonate (unknown view)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/impersonate", schema.ImpersonateParam{
+		View:   "foo",
+		Reason: "test",
+	}, router, addItemRes.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users/:id/impersonate (root user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/impersonate", schema.ImpersonateParam{
+		Reason: "test",
+	}, router, config.C.Root.UserName))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users/:id/impersonate
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/impersonate", schema.ImpersonateParam{
+		ExpiresIn: 60,
+		Reason:    "test",
+	}, router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tokenInfo.AccessToken)
+	assert.Empty(t, tokenInfo.RefreshToken)
+
+	var claims jwtauth.CustomClaims
+	_, _, err = new(jwt.Parser).ParseUnverified(tokenInfo.AccessToken, &claims)
+	assert.Nil(t, err)
+	assert.Equal(t, addItemRes.RecordID, claims.Subject)
+	assert.Equal(t, config.C.JWTAuth.DefaultView, claims.View)
+	if assert.NotNil(t, claims.Actor) {
+		assert.Equal(t, config.C.Root.UserName, claims.Actor.Subject)
+	}
+	assert.True(t, claims.ExpiresAt-claims.IssuedAt <= 60)
+
+	// get /users/:id/sessions
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s/sessions", nil, router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var sessions schema.UserSessions
+	err = parsePageReader(w.Body, &sessions)
+	assert.Nil(t, err)
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, config.C.Root.UserName, sessions[0].ActorID)
+	}
+
+	// post /tenants
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/tenants", &schema.Tenant{
+		Code:   util.MustUUID(),
+		Name:   util.MustUUID(),
+		Status: 1,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addTenantItemRes ResRecordID
+	err = parseReader(w.Body, &addTenantItemRes)
+	assert.Nil(t, err)
+
+	// post /roles (in the other tenant)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", &schema.Role{
+		TenantID: addTenantItemRes.RecordID,
+		Name:     util.MustUUID(),
+		Status:   1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addTenantRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addTenantRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users (only has roles in the other tenant)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Password: "test-1234",
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addTenantRoleItemRes.RecordID,
+			},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addOtherItemRes ResRecordID
+	err = parseReader(w.Body, &addOtherItemRes)
+	assert.Nil(t, err)
+
+	// post /users/:id/impersonate (not a member of the current tenant)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/impersonate", schema.ImpersonateParam{
+		Reason: "test",
+	}, router, addOtherItemRes.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users/:id/tokens (not a member of the current tenant)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "ci",
+		ActionIDs: []string{util.MustUUID()},
+	}, router, addOtherItemRes.RecordID))
+	assert.Equal(t, 404, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, addOtherItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addTenantRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /tenants/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/tenants/%s", addTenantItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /pub/current/impersonation (not impersonating)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/pub/current/impersonation"))
+	assert.Equal(t, 400, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /roles/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /menus/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
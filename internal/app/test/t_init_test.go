@@ -9,8 +9,11 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/wangwei518/gin-admin/internal/app/bll/impl/bll"
 	"github.com/wangwei518/gin-admin/internal/app/config"
 	"github.com/wangwei518/gin-admin/internal/app/initialize"
+	"github.com/wangwei518/gin-admin/pkg/oidc/oidctest"
+	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,7 +23,12 @@ const (
 	apiPrefix  = "/api/"
 )
 
-var engine *gin.Engine
+var (
+	engine       *gin.Engine
+	enforcer     *casbin.SyncedEnforcer
+	casbinPolicy *bll.CasbinPolicy
+	idp          *oidctest.Server
+)
 
 func init() {
 	// 初始化配置文件
@@ -29,10 +37,20 @@ func init() {
 	config.C.RunMode = "test"
 	config.C.Log.Level = 2
 	config.C.JWTAuth.Enable = false
+	config.C.JWTAuth.Store = "file"
 	config.C.Casbin.Enable = false
 	config.C.Casbin.Model = modelFile
 	config.C.Gorm.Debug = false
 	config.C.Gorm.DBType = "sqlite3"
+	config.C.LoginLock.DelayStep = 1
+	config.C.Mail.Sender = "file"
+
+	// 使用测试身份提供方
+	idp = oidctest.NewServer("gin-admin")
+	config.C.OIDC.Enable = true
+	config.C.OIDC.Issuer = idp.Issuer()
+	config.C.OIDC.ClientID = idp.ClientID
+	config.C.OIDC.RedirectURL = "http://localhost/api/v1/pub/login/oidc/callback"
 
 	initialize.InitLogger()
 	injector, _, err := initialize.BuildInjector()
@@ -40,6 +58,8 @@ func init() {
 		panic(err)
 	}
 	engine = injector.Engine
+	enforcer = injector.CasbinEnforcer
+	casbinPolicy = injector.CasbinPolicy
 }
 
 // ResRecordID 响应记录ID
@@ -117,6 +137,11 @@ func newDeleteRequest(formatRouter string, args ...interface{}) *http.Request {
 	return req
 }
 
+func newPatchRequest(formatRouter string, args ...interface{}) *http.Request {
+	req, _ := http.NewRequest("PATCH", fmt.Sprintf(formatRouter, args...), nil)
+	return req
+}
+
 func newGetRequest(formatRouter string, params map[string]string, args ...interface{}) *http.Request {
 	values := make(url.Values)
 	for k, v := range params {
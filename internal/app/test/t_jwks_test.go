@@ -0,0 +1,21 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKS(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	// get /.well-known/jwks.json
+	engine.ServeHTTP(w, newGetRequest("/.well-known/jwks.json", nil))
+	assert.Equal(t, 200, w.Code)
+	var jwks jwtauth.JWKSet
+	err := parseReader(w.Body, &jwks)
+	assert.Nil(t, err)
+	assert.NotNil(t, jwks.Keys)
+}
@@ -0,0 +1,276 @@
+package test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestLoginLock(t *testing.T) {
+	const router = apiPrefix + "v1/pub/login"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	password := "test-1234"
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Password: password,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// post /pub/login (wrong password until locked)
+	threshold := config.C.LoginLock.UserThreshold
+	for i := 1; i <= threshold; i++ {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+			UserName: addUserItem.UserName,
+			Password: "foo",
+		}))
+		if i < threshold {
+			assert.Equal(t, 400, w.Code)
+		} else {
+			assert.Equal(t, 429, w.Code)
+		}
+	}
+
+	// post /pub/login (locked)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+	}))
+	assert.Equal(t, 429, w.Code)
+	var errResult schema.ErrorResult
+	err = parseReader(w.Body, &errResult)
+	assert.Nil(t, err)
+	assert.Equal(t, 9997, errResult.Error.Code)
+
+	// get /users.locks
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users.locks", nil))
+	assert.Equal(t, 200, w.Code)
+	var locks schema.LoginLocks
+	err = parsePageReader(w.Body, &locks)
+	assert.Nil(t, err)
+	var found bool
+	for _, item := range locks {
+		if item.Type == "user" && item.Name == addUserItem.UserName {
+			found = true
+			assert.NotZero(t, item.ExpiresAt)
+		}
+	}
+	assert.True(t, found)
+
+	// delete /users.locks/:type/:name (invalid type)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users.locks/%s/%s", "foo", addUserItem.UserName))
+	assert.Equal(t, 400, w.Code)
+
+	// delete /users.locks/:type/:name
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users.locks/%s/%s", "user", addUserItem.UserName))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// post /pub/login (unlocked)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+	}))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /roles/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /menus/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
+
+func TestLoginLockForwardedFor(t *testing.T) {
+	const router = apiPrefix + "v1/pub/login"
+	var err error
+
+	// 客户端的连接地址
+	const remoteIP = "192.0.2.1"
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users (spread failures so that no user reaches its own threshold)
+	userThreshold := config.C.LoginLock.UserThreshold
+	threshold := config.C.LoginLock.IPThreshold
+	var userNames, userIDs []string
+	for i := 0; i < (threshold+userThreshold-2)/(userThreshold-1); i++ {
+		addUserItem := &schema.User{
+			UserName: util.MustUUID(),
+			RealName: util.MustUUID(),
+			Status:   1,
+			Password: "test-1234",
+			UserRoles: schema.UserRoles{
+				&schema.UserRole{
+					RoleID: addRoleItemRes.RecordID,
+				},
+			},
+		}
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+		assert.Equal(t, 200, w.Code)
+		var addUserItemRes ResRecordID
+		err = parseReader(w.Body, &addUserItemRes)
+		assert.Nil(t, err)
+		userNames = append(userNames, addUserItem.UserName)
+		userIDs = append(userIDs, addUserItemRes.RecordID)
+	}
+
+	// delete /users.locks/:type/:name (clear failures from other tests)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users.locks/%s/%s", "ip", remoteIP))
+	assert.Equal(t, 200, w.Code)
+
+	// post /pub/login (forged X-Forwarded-For does not reset the IP failure count)
+	for i := 1; i <= threshold; i++ {
+		w = httptest.NewRecorder()
+		req := newPostRequest(router, schema.LoginParam{
+			UserName: userNames[(i-1)%len(userNames)],
+			Password: "foo",
+		})
+		req.RemoteAddr = remoteIP + ":1234"
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i))
+		req.Header.Set("X-Real-Ip", fmt.Sprintf("198.51.100.%d", i))
+		engine.ServeHTTP(w, req)
+		if i < threshold {
+			assert.Equal(t, 400, w.Code)
+		} else {
+			assert.Equal(t, 429, w.Code)
+		}
+	}
+
+	// get /users.locks
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users.locks", nil))
+	assert.Equal(t, 200, w.Code)
+	var locks schema.LoginLocks
+	err = parsePageReader(w.Body, &locks)
+	assert.Nil(t, err)
+	var found bool
+	for _, item := range locks {
+		if item.Type == "ip" {
+			assert.Equal(t, remoteIP, item.Name)
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	// delete /users.locks/:type/:name
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users.locks/%s/%s", "ip", remoteIP))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /users/:id
+	for _, userID := range userIDs {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", userID))
+		assert.Equal(t, 200, w.Code)
+	}
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
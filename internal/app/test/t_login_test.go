@@ -0,0 +1,224 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestLogin(t *testing.T) {
+	const router = apiPrefix + "v1/pub/login"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	password := "test-1234"
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Password: password,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// post /pub/login (local user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tokenInfo.AccessToken)
+	assert.NotEmpty(t, tokenInfo.RefreshToken)
+
+	// post /pub/refresh-token
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/refresh-token", schema.RefreshTokenParam{
+		RefreshToken: tokenInfo.RefreshToken,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var refreshTokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &refreshTokenInfo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, refreshTokenInfo.AccessToken)
+	assert.NotEqual(t, tokenInfo.RefreshToken, refreshTokenInfo.RefreshToken)
+
+	// post /pub/refresh-token (reused)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/refresh-token", schema.RefreshTokenParam{
+		RefreshToken: tokenInfo.RefreshToken,
+	}))
+	assert.Equal(t, 401, w.Code)
+
+	// post /pub/refresh-token (family revoked)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/refresh-token", schema.RefreshTokenParam{
+		RefreshToken: refreshTokenInfo.RefreshToken,
+	}))
+	assert.Equal(t, 401, w.Code)
+
+	// post /pub/login (two sessions)
+	for i := 0; i < 2; i++ {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+			UserName: addUserItem.UserName,
+			Password: password,
+		}))
+		assert.Equal(t, 200, w.Code)
+	}
+
+	// get /users/:id/sessions
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users/%s/sessions", nil, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var sessions schema.UserSessions
+	err = parsePageReader(w.Body, &sessions)
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 2)
+
+	// get /users/:id/sessions (out of scope)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users/%s/sessions", nil, config.C.Root.UserName))
+	assert.Equal(t, 404, w.Code)
+
+	// delete /users/:id/sessions (out of scope)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s/sessions", util.MustUUID()))
+	assert.Equal(t, 404, w.Code)
+
+	// delete /users/:id/sessions/:sid
+	if len(sessions) > 0 {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s/sessions/%s", addUserItemRes.RecordID, sessions[0].ID))
+		assert.Equal(t, 200, w.Code)
+		err = parseOK(w.Body)
+		assert.Nil(t, err)
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users/%s/sessions", nil, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	sessions = nil
+	err = parsePageReader(w.Body, &sessions)
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 1)
+
+	// patch /users/:id/disable (revoke all sessions)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest(apiPrefix+"v1/users/%s/disable", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users/%s/sessions", nil, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	sessions = nil
+	err = parsePageReader(w.Body, &sessions)
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 0)
+
+	// patch /users/:id/enable
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest(apiPrefix+"v1/users/%s/enable", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// post /pub/login (token issued for the mobile view)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+		View:     schema.ViewMobile,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var mobileTokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &mobileTokenInfo)
+	assert.Nil(t, err)
+	var claims jwtauth.CustomClaims
+	_, _, err = new(jwt.Parser).ParseUnverified(mobileTokenInfo.AccessToken, &claims)
+	assert.Nil(t, err)
+	assert.Equal(t, schema.ViewMobile, claims.View)
+
+	// post /pub/login (unknown view)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+		View:     "foo",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /pub/login (wrong password)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: "foo",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /roles/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /menus/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
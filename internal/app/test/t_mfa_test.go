@@ -0,0 +1,228 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/totp"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestLoginMFA(t *testing.T) {
+	const router = apiPrefix + "v1/pub/login"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles (require mfa)
+	addRoleItem := &schema.Role{
+		Name:       util.MustUUID(),
+		Status:     1,
+		RequireMFA: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	password := "test-1234"
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Password: password,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	loginParam := schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: password,
+	}
+
+	// post /pub/login (enroll required)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, loginParam))
+	assert.Equal(t, 200, w.Code)
+	var challenge schema.LoginMFAChallenge
+	err = parseReader(w.Body, &challenge)
+	assert.Nil(t, err)
+	assert.True(t, challenge.MFARequired)
+	assert.True(t, challenge.EnrollRequired)
+	assert.NotEmpty(t, challenge.MFAToken)
+
+	// post /pub/login/mfa/enroll
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/mfa/enroll", schema.LoginMFAEnrollParam{
+		MFAToken: challenge.MFAToken,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var enrollInfo schema.MFAEnrollInfo
+	err = parseReader(w.Body, &enrollInfo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, enrollInfo.Secret)
+	assert.Contains(t, enrollInfo.URI, "otpauth://totp/")
+
+	// post /pub/login/mfa (activate)
+	code, err := totp.GenerateCode(enrollInfo.Secret, time.Now())
+	assert.Nil(t, err)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/mfa", schema.LoginMFAParam{
+		MFAToken: challenge.MFAToken,
+		Code:     code,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tokenInfo.AccessToken)
+	assert.NotEmpty(t, tokenInfo.RecoveryCodes)
+
+	// post /pub/login/mfa (challenge reused)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/mfa", schema.LoginMFAParam{
+		MFAToken: challenge.MFAToken,
+		Code:     code,
+	}))
+	assert.Equal(t, 401, w.Code)
+
+	// post /pub/login (mfa enabled)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, loginParam))
+	assert.Equal(t, 200, w.Code)
+	challenge = schema.LoginMFAChallenge{}
+	err = parseReader(w.Body, &challenge)
+	assert.Nil(t, err)
+	assert.True(t, challenge.MFARequired)
+	assert.False(t, challenge.EnrollRequired)
+
+	// post /pub/login/mfa (code reused)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/mfa", schema.LoginMFAParam{
+		MFAToken: challenge.MFAToken,
+		Code:     code,
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /pub/login/mfa (wrong code until locked, password login does not reset failures)
+	threshold := config.C.LoginLock.UserThreshold
+	for i := 2; i <= threshold; i++ {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(router, loginParam))
+		assert.Equal(t, 200, w.Code)
+		challenge = schema.LoginMFAChallenge{}
+		err = parseReader(w.Body, &challenge)
+		assert.Nil(t, err)
+
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(router+"/mfa", schema.LoginMFAParam{
+			MFAToken: challenge.MFAToken,
+			Code:     "000000x",
+		}))
+		if i < threshold {
+			assert.Equal(t, 400, w.Code)
+		} else {
+			assert.Equal(t, 429, w.Code)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, loginParam))
+	assert.Equal(t, 429, w.Code)
+
+	// delete /users.locks/:type/:name
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users.locks/%s/%s", "user", loginParam.UserName))
+	assert.Equal(t, 200, w.Code)
+
+	// post /pub/login/mfa (recovery code)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, loginParam))
+	assert.Equal(t, 200, w.Code)
+	challenge = schema.LoginMFAChallenge{}
+	err = parseReader(w.Body, &challenge)
+	assert.Nil(t, err)
+
+	if len(tokenInfo.RecoveryCodes) > 0 {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(router+"/mfa", schema.LoginMFAParam{
+			MFAToken: challenge.MFAToken,
+			Code:     tokenInfo.RecoveryCodes[0],
+		}))
+		assert.Equal(t, 200, w.Code)
+		var recoveryTokenInfo schema.LoginTokenInfo
+		err = parseReader(w.Body, &recoveryTokenInfo)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, recoveryTokenInfo.AccessToken)
+		assert.Empty(t, recoveryTokenInfo.RecoveryCodes)
+	}
+
+	// delete /users/:id/mfa
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s/mfa", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, loginParam))
+	assert.Equal(t, 200, w.Code)
+	challenge = schema.LoginMFAChallenge{}
+	err = parseReader(w.Body, &challenge)
+	assert.Nil(t, err)
+	assert.True(t, challenge.EnrollRequired)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
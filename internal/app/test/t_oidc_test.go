@@ -0,0 +1,239 @@
+package test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestLoginOIDC(t *testing.T) {
+	const router = apiPrefix + "v1/pub/login/oidc"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	group := util.MustUUID()
+	config.C.OIDC.GroupRoles = []config.OIDCGroupRole{
+		{Group: group, RoleName: addRoleItem.Name},
+	}
+	defer func() { config.C.OIDC.GroupRoles = nil }()
+
+	config.C.OIDC.Claims.UserName = "preferred_username"
+	defer func() { config.C.OIDC.Claims.UserName = "sub" }()
+
+	// 使用身份提供方当前的声明完成一次OIDC登录
+	oidcLogin := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, newGetRequest(router, nil))
+		assert.Equal(t, 200, w.Code)
+		var authorize schema.LoginOIDCAuthorize
+		err := parseReader(w.Body, &authorize)
+		assert.Nil(t, err)
+
+		code, state, err := idp.Authorize(authorize.AuthURL)
+		assert.Nil(t, err)
+		assert.Equal(t, authorize.State, state)
+
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newGetRequest(router+"/callback", map[string]string{
+			"code":  code,
+			"state": state,
+		}))
+		return w
+	}
+
+	userName := util.MustUUID()
+	subject := util.MustUUID()
+	idp.SetClaims(map[string]interface{}{
+		"sub":                subject,
+		"preferred_username": userName,
+		"name":               "OIDC User",
+		"email":              "oidc@example.com",
+		"groups":             []string{group},
+	})
+
+	// get /pub/login/oidc
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router, nil))
+	assert.Equal(t, 200, w.Code)
+	var authorize schema.LoginOIDCAuthorize
+	err = parseReader(w.Body, &authorize)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, authorize.State)
+
+	code, state, err := idp.Authorize(authorize.AuthURL)
+	assert.Nil(t, err)
+	assert.Equal(t, authorize.State, state)
+
+	// get /pub/login/oidc/callback
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/callback", map[string]string{
+		"code":  code,
+		"state": state,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tokenInfo.AccessToken)
+
+	// get /pub/login/oidc/callback (state can only be used once)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/callback", map[string]string{
+		"code":  code,
+		"state": state,
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// get /pub/login/oidc/callback (same subject, username claim changed)
+	idp.SetClaims(map[string]interface{}{
+		"sub":                subject,
+		"preferred_username": util.MustUUID(),
+		"name":               "OIDC User",
+		"email":              "oidc@example.com",
+		"groups":             []string{group},
+	})
+	w = oidcLogin()
+	assert.Equal(t, 200, w.Code)
+
+	// get /pub/login/oidc/callback (another subject claims the same username)
+	idp.SetClaims(map[string]interface{}{
+		"sub":                util.MustUUID(),
+		"preferred_username": userName,
+	})
+	w = oidcLogin()
+	assert.Equal(t, 400, w.Code)
+
+	// post /users (local user)
+	localUserName := util.MustUUID()
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", &schema.User{
+		UserName: localUserName,
+		RealName: util.MustUUID(),
+		Password: "test-1234",
+		Status:   1,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{RoleID: addRoleItemRes.RecordID},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// get /pub/login/oidc/callback (cannot link to a local user)
+	idp.SetClaims(map[string]interface{}{
+		"sub":                util.MustUUID(),
+		"preferred_username": localUserName,
+	})
+	w = oidcLogin()
+	assert.Equal(t, 400, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/users", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// get /users?userName=
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users", newPageParam(map[string]string{
+		"userName": userName,
+	})))
+	assert.Equal(t, 200, w.Code)
+	var users schema.Users
+	err = parsePageReader(w.Body, &users)
+	assert.Nil(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, "oidc", users[0].Provider)
+		assert.Equal(t, "OIDC User", users[0].RealName)
+		assert.Equal(t, "oidc@example.com", users[0].Email)
+
+		// get /users/:id
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/users", users[0].RecordID))
+		assert.Equal(t, 200, w.Code)
+		var user schema.User
+		err = parseReader(w.Body, &user)
+		assert.Nil(t, err)
+		if assert.Len(t, user.UserRoles, 1) {
+			assert.Equal(t, addRoleItemRes.RecordID, user.UserRoles[0].RoleID)
+		}
+
+		// delete /users/:id
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/users", users[0].RecordID))
+		assert.Equal(t, 200, w.Code)
+	}
+
+	// get /pub/login/oidc?view= (unknown view)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router, map[string]string{"view": "foo"}))
+	assert.Equal(t, 400, w.Code)
+
+	// get /pub/login/oidc?redirect=1
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router, map[string]string{"redirect": "1"}))
+	assert.Equal(t, 302, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	assert.Nil(t, err)
+	state = location.Query().Get("state")
+
+	// get /pub/login/oidc/callback (denied by provider)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/callback", map[string]string{
+		"state": state,
+		"error": "access_denied",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// get /pub/login/oidc/callback (unknown state)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/callback", map[string]string{
+		"code":  "foo",
+		"state": "bar",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/roles", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
@@ -0,0 +1,179 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestOrg(t *testing.T) {
+	const router = apiPrefix + "v1/orgs"
+	var err error
+
+	createOrg := func(parentID string) string {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(router, &schema.Org{
+			Name:     util.MustUUID(),
+			ParentID: parentID,
+			Status:   1,
+		}))
+		assert.Equal(t, 200, w.Code)
+		var res ResRecordID
+		err := parseReader(w.Body, &res)
+		assert.Nil(t, err)
+		return res.RecordID
+	}
+
+	// post /orgs (root -> child -> grandchild, other)
+	rootID := createOrg("")
+	childID := createOrg(rootID)
+	grandchildID := createOrg(childID)
+	otherID := createOrg("")
+
+	// post /orgs (invalid parent)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, &schema.Org{
+		Name:     util.MustUUID(),
+		ParentID: util.MustUUID(),
+		Status:   1,
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// get /orgs/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, router, grandchildID))
+	assert.Equal(t, 200, w.Code)
+	var getItem schema.Org
+	err = parseReader(w.Body, &getItem)
+	assert.Nil(t, err)
+	assert.Equal(t, rootID+"/"+childID, getItem.ParentPath)
+
+	// put /orgs/:id/move (move to its own descendant)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s/move", schema.OrgMoveParam{ParentID: grandchildID}, router, rootID))
+	assert.Equal(t, 400, w.Code)
+
+	// put /orgs/:id/move
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s/move", schema.OrgMoveParam{ParentID: otherID}, router, childID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// get /orgs/:id (the path of the descendant is updated)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, router, grandchildID))
+	assert.Equal(t, 200, w.Code)
+	getItem = schema.Org{}
+	err = parseReader(w.Body, &getItem)
+	assert.Nil(t, err)
+	assert.Equal(t, otherID+"/"+childID, getItem.ParentPath)
+
+	// get /orgs.tree
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/orgs.tree", nil))
+	assert.Equal(t, 200, w.Code)
+	var trees schema.OrgTrees
+	err = parsePageReader(w.Body, &trees)
+	assert.Nil(t, err)
+	var otherTree *schema.OrgTree
+	for _, item := range trees {
+		if item.RecordID == otherID {
+			otherTree = item
+		}
+	}
+	if assert.NotNil(t, otherTree) && assert.NotNil(t, otherTree.Children) && assert.Len(t, *otherTree.Children, 1) {
+		childTree := (*otherTree.Children)[0]
+		assert.Equal(t, childID, childTree.RecordID)
+		if assert.NotNil(t, childTree.Children) && assert.Len(t, *childTree.Children, 1) {
+			assert.Equal(t, grandchildID, (*childTree.Children)[0].RecordID)
+		}
+	}
+
+	// delete /orgs/:id (with children)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, childID))
+	assert.Equal(t, 400, w.Code)
+
+	// post /roles
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{MenuID: util.MustUUID()},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users (secondary department)
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Type:     schema.UserTypeService,
+		OrgID:    rootID,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{RoleID: addRoleItemRes.RecordID},
+		},
+		UserOrgs: schema.UserOrgs{
+			&schema.UserOrg{OrgID: grandchildID},
+			&schema.UserOrg{OrgID: rootID},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// get /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/users", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var getUserItem schema.User
+	err = parseReader(w.Body, &getUserItem)
+	assert.Nil(t, err)
+	assert.Equal(t, rootID, getUserItem.OrgID)
+	assert.Equal(t, []string{grandchildID}, getUserItem.UserOrgs.ToOrgIDs())
+
+	// get /users (by secondary department)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users", newPageParam(map[string]string{
+		"orgID":    grandchildID,
+		"pageSize": "10",
+	})))
+	assert.Equal(t, 200, w.Code)
+	var users []*schema.UserShow
+	err = parsePageReader(w.Body, &users)
+	assert.Nil(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, addUserItemRes.RecordID, users[0].RecordID)
+	}
+
+	// delete /orgs/:id (with users)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, grandchildID))
+	assert.Equal(t, 400, w.Code)
+
+	// put /users/:id (drop the secondary department)
+	getUserItem.UserOrgs = nil
+	getUserItem.Password = ""
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", getUserItem, apiPrefix+"v1/users", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /orgs/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, grandchildID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
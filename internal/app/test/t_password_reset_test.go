@@ -0,0 +1,192 @@
+package test
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/mail"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+var resetTokenRegexp = regexp.MustCompile(`token=([A-Za-z0-9_-]+)`)
+
+// 从文件发送器写入的邮件中查找发送给指定邮箱的最后一个重置令牌
+func lastResetToken(t *testing.T, email string) string {
+	msgs, err := mail.ReadFile(config.C.Mail.FilePath)
+	assert.Nil(t, err)
+
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if len(msgs[i].To) > 0 && msgs[i].To[0] == email {
+			if m := resetTokenRegexp.FindStringSubmatch(msgs[i].Body); len(m) == 2 {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+func TestPasswordReset(t *testing.T) {
+	const router = apiPrefix + "v1/pub/password"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Email:    util.MustUUID() + "@example.com",
+		Status:   1,
+		Password: "test-1234",
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: addRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// post /pub/login
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/login", schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: addUserItem.Password,
+	}))
+	assert.Equal(t, 200, w.Code)
+
+	// post /pub/password/forgot (unknown user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/forgot", schema.ForgotPasswordParam{
+		UserName: util.MustUUID(),
+	}))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// post /pub/password/forgot
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/forgot", schema.ForgotPasswordParam{
+		UserName: addUserItem.UserName,
+	}))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+	token := lastResetToken(t, addUserItem.Email)
+	assert.NotEmpty(t, token)
+
+	// post /pub/password/reset (invalid token)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/reset", schema.ResetPasswordParam{
+		Token:       util.MustUUID(),
+		NewPassword: "test-5678",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /pub/password/reset (password rejected by policy)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/reset", schema.ResetPasswordParam{
+		Token:       token,
+		NewPassword: "abc",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /pub/password/reset
+	newPassword := "test-5678"
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/reset", schema.ResetPasswordParam{
+		Token:       token,
+		NewPassword: newPassword,
+	}))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// post /pub/password/reset (token reused)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/reset", schema.ResetPasswordParam{
+		Token:       token,
+		NewPassword: "test-9012",
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// get /users/:id/sessions
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/users/%s/sessions", nil, addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var sessions schema.UserSessions
+	err = parsePageReader(w.Body, &sessions)
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 0)
+
+	// post /pub/login (old password)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/login", schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: addUserItem.Password,
+	}))
+	assert.NotEqual(t, 200, w.Code)
+
+	// post /pub/login (new password)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/login", schema.LoginParam{
+		UserName: addUserItem.UserName,
+		Password: newPassword,
+	}))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /roles/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /menus/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,162 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	const router = apiPrefix + "v1/users"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /roles
+	addRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID: addMenuItemRes.RecordID,
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", addRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	newUser := func(password string) *schema.User {
+		return &schema.User{
+			UserName: util.MustUUID(),
+			RealName: util.MustUUID(),
+			Status:   1,
+			Password: password,
+			UserRoles: schema.UserRoles{
+				&schema.UserRole{
+					RoleID: addRoleItemRes.RecordID,
+				},
+			},
+		}
+	}
+
+	// post /users (too short)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, newUser("abc")))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users (banned word)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, newUser("MyPassword2020")))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users (digest of a short or empty password)
+	for _, item := range []string{util.MD5HashString("test"), util.MD5HashString("")} {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(router, newUser(item)))
+		assert.Equal(t, 400, w.Code)
+	}
+
+	// post /users (must change password on next login)
+	password := "test-1234"
+	addItem := newUser(password)
+	addItem.MustChangePassword = 1
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, addItem))
+	assert.Equal(t, 200, w.Code)
+	var addItemRes ResRecordID
+	err = parseReader(w.Body, &addItemRes)
+	assert.Nil(t, err)
+
+	// post /pub/login (restricted token)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/login", schema.LoginParam{
+		UserName: addItem.UserName,
+		Password: password,
+	}))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+	assert.True(t, tokenInfo.PasswordChangeRequired)
+	var claims jwtauth.CustomClaims
+	_, _, err = new(jwt.Parser).ParseUnverified(tokenInfo.AccessToken, &claims)
+	assert.Nil(t, err)
+	assert.Equal(t, schema.ViewPasswordChange, claims.View)
+
+	// get /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var getItem schema.User
+	err = parseReader(w.Body, &getItem)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, getItem.MustChangePassword)
+	assert.NotNil(t, getItem.PasswordChangedAt)
+
+	// put /users/:id (new password)
+	putItem := getItem
+	putItem.Password = "test-5678"
+	putItem.MustChangePassword = 2
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", putItem, router, getItem.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// put /users/:id (reused password)
+	putItem.Password = password
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", putItem, router, getItem.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// post /pub/login (unrestricted token)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/login", schema.LoginParam{
+		UserName: addItem.UserName,
+		Password: "test-5678",
+	}))
+	assert.Equal(t, 200, w.Code)
+	tokenInfo = schema.LoginTokenInfo{}
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+	assert.False(t, tokenInfo.PasswordChangeRequired)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /roles/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// delete /menus/:id
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+}
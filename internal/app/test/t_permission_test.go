@@ -0,0 +1,605 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestPermission(t *testing.T) {
+	const router = apiPrefix + "v1/permissions"
+	var err error
+
+	config.C.Casbin.Enable = true
+	defer func() { config.C.Casbin.Enable = false }()
+
+	// unique resource path, so data left by other runs does not match
+	resPath := "/api/v1/permission-" + util.MustUUID()
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: resPath},
+					&schema.MenuActionResource{Method: "GET", Path: resPath + "/:id"},
+				},
+			},
+			&schema.MenuAction{
+				Code: "add",
+				Name: "新增",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "POST", Path: resPath},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// get /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	mActions := make(map[string]*schema.MenuAction)
+	for _, item := range menuItem.Actions {
+		mActions[item.Code] = item
+	}
+	if !assert.Len(t, mActions, 2) {
+		return
+	}
+
+	// post /roles (parent role with the query action)
+	parentRoleItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID:   addMenuItemRes.RecordID,
+				ActionID: mActions["query"].RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", parentRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var parentRoleItemRes ResRecordID
+	err = parseReader(w.Body, &parentRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /roles (child role without own menus)
+	childRoleItem := &schema.Role{
+		Name:     util.MustUUID(),
+		Status:   1,
+		ParentID: parentRoleItemRes.RecordID,
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", childRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var childRoleItemRes ResRecordID
+	err = parseReader(w.Body, &childRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Password: "test-1234",
+		Status:   1,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{
+				RoleID: childRoleItemRes.RecordID,
+			},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	enforcer.EnableEnforce(true)
+	defer enforcer.EnableEnforce(false)
+
+	// get /permissions/explain (allowed by the inherited role)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/explain", map[string]string{
+		"userID": addUserItemRes.RecordID,
+		"method": "GET",
+		"path":   resPath + "/abc",
+	}))
+	assert.Equal(t, 200, w.Code)
+	var explain schema.PermissionExplain
+	err = parseReader(w.Body, &explain)
+	assert.Nil(t, err)
+	assert.True(t, explain.Allowed)
+	if assert.Len(t, explain.Matches, 1) {
+		match := explain.Matches[0]
+		assert.Equal(t, childRoleItemRes.RecordID, match.UserRoleID)
+		assert.Equal(t, parentRoleItemRes.RecordID, match.RoleID)
+		assert.Equal(t, mActions["query"].RecordID, match.ActionID)
+		assert.Equal(t, resPath+"/:id", match.Path)
+	}
+
+	// get /permissions/explain (denied with candidates)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/explain", map[string]string{
+		"userID": addUserItemRes.RecordID,
+		"method": "POST",
+		"path":   resPath,
+	}))
+	assert.Equal(t, 200, w.Code)
+	explain = schema.PermissionExplain{}
+	err = parseReader(w.Body, &explain)
+	assert.Nil(t, err)
+	assert.False(t, explain.Allowed)
+	assert.Len(t, explain.Matches, 0)
+	if assert.NotEmpty(t, explain.Candidates) {
+		assert.Equal(t, mActions["add"].RecordID, explain.Candidates[0].ActionID)
+		assert.NotEmpty(t, explain.Candidates[0].Hint)
+	}
+
+	// get /permissions/explain (unknown user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router+"/explain", map[string]string{
+		"userID": util.MustUUID(),
+		"method": "GET",
+		"path":   resPath,
+	}))
+	assert.Equal(t, 404, w.Code)
+
+	// post /permissions/check
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/check", schema.PermissionCheckParam{
+		UserID: addUserItemRes.RecordID,
+		Items: schema.PermissionCheckItems{
+			&schema.PermissionCheckItem{Method: "GET", Path: resPath},
+			&schema.PermissionCheckItem{Method: "POST", Path: resPath},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var checkItems schema.PermissionCheckItems
+	err = parsePageReader(w.Body, &checkItems)
+	assert.Nil(t, err)
+	if assert.Len(t, checkItems, 2) {
+		assert.True(t, checkItems[0].Allowed)
+		assert.False(t, checkItems[1].Allowed)
+	}
+
+	// get /permissions/explain (consistent with the enforcer)
+	for _, item := range []struct{ method, path string }{
+		{"GET", resPath},
+		{"GET", resPath + "/abc"},
+		{"POST", resPath},
+		{"DELETE", resPath + "/abc"},
+		{"GET", resPath + "/abc/def"},
+	} {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newGetRequest(router+"/explain", map[string]string{
+			"userID": addUserItemRes.RecordID,
+			"method": item.method,
+			"path":   item.path,
+		}))
+		assert.Equal(t, 200, w.Code)
+		explain = schema.PermissionExplain{}
+		err = parseReader(w.Body, &explain)
+		assert.Nil(t, err)
+
+		ok, err := enforcer.Enforce(addUserItemRes.RecordID, "", item.path, item.method, schema.ViewAdmin, abac.NewEnv("", time.Now(), nil))
+		assert.Nil(t, err)
+		assert.Equal(t, ok, explain.Allowed, item.method+" "+item.path)
+	}
+
+	// post /pub/current/permissions/check (root user)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/pub/current/permissions/check", schema.PermissionCheckParam{
+		Items: schema.PermissionCheckItems{
+			&schema.PermissionCheckItem{Method: "POST", Path: resPath},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	checkItems = nil
+	err = parsePageReader(w.Body, &checkItems)
+	assert.Nil(t, err)
+	if assert.Len(t, checkItems, 1) {
+		assert.True(t, checkItems[0].Allowed)
+	}
+}
+
+func TestPermissionDryRun(t *testing.T) {
+	var err error
+
+	resPath := "/api/v1/permission-" + util.MustUUID()
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: resPath},
+				},
+			},
+			&schema.MenuAction{
+				Code: "add",
+				Name: "新增",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "POST", Path: resPath},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// get /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	mActions := make(map[string]*schema.MenuAction)
+	for _, item := range menuItem.Actions {
+		mActions[item.Code] = item
+	}
+	if !assert.Len(t, mActions, 2) {
+		return
+	}
+	queryRoleMenus := schema.RoleMenus{
+		&schema.RoleMenu{MenuID: addMenuItemRes.RecordID, ActionID: mActions["query"].RecordID},
+	}
+	addRoleMenus := schema.RoleMenus{
+		&schema.RoleMenu{MenuID: addMenuItemRes.RecordID, ActionID: mActions["add"].RecordID},
+	}
+
+	// post /roles (parent role with the query action)
+	parentRoleItem := &schema.Role{
+		Name:      util.MustUUID(),
+		Status:    1,
+		RoleMenus: queryRoleMenus,
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", parentRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var parentRoleItemRes ResRecordID
+	err = parseReader(w.Body, &parentRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /roles (child role with the add action)
+	childRoleItem := &schema.Role{
+		Name:      util.MustUUID(),
+		Status:    1,
+		ParentID:  parentRoleItemRes.RecordID,
+		RoleMenus: addRoleMenus,
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", childRoleItem))
+	assert.Equal(t, 200, w.Code)
+	var childRoleItemRes ResRecordID
+	err = parseReader(w.Body, &childRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users (with the child role)
+	childUserItem := &schema.User{
+		UserName:  "a-" + util.MustUUID(),
+		RealName:  util.MustUUID(),
+		Password:  "test-1234",
+		Status:    1,
+		UserRoles: schema.UserRoles{&schema.UserRole{RoleID: childRoleItemRes.RecordID}},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", childUserItem))
+	assert.Equal(t, 200, w.Code)
+	var childUserItemRes ResRecordID
+	err = parseReader(w.Body, &childUserItemRes)
+	assert.Nil(t, err)
+
+	// post /users (with the parent role)
+	parentUserItem := &schema.User{
+		UserName:  "b-" + util.MustUUID(),
+		RealName:  util.MustUUID(),
+		Password:  "test-1234",
+		Status:    1,
+		UserRoles: schema.UserRoles{&schema.UserRole{RoleID: parentRoleItemRes.RecordID}},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", parentUserItem))
+	assert.Equal(t, 200, w.Code)
+	var parentUserItemRes ResRecordID
+	err = parseReader(w.Body, &parentUserItemRes)
+	assert.Nil(t, err)
+
+	// put /roles/:id/dry-run (replace query with add on the parent role)
+	parentRoleItem.RoleMenus = addRoleMenus
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s/dry-run", parentRoleItem, apiPrefix+"v1/roles", parentRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var diff schema.PermissionDiff
+	err = parseReader(w.Body, &diff)
+	assert.Nil(t, err)
+	if assert.Len(t, diff.Users, 2) {
+		childDiff, parentDiff := diff.Users[0], diff.Users[1]
+		assert.Equal(t, childUserItemRes.RecordID, childDiff.UserID)
+		assert.Len(t, childDiff.GainedActions, 0)
+		if assert.Len(t, childDiff.LostActions, 1) {
+			assert.Equal(t, mActions["query"].RecordID, childDiff.LostActions[0].ActionID)
+		}
+		if assert.Len(t, childDiff.LostResources, 1) {
+			assert.Equal(t, "GET", childDiff.LostResources[0].Method)
+		}
+		assert.Len(t, childDiff.LostMenus, 0)
+
+		assert.Equal(t, parentUserItemRes.RecordID, parentDiff.UserID)
+		if assert.Len(t, parentDiff.GainedActions, 1) {
+			assert.Equal(t, mActions["add"].RecordID, parentDiff.GainedActions[0].ActionID)
+		}
+		assert.Len(t, parentDiff.LostActions, 1)
+	}
+	if assert.Len(t, diff.Actions, 2) {
+		addDiff, queryDiff := diff.Actions[0], diff.Actions[1]
+		assert.Equal(t, mActions["add"].RecordID, addDiff.ActionID)
+		assert.Len(t, addDiff.GainedUsers, 1)
+		assert.Equal(t, mActions["query"].RecordID, queryDiff.ActionID)
+		assert.Len(t, queryDiff.LostUsers, 2)
+	}
+
+	// get /roles/:id (nothing saved)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/roles", parentRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var getRoleItem schema.Role
+	err = parseReader(w.Body, &getRoleItem)
+	assert.Nil(t, err)
+	if assert.Len(t, getRoleItem.RoleMenus, 1) {
+		assert.Equal(t, mActions["query"].RecordID, getRoleItem.RoleMenus[0].ActionID)
+	}
+
+	// put /roles/:id/dry-run (invalid parent)
+	parentRoleItem.ParentID = parentRoleItemRes.RecordID
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s/dry-run", parentRoleItem, apiPrefix+"v1/roles", parentRoleItemRes.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// put /users/:id/dry-run (switch to the child role)
+	parentUserItem.UserRoles = schema.UserRoles{&schema.UserRole{RoleID: childRoleItemRes.RecordID}}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s/dry-run", parentUserItem, apiPrefix+"v1/users", parentUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	diff = schema.PermissionDiff{}
+	err = parseReader(w.Body, &diff)
+	assert.Nil(t, err)
+	if assert.Len(t, diff.Users, 1) {
+		assert.Len(t, diff.Users[0].GainedActions, 1)
+		assert.Len(t, diff.Users[0].LostActions, 0)
+		if assert.Len(t, diff.Users[0].GainedResources, 1) {
+			assert.Equal(t, "POST", diff.Users[0].GainedResources[0].Method)
+		}
+	}
+
+	// put /users/:id/dry-run (disable the user)
+	parentUserItem.Status = 2
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s/dry-run", parentUserItem, apiPrefix+"v1/users", parentUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	diff = schema.PermissionDiff{}
+	err = parseReader(w.Body, &diff)
+	assert.Nil(t, err)
+	if assert.Len(t, diff.Users, 1) {
+		assert.Len(t, diff.Users[0].LostActions, 1)
+		if assert.Len(t, diff.Users[0].LostMenus, 1) {
+			assert.Equal(t, addMenuItemRes.RecordID, diff.Users[0].LostMenus[0].MenuID)
+		}
+	}
+}
+
+func TestPermissionCondition(t *testing.T) {
+	const router = apiPrefix + "v1/permissions"
+	var err error
+
+	config.C.Casbin.Enable = true
+	defer func() { config.C.Casbin.Enable = false }()
+
+	resPath := "/api/v1/permission-" + util.MustUUID()
+
+	w := httptest.NewRecorder()
+
+	// post /menus (deny rule and conditional rule)
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: resPath + "/:id"},
+					&schema.MenuActionResource{Method: "GET", Path: resPath + "/secret", Effect: schema.ResourceEffectDeny},
+					&schema.MenuActionResource{Method: "POST", Path: resPath, IPRanges: "10.0.0.0/8", Attributes: "header.X-Env=^prod$"},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// post /menus (invalid condition)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: resPath, TimeWindow: "09:00"},
+				},
+			},
+		},
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	if !assert.Len(t, menuItem.Actions, 1) || !assert.Len(t, menuItem.Actions[0].Resources, 3) {
+		return
+	}
+
+	// post /roles
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{
+				MenuID:   addMenuItemRes.RecordID,
+				ActionID: menuItem.Actions[0].RecordID,
+			},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addRoleItemRes ResRecordID
+	err = parseReader(w.Body, &addRoleItemRes)
+	assert.Nil(t, err)
+
+	// post /users
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Password: "test-1234",
+		Status:   1,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{RoleID: addRoleItemRes.RecordID},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	enforcer.EnableEnforce(true)
+	defer enforcer.EnableEnforce(false)
+
+	explain := func(params map[string]string) *schema.PermissionExplain {
+		params["userID"] = addUserItemRes.RecordID
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, newGetRequest(router+"/explain", params))
+		assert.Equal(t, 200, w.Code)
+		var result schema.PermissionExplain
+		err := parseReader(w.Body, &result)
+		assert.Nil(t, err)
+		return &result
+	}
+
+	// get /permissions/explain (denied by the deny rule)
+	result := explain(map[string]string{"method": "GET", "path": resPath + "/secret"})
+	assert.False(t, result.Allowed)
+	if assert.Len(t, result.Matches, 1) {
+		assert.Equal(t, schema.ResourceEffectDeny, result.Matches[0].Effect)
+		assert.Equal(t, resPath+"/secret", result.Matches[0].Path)
+	}
+
+	result = explain(map[string]string{"method": "GET", "path": resPath + "/abc"})
+	assert.True(t, result.Allowed)
+
+	// get /permissions/explain (conditions)
+	result = explain(map[string]string{
+		"method":              "POST",
+		"path":                resPath,
+		"ip":                  "10.1.2.3",
+		"attrs[header.X-Env]": "prod",
+	})
+	assert.True(t, result.Allowed)
+	if assert.Len(t, result.Matches, 1) {
+		assert.Equal(t, "10.0.0.0/8", result.Matches[0].IPRanges)
+	}
+
+	result = explain(map[string]string{
+		"method":              "POST",
+		"path":                resPath,
+		"ip":                  "192.168.1.1",
+		"attrs[header.X-Env]": "prod",
+	})
+	assert.False(t, result.Allowed)
+	if assert.NotEmpty(t, result.Candidates) {
+		assert.Equal(t, resPath, result.Candidates[0].Path)
+		assert.Contains(t, result.Candidates[0].Hint, "访问条件不满足")
+	}
+
+	// post /permissions/check
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router+"/check", schema.PermissionCheckParam{
+		UserID:     addUserItemRes.RecordID,
+		IP:         "10.1.2.3",
+		Attributes: map[string]string{"header.x-env": "prod"},
+		Items: schema.PermissionCheckItems{
+			&schema.PermissionCheckItem{Method: "GET", Path: resPath + "/abc"},
+			&schema.PermissionCheckItem{Method: "GET", Path: resPath + "/secret"},
+			&schema.PermissionCheckItem{Method: "POST", Path: resPath},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var checkItems schema.PermissionCheckItems
+	err = parsePageReader(w.Body, &checkItems)
+	assert.Nil(t, err)
+	if assert.Len(t, checkItems, 3) {
+		assert.True(t, checkItems[0].Allowed)
+		assert.False(t, checkItems[1].Allowed)
+		assert.True(t, checkItems[2].Allowed)
+	}
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /roles/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", addRoleItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
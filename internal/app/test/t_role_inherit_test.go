@@ -0,0 +1,204 @@
+package test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestRoleInherit(t *testing.T) {
+	const router = apiPrefix + "v1/roles"
+	var err error
+
+	config.C.Casbin.Enable = true
+	defer func() { config.C.Casbin.Enable = false }()
+
+	w := httptest.NewRecorder()
+
+	// post /menus
+	addMenuItem := &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "add",
+				Name: "新增",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "POST", Path: "/api/v1/role-inherit-test"},
+				},
+			},
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: "/api/v1/role-inherit-test"},
+				},
+			},
+		},
+	}
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", addMenuItem))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	// get /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	if !assert.Len(t, menuItem.Actions, 2) {
+		return
+	}
+	addAction, queryAction := menuItem.Actions[0], menuItem.Actions[1]
+
+	// post /roles (parent role)
+	parentItem := &schema.Role{
+		Name:   util.MustUUID(),
+		Status: 1,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{MenuID: addMenuItemRes.RecordID, ActionID: queryAction.RecordID},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, parentItem))
+	assert.Equal(t, 200, w.Code)
+	var parentItemRes ResRecordID
+	err = parseReader(w.Body, &parentItemRes)
+	assert.Nil(t, err)
+
+	// post /roles (invalid parent)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, &schema.Role{
+		Name:     util.MustUUID(),
+		Status:   1,
+		ParentID: util.MustUUID(),
+	}))
+	assert.Equal(t, 400, w.Code)
+
+	// post /roles (child role)
+	childItem := &schema.Role{
+		Name:     util.MustUUID(),
+		Status:   1,
+		ParentID: parentItemRes.RecordID,
+		RoleMenus: schema.RoleMenus{
+			&schema.RoleMenu{MenuID: addMenuItemRes.RecordID, ActionID: addAction.RecordID},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, childItem))
+	assert.Equal(t, 200, w.Code)
+	var childItemRes ResRecordID
+	err = parseReader(w.Body, &childItemRes)
+	assert.Nil(t, err)
+	assert.True(t, enforcer.HasGroupingPolicy(childItemRes.RecordID, parentItemRes.RecordID, ""))
+
+	// get /roles/:id (direct and effective permissions)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, router, childItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var getItem schema.Role
+	err = parseReader(w.Body, &getItem)
+	assert.Nil(t, err)
+	assert.Len(t, getItem.RoleMenus, 1)
+	if assert.Len(t, getItem.EffectiveRoleMenus, 2) {
+		mEffective := getItem.EffectiveRoleMenus.ToMap()
+		inherited := mEffective[addMenuItemRes.RecordID+"-"+queryAction.RecordID]
+		if assert.NotNil(t, inherited) {
+			assert.Equal(t, parentItemRes.RecordID, inherited.RoleID)
+		}
+	}
+
+	// put /roles/:id (cycle)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, router, parentItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var putParentItem schema.Role
+	err = parseReader(w.Body, &putParentItem)
+	assert.Nil(t, err)
+	putParentItem.ParentID = childItemRes.RecordID
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", putParentItem, router, parentItemRes.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// post /users
+	addUserItem := &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Status:   1,
+		Type:     schema.UserTypeService,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{RoleID: childItemRes.RecordID},
+		},
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", addUserItem))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+
+	// 测试环境未启用权限校验，通过用户的隐式权限检查继承关系
+	hasInheritedPermission := func() bool {
+		permissions, err := enforcer.GetImplicitPermissionsForUser(addUserItemRes.RecordID, "")
+		assert.Nil(t, err)
+		for _, p := range permissions {
+			if p[2] == "/api/v1/role-inherit-test" && p[3] == "GET" {
+				return true
+			}
+		}
+		return false
+	}
+	assert.True(t, hasInheritedPermission())
+
+	// post /users/:id/tokens (inherited action)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest("%s/%s/tokens", schema.APITokenCreateParam{
+		Name:      "role-inherit",
+		ActionIDs: []string{queryAction.RecordID},
+	}, apiPrefix+"v1/users", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// patch /roles/:id/disable (parent role)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest(router+"/%s/disable", parentItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	assert.False(t, enforcer.HasGroupingPolicy(childItemRes.RecordID, parentItemRes.RecordID, ""))
+	assert.False(t, hasInheritedPermission())
+
+	// patch /roles/:id/enable (parent role)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest(router+"/%s/enable", parentItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	assert.True(t, enforcer.HasGroupingPolicy(childItemRes.RecordID, parentItemRes.RecordID, ""))
+
+	// delete /roles/:id (with child roles)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, parentItemRes.RecordID))
+	assert.Equal(t, 400, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/users", addUserItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// delete /roles/:id
+	for _, roleID := range []string{childItemRes.RecordID, parentItemRes.RecordID} {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, roleID))
+		assert.Equal(t, 200, w.Code)
+	}
+	assert.Empty(t, enforcer.GetFilteredGroupingPolicy(0, childItemRes.RecordID))
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
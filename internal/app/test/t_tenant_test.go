@@ -0,0 +1,337 @@
+package test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/internal/app/config"
+	icontext "github.com/wangwei518/gin-admin/internal/app/context"
+	"github.com/wangwei518/gin-admin/internal/app/initialize"
+	"github.com/wangwei518/gin-admin/internal/app/model/impl/gorm/model"
+	"github.com/wangwei518/gin-admin/internal/app/schema"
+	"github.com/wangwei518/gin-admin/pkg/abac"
+	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func TestTenant(t *testing.T) {
+	const router = apiPrefix + "v1/tenants"
+	var err error
+
+	w := httptest.NewRecorder()
+
+	// post /tenants
+	addItem := &schema.Tenant{
+		Code:   util.MustUUID(),
+		Name:   util.MustUUID(),
+		Status: 1,
+	}
+	engine.ServeHTTP(w, newPostRequest(router, addItem))
+	assert.Equal(t, 200, w.Code)
+	var addItemRes ResRecordID
+	err = parseReader(w.Body, &addItemRes)
+	assert.Nil(t, err)
+
+	// post /tenants (duplicate code)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(router, addItem))
+	assert.Equal(t, 400, w.Code)
+
+	// get /tenants/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var getItem schema.Tenant
+	err = parseReader(w.Body, &getItem)
+	assert.Nil(t, err)
+	assert.Equal(t, addItem.Code, getItem.Code)
+	assert.Equal(t, addItem.Name, getItem.Name)
+	assert.Equal(t, config.C.Root.UserName, getItem.Creator)
+
+	// put /tenants/:id
+	putItem := getItem
+	putItem.Name = util.MustUUID()
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest("%s/%s", putItem, router, getItem.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// query /tenants
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(router, newPageParam(map[string]string{"queryValue": putItem.Name})))
+	assert.Equal(t, 200, w.Code)
+	var pageItems []*schema.Tenant
+	err = parsePageReader(w.Body, &pageItems)
+	assert.Nil(t, err)
+	if assert.Len(t, pageItems, 1) {
+		assert.Equal(t, putItem.RecordID, pageItems[0].RecordID)
+		assert.Equal(t, putItem.Name, pageItems[0].Name)
+	}
+
+	// get /pub/current/tenants
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest(apiPrefix+"v1/pub/current/tenants", nil))
+	assert.Equal(t, 200, w.Code)
+	var tenants schema.Tenants
+	err = parsePageReader(w.Body, &tenants)
+	assert.Nil(t, err)
+	assert.Contains(t, tenants.ToMap(), addItemRes.RecordID)
+
+	// put /pub/current/tenant
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest(apiPrefix+"v1/pub/current/tenant", schema.TenantSwitchParam{TenantID: addItemRes.RecordID}))
+	assert.Equal(t, 200, w.Code)
+	var tokenInfo schema.LoginTokenInfo
+	err = parseReader(w.Body, &tokenInfo)
+	assert.Nil(t, err)
+
+	var claims jwtauth.CustomClaims
+	_, _, err = new(jwt.Parser).ParseUnverified(tokenInfo.AccessToken, &claims)
+	assert.Nil(t, err)
+	assert.Equal(t, config.C.Root.UserName, claims.Subject)
+	assert.Equal(t, addItemRes.RecordID, claims.Tenant)
+
+	// put /pub/current/tenant (not found)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest(apiPrefix+"v1/pub/current/tenant", schema.TenantSwitchParam{TenantID: util.MustUUID()}))
+	assert.Equal(t, 400, w.Code)
+
+	// patch /tenants/:id/disable
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPatchRequest("%s/%s/disable", router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+
+	// put /pub/current/tenant (disabled)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPutRequest(apiPrefix+"v1/pub/current/tenant", schema.TenantSwitchParam{TenantID: addItemRes.RecordID}))
+	assert.Equal(t, 400, w.Code)
+
+	// delete /tenants/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest("%s/%s", router, addItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	err = parseOK(w.Body)
+	assert.Nil(t, err)
+
+	// get /tenants/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, router, addItemRes.RecordID))
+	assert.Equal(t, 404, w.Code)
+}
+
+func TestTenantCasbin(t *testing.T) {
+	var err error
+
+	config.C.Casbin.Enable = true
+	defer func() { config.C.Casbin.Enable = false }()
+
+	resPath := "/api/v1/tenant-test-" + util.MustUUID()
+
+	w := httptest.NewRecorder()
+
+	// post /tenants
+	var tenantIDs []string
+	for i := 0; i < 2; i++ {
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/tenants", &schema.Tenant{
+			Code:   util.MustUUID(),
+			Name:   util.MustUUID(),
+			Status: 1,
+		}))
+		assert.Equal(t, 200, w.Code)
+		var addTenantItemRes ResRecordID
+		err = parseReader(w.Body, &addTenantItemRes)
+		assert.Nil(t, err)
+		tenantIDs = append(tenantIDs, addTenantItemRes.RecordID)
+	}
+
+	// post /menus
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/menus", &schema.Menu{
+		Name:       util.MustUUID(),
+		ShowStatus: 1,
+		Status:     1,
+		Actions: schema.MenuActions{
+			&schema.MenuAction{
+				Code: "query",
+				Name: "查询",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "GET", Path: resPath},
+				},
+			},
+			&schema.MenuAction{
+				Code: "edit",
+				Name: "编辑",
+				Resources: schema.MenuActionResources{
+					&schema.MenuActionResource{Method: "PUT", Path: resPath},
+				},
+			},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addMenuItemRes ResRecordID
+	err = parseReader(w.Body, &addMenuItemRes)
+	assert.Nil(t, err)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newGetRequest("%s/%s", nil, apiPrefix+"v1/menus", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+	var menuItem schema.Menu
+	err = parseReader(w.Body, &menuItem)
+	assert.Nil(t, err)
+	if !assert.Len(t, menuItem.Actions, 2) {
+		return
+	}
+
+	// post /roles (the first tenant can query, the second tenant can query and edit)
+	var roleIDs []string
+	for i, tenantID := range tenantIDs {
+		roleMenus := schema.RoleMenus{}
+		for _, action := range menuItem.Actions {
+			if i == 0 && action.Code != "query" {
+				continue
+			}
+			roleMenus = append(roleMenus, &schema.RoleMenu{
+				MenuID:   addMenuItemRes.RecordID,
+				ActionID: action.RecordID,
+			})
+		}
+
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/roles", &schema.Role{
+			TenantID:  tenantID,
+			Name:      util.MustUUID(),
+			Status:    1,
+			RoleMenus: roleMenus,
+		}))
+		assert.Equal(t, 200, w.Code)
+		var addRoleItemRes ResRecordID
+		err = parseReader(w.Body, &addRoleItemRes)
+		assert.Nil(t, err)
+		roleIDs = append(roleIDs, addRoleItemRes.RecordID)
+	}
+	assert.True(t, enforcer.HasPolicy(roleIDs[0], tenantIDs[0], resPath, "GET", "*", schema.ResourceEffectAllow, ""))
+	assert.True(t, enforcer.HasPolicy(roleIDs[1], tenantIDs[1], resPath, "PUT", "*", schema.ResourceEffectAllow, ""))
+
+	// post /users (different roles in different tenants)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newPostRequest(apiPrefix+"v1/users", &schema.User{
+		UserName: util.MustUUID(),
+		RealName: util.MustUUID(),
+		Password: "test-1234",
+		Status:   1,
+		UserRoles: schema.UserRoles{
+			&schema.UserRole{RoleID: roleIDs[0]},
+			&schema.UserRole{RoleID: roleIDs[1]},
+		},
+	}))
+	assert.Equal(t, 200, w.Code)
+	var addUserItemRes ResRecordID
+	err = parseReader(w.Body, &addUserItemRes)
+	assert.Nil(t, err)
+	userID := addUserItemRes.RecordID
+
+	assert.True(t, enforcer.HasGroupingPolicy(userID, roleIDs[0], tenantIDs[0]))
+	assert.True(t, enforcer.HasGroupingPolicy(userID, roleIDs[1], tenantIDs[1]))
+	assert.False(t, enforcer.HasGroupingPolicy(userID, roleIDs[0], ""))
+
+	enforcer.EnableEnforce(true)
+	enforce := func(userID, tenantID, method string) bool {
+		ok, err := enforcer.Enforce(userID, tenantID, resPath, method, schema.ViewAdmin, abac.NewEnv("", time.Now(), nil))
+		assert.Nil(t, err)
+		return ok
+	}
+	assert.True(t, enforce(userID, tenantIDs[0], "GET"))
+	assert.False(t, enforce(userID, tenantIDs[0], "PUT"))
+	assert.True(t, enforce(userID, tenantIDs[1], "PUT"))
+	assert.False(t, enforce(userID, "", "GET"))
+	assert.True(t, enforce(config.C.Root.UserName, tenantIDs[0], "PUT"))
+	enforcer.EnableEnforce(false)
+
+	// 在租户中查询的数据按照租户过滤(用户在租户中分配了角色即属于该租户)
+	db, cleanFunc, err := initialize.NewGormDB()
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cleanFunc()
+
+	ctx := context.Background()
+	userModel := &model.User{DB: db}
+	userRoleModel := &model.UserRole{DB: db}
+	for _, tenantID := range []string{tenantIDs[0], tenantIDs[1], ""} {
+		userItem, err := userModel.Get(icontext.NewTenantID(ctx, tenantID), userID)
+		assert.Nil(t, err)
+		assert.NotNil(t, userItem)
+	}
+	userItem, err := userModel.Get(icontext.NewTenantID(ctx, util.MustUUID()), userID)
+	assert.Nil(t, err)
+	assert.Nil(t, userItem)
+
+	userRoleResult, err := userRoleModel.Query(icontext.NewTenantID(ctx, tenantIDs[0]), schema.UserRoleQueryParam{UserID: userID})
+	assert.Nil(t, err)
+	if assert.Len(t, userRoleResult.Data, 1) {
+		assert.Equal(t, roleIDs[0], userRoleResult.Data[0].RoleID)
+		assert.Equal(t, tenantIDs[0], userRoleResult.Data[0].TenantID)
+	}
+	userRoleResult, err = userRoleModel.Query(ctx, schema.UserRoleQueryParam{UserID: userID})
+	assert.Nil(t, err)
+	assert.Len(t, userRoleResult.Data, 2)
+
+	demoModel := &model.Demo{DB: db}
+	demoItem := schema.Demo{
+		RecordID: util.NewRecordID(),
+		Code:     util.MustUUID(),
+		Name:     util.MustUUID(),
+		Status:   1,
+	}
+	err = demoModel.Create(icontext.NewTenantID(ctx, tenantIDs[0]), demoItem)
+	assert.Nil(t, err)
+	getDemoItem, err := demoModel.Get(icontext.NewTenantID(ctx, tenantIDs[0]), demoItem.RecordID)
+	assert.Nil(t, err)
+	if assert.NotNil(t, getDemoItem) {
+		assert.Equal(t, tenantIDs[0], getDemoItem.TenantID)
+	}
+	getDemoItem, err = demoModel.Get(icontext.NewTenantID(ctx, tenantIDs[1]), demoItem.RecordID)
+	assert.Nil(t, err)
+	assert.Nil(t, getDemoItem)
+	err = demoModel.Delete(icontext.NewTenantID(ctx, tenantIDs[1]), demoItem.RecordID)
+	assert.Nil(t, err)
+	getDemoItem, err = demoModel.Get(ctx, demoItem.RecordID)
+	assert.Nil(t, err)
+	assert.NotNil(t, getDemoItem)
+	err = demoModel.Delete(ctx, demoItem.RecordID)
+	assert.Nil(t, err)
+
+	// delete /tenants/:id (has roles)
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/tenants/%s", tenantIDs[0]))
+	assert.Equal(t, 400, w.Code)
+
+	// delete /users/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/users/%s", userID))
+	assert.Equal(t, 200, w.Code)
+	assert.Empty(t, enforcer.GetFilteredGroupingPolicy(0, userID))
+
+	for i, roleID := range roleIDs {
+		// delete /roles/:id
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/roles/%s", roleID))
+		assert.Equal(t, 200, w.Code)
+
+		// delete /tenants/:id
+		w = httptest.NewRecorder()
+		engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/tenants/%s", tenantIDs[i]))
+		assert.Equal(t, 200, w.Code)
+	}
+
+	// delete /menus/:id
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, newDeleteRequest(apiPrefix+"v1/menus/%s", addMenuItemRes.RecordID))
+	assert.Equal(t, 200, w.Code)
+}
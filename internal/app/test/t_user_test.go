@@ -48,7 +48,7 @@ func TestUser(t *testing.T) {
 		UserName: util.MustUUID(),
 		RealName: util.MustUUID(),
 		Status:   1,
-		Password: util.MD5HashString("test"),
+		Password: "test-1234",
 		UserRoles: schema.UserRoles{
 			&schema.UserRole{
 				RoleID: addRoleItemRes.RecordID,
@@ -0,0 +1,258 @@
+package abac
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 请求属性的前缀
+const (
+	AttrHeaderPrefix = "header." // 请求头(如header.X-Env)
+	AttrQueryPrefix  = "query."  // 查询参数(如query.type)
+)
+
+// 格式化属性名(请求头的名称不区分大小写)
+func attrKey(key string) string {
+	key = strings.TrimSpace(key)
+	if strings.HasPrefix(key, AttrHeaderPrefix) {
+		return AttrHeaderPrefix + http.CanonicalHeaderKey(key[len(AttrHeaderPrefix):])
+	}
+	return key
+}
+
+// NewEnv 创建请求环境
+func NewEnv(ip string, t time.Time, attrs map[string]string) *Env {
+	env := &Env{
+		IP:    ip,
+		Time:  t,
+		Attrs: make(map[string]string, len(attrs)),
+	}
+	for k, v := range attrs {
+		env.Attrs[attrKey(k)] = v
+	}
+	return env
+}
+
+// NewRequestEnv 使用请求创建请求环境(请求头及查询参数作为请求属性)
+// 请求属性由客户端提供，可以被伪造或省略，不能作为安全边界；ip应使用服务端可信的客户端IP
+func NewRequestEnv(r *http.Request, ip string) *Env {
+	attrs := make(map[string]string)
+	for k := range r.Header {
+		attrs[AttrHeaderPrefix+k] = r.Header.Get(k)
+	}
+	query := r.URL.Query()
+	for k := range query {
+		attrs[AttrQueryPrefix+k] = query.Get(k)
+	}
+	return NewEnv(ip, time.Now(), attrs)
+}
+
+// Env 请求环境
+type Env struct {
+	IP    string            // 客户端IP
+	Time  time.Time         // 请求时间
+	Attrs map[string]string // 请求属性
+}
+
+func (e *Env) String() string {
+	return fmt.Sprintf("%s@%s", e.IP, e.Time.Format("15:04"))
+}
+
+// Attr 获取请求属性
+func (e *Env) Attr(key string) string {
+	return e.Attrs[attrKey(key)]
+}
+
+// 属性条件
+type attrCondition struct {
+	key string
+	re  *regexp.Regexp
+}
+
+// Condition 访问条件(全部条件均满足时才匹配，未设置的条件不限制)
+type Condition struct {
+	ipNets    []*net.IPNet
+	hasWindow bool
+	start     int // 时间窗口开始(当天的分钟数)
+	end       int // 时间窗口结束(当天的分钟数)
+	attrs     []*attrCondition
+}
+
+// 解析时间(HH:MM)，返回当天的分钟数
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Parse 解析访问条件
+// ipRanges 为客户端IP范围，多个CIDR(或IP)以逗号分隔
+// timeWindow 为时间窗口(如09:00-18:00，结束时间小于开始时间表示跨天)
+// attributes 为请求属性条件(如header.X-Env=^prod$;query.type=1)，多个条件以分号分隔，值为正则表达式
+func Parse(ipRanges, timeWindow, attributes string) (*Condition, error) {
+	c := new(Condition)
+
+	for _, s := range strings.Split(ipRanges, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip %q", s)
+			}
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+			s = fmt.Sprintf("%s/%d", s, bits)
+		}
+
+		_, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %q", s)
+		}
+		c.ipNets = append(c.ipNets, ipNet)
+	}
+
+	if v := strings.TrimSpace(timeWindow); v != "" {
+		ss := strings.Split(v, "-")
+		if len(ss) != 2 {
+			return nil, fmt.Errorf("invalid time window %q", v)
+		}
+
+		start, err := parseClock(ss[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseClock(ss[1])
+		if err != nil {
+			return nil, err
+		} else if start == end {
+			return nil, fmt.Errorf("invalid time window %q", v)
+		}
+		c.hasWindow, c.start, c.end = true, start, end
+	}
+
+	for _, s := range strings.Split(attributes, ";") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid attribute %q", s)
+		}
+
+		re, err := regexp.Compile(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute %q: %s", s, err.Error())
+		}
+		c.attrs = append(c.attrs, &attrCondition{key: attrKey(kv[0]), re: re})
+	}
+
+	return c, nil
+}
+
+// Empty 是否未设置任何条件
+func (c *Condition) Empty() bool {
+	return len(c.ipNets) == 0 && !c.hasWindow && len(c.attrs) == 0
+}
+
+// Match 判断请求环境是否满足访问条件
+func (c *Condition) Match(env *Env) bool {
+	if len(c.ipNets) > 0 {
+		ip := net.ParseIP(env.IP)
+		if ip == nil {
+			return false
+		}
+
+		var ok bool
+		for _, ipNet := range c.ipNets {
+			if ipNet.Contains(ip) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	if c.hasWindow {
+		t := env.Time
+		if t.IsZero() {
+			t = time.Now()
+		}
+
+		m := t.Hour()*60 + t.Minute()
+		if c.start < c.end {
+			if m < c.start || m >= c.end {
+				return false
+			}
+		} else if m < c.start && m >= c.end {
+			return false
+		}
+	}
+
+	for _, item := range c.attrs {
+		if !item.re.MatchString(env.Attr(item.key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// 访问条件编码的参数名
+const (
+	encodeIPKey   = "ip"
+	encodeTimeKey = "time"
+	encodeAttrKey = "attrs"
+)
+
+// Encode 将访问条件编码为一个字符串(不包含逗号及空白，可用于casbin的策略规则)，未设置条件时为空
+func Encode(ipRanges, timeWindow, attributes string) string {
+	values := make(url.Values)
+	if v := strings.TrimSpace(ipRanges); v != "" {
+		values.Set(encodeIPKey, v)
+	}
+	if v := strings.TrimSpace(timeWindow); v != "" {
+		values.Set(encodeTimeKey, v)
+	}
+	if v := strings.TrimSpace(attributes); v != "" {
+		values.Set(encodeAttrKey, v)
+	}
+	return values.Encode()
+}
+
+// 已解析的访问条件(编码字符串 -> *Condition)
+var decodeCache sync.Map
+
+// Decode 解析Encode编码的访问条件(解析结果会被缓存)
+func Decode(s string) (*Condition, error) {
+	if v, ok := decodeCache.Load(s); ok {
+		return v.(*Condition), nil
+	}
+
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := Parse(values.Get(encodeIPKey), values.Get(encodeTimeKey), values.Get(encodeAttrKey))
+	if err != nil {
+		return nil, err
+	}
+	decodeCache.Store(s, c)
+	return c, nil
+}
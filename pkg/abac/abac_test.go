@@ -0,0 +1,90 @@
+package abac
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func clock(hour, min int) time.Time {
+	return time.Date(2020, 1, 1, hour, min, 0, 0, time.Local)
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse("10.0.0.0/33", "", "")
+	assert.NotNil(t, err)
+
+	_, err = Parse("", "09:00", "")
+	assert.NotNil(t, err)
+
+	_, err = Parse("", "09:00-09:00", "")
+	assert.NotNil(t, err)
+
+	_, err = Parse("", "", "header.X-Env")
+	assert.NotNil(t, err)
+
+	_, err = Parse("", "", "header.X-Env=(")
+	assert.NotNil(t, err)
+
+	c, err := Parse("", "", "")
+	assert.Nil(t, err)
+	assert.True(t, c.Empty())
+	assert.True(t, c.Match(NewEnv("", time.Time{}, nil)))
+}
+
+func TestMatchIP(t *testing.T) {
+	c, err := Parse("10.0.0.0/8, 192.168.1.10", "", "")
+	assert.Nil(t, err)
+
+	assert.True(t, c.Match(NewEnv("10.1.2.3", time.Time{}, nil)))
+	assert.True(t, c.Match(NewEnv("192.168.1.10", time.Time{}, nil)))
+	assert.False(t, c.Match(NewEnv("192.168.1.11", time.Time{}, nil)))
+	assert.False(t, c.Match(NewEnv("", time.Time{}, nil)))
+}
+
+func TestMatchTimeWindow(t *testing.T) {
+	c, err := Parse("", "09:00-18:00", "")
+	assert.Nil(t, err)
+	assert.True(t, c.Match(NewEnv("", clock(9, 0), nil)))
+	assert.True(t, c.Match(NewEnv("", clock(17, 59), nil)))
+	assert.False(t, c.Match(NewEnv("", clock(18, 0), nil)))
+	assert.False(t, c.Match(NewEnv("", clock(8, 59), nil)))
+
+	// 跨天的时间窗口
+	c, err = Parse("", "22:00-06:00", "")
+	assert.Nil(t, err)
+	assert.True(t, c.Match(NewEnv("", clock(23, 0), nil)))
+	assert.True(t, c.Match(NewEnv("", clock(5, 59), nil)))
+	assert.False(t, c.Match(NewEnv("", clock(12, 0), nil)))
+}
+
+func TestMatchAttrs(t *testing.T) {
+	c, err := Parse("", "", "header.x-env=^prod$;query.type=^(1|2)$")
+	assert.Nil(t, err)
+
+	assert.True(t, c.Match(NewEnv("", time.Time{}, map[string]string{
+		"header.X-Env": "prod",
+		"query.type":   "2",
+	})))
+	assert.False(t, c.Match(NewEnv("", time.Time{}, map[string]string{
+		"header.X-Env": "test",
+		"query.type":   "2",
+	})))
+	assert.False(t, c.Match(NewEnv("", time.Time{}, map[string]string{
+		"header.X-Env": "prod",
+	})))
+}
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "", Encode("", " ", ""))
+
+	s := Encode("10.0.0.0/8,172.16.0.0/12", "09:00-18:00", "header.X-Env=^(a|b)+$")
+	assert.False(t, strings.ContainsAny(s, ", \t"))
+
+	c, err := Decode(s)
+	assert.Nil(t, err)
+	assert.True(t, c.Match(NewEnv("172.16.1.1", clock(10, 0), map[string]string{"header.X-Env": "ab"})))
+	assert.False(t, c.Match(NewEnv("172.16.1.1", clock(10, 0), map[string]string{"header.X-Env": "c"})))
+}
@@ -3,11 +3,13 @@ package auth
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // 定义错误
 var (
 	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenReused  = errors.New("refresh token reused")
 )
 
 // TokenInfo 令牌信息
@@ -18,21 +20,104 @@ type TokenInfo interface {
 	GetTokenType() string
 	// 获取令牌到期时间戳
 	GetExpiresAt() int64
+	// 获取刷新令牌
+	GetRefreshToken() string
+	// 获取刷新令牌到期时间戳
+	GetRefreshExpiresAt() int64
 	// JSON编码
 	EncodeToJSON() ([]byte, error)
 }
 
+// Session 会话信息(一次登录及其刷新产生的令牌属于同一会话)
+type Session struct {
+	ID        string `json:"id"`                 // 会话ID
+	UserID    string `json:"user_id"`            // 用户ID
+	ActorID   string `json:"actor_id,omitempty"` // 模拟登录的操作者ID(模拟登录会话登记在被模拟的用户下)
+	IP        string `json:"ip"`                 // 登录IP
+	UserAgent string `json:"user_agent"`         // 用户代理
+	IssuedAt  int64  `json:"issued_at"`          // 登录时间戳
+	ExpiresAt int64  `json:"expires_at"`         // 会话到期时间戳
+}
+
+// TokenClaims 访问令牌中的声明
+type TokenClaims struct {
+	UserID    string // 用户ID
+	View      string // 令牌视图
+	TenantID  string // 租户ID(为空时为默认租户)
+	ActorID   string // 模拟登录的操作者ID(为空则不是模拟登录)
+	SessionID string // 会话ID
+}
+
+// ClientInfo 客户端信息
+type ClientInfo struct {
+	IP        string // 客户端IP
+	UserAgent string // 用户代理
+}
+
+type clientInfoKey struct{}
+
+// NewClientContext 创建客户端信息的上下文
+func NewClientContext(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// FromClientContext 从上下文中获取客户端信息
+func FromClientContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+type tenantKey struct{}
+
+// NewTenantContext 创建租户的上下文(生成的令牌属于该租户)
+func NewTenantContext(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// FromTenantContext 从上下文中获取租户
+func FromTenantContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantKey{}).(string)
+	return tenantID
+}
+
 // Auther 认证接口
 type Auther interface {
 	// 生成令牌
 	GenerateToken(ctx context.Context, userID string, userView string) (TokenInfo, error)
 
+	// 使用刷新令牌换取新的令牌(刷新令牌只能使用一次)
+	RefreshToken(ctx context.Context, refreshToken string) (TokenInfo, error)
+
 	// 销毁令牌
 	DestroyToken(ctx context.Context, accessToken string) error
 
+	// 生成模拟登录令牌(以操作者身份模拟指定用户，只有访问令牌，到期后不能刷新)
+	GenerateImpersonationToken(ctx context.Context, actorID, userID, userView string, expired int) (TokenInfo, error)
+
 	// 解析用户ID
 	ParseUserID(ctx context.Context, accessToken string) (string, string, error)
 
+	// 解析访问令牌中的声明
+	ParseToken(ctx context.Context, accessToken string) (*TokenClaims, error)
+
+	// 生成多因素认证的挑战令牌(返回令牌及到期时间戳)
+	GenerateChallengeToken(ctx context.Context, userID string) (string, int64, error)
+
+	// 解析挑战令牌中的用户ID(consume为true时令牌随即失效)
+	ParseChallengeToken(ctx context.Context, challengeToken string, consume bool) (string, error)
+
+	// 标记一次性凭证已使用(如动态验证码)，返回是否为到期前的首次使用
+	UseOnce(ctx context.Context, key string, expiration time.Duration) (bool, error)
+
+	// 查询用户的有效会话
+	QuerySessions(ctx context.Context, userID string) ([]*Session, error)
+
+	// 撤销用户的指定会话
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+
+	// 撤销用户的全部会话
+	RevokeSessions(ctx context.Context, userID string) error
+
 	// 释放资源
 	Release() error
 }
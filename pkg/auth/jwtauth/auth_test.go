@@ -3,9 +3,12 @@ package jwtauth
 import (
 	"context"
 	"testing"
+	"time"
 
-	//"github.com/wangwei518/gin-admin/pkg/auth/jwtauth/store/buntdb"
+	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/pkg/auth"
+	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth/store/buntdb"
 	"github.com/wangwei518/gin-admin/pkg/auth/jwtauth/store/elasticsearch"
 )
 
@@ -43,3 +46,256 @@ func TestAuth(t *testing.T) {
 	assert.EqualError(t, err, "invalid token")
 	assert.Empty(t, id)
 }
+
+func TestRefreshToken(t *testing.T) {
+	store, err := buntdb.NewStore(":memory:")
+	assert.Nil(t, err)
+
+	jwtAuth := New(store)
+	defer jwtAuth.Release()
+
+	ctx := context.Background()
+	token, err := jwtAuth.GenerateToken(ctx, "test", "global")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, token.GetRefreshToken())
+
+	// 刷新令牌不能作为访问令牌使用
+	_, _, err = jwtAuth.ParseUserID(ctx, token.GetRefreshToken())
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	// 访问令牌不能用于刷新
+	_, err = jwtAuth.RefreshToken(ctx, token.GetAccessToken())
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	newToken, err := jwtAuth.RefreshToken(ctx, token.GetRefreshToken())
+	assert.Nil(t, err)
+	assert.NotEqual(t, token.GetRefreshToken(), newToken.GetRefreshToken())
+
+	id, view, err := jwtAuth.ParseUserID(ctx, newToken.GetAccessToken())
+	assert.Nil(t, err)
+	assert.Equal(t, "test", id)
+	assert.Equal(t, "global", view)
+
+	// 重复使用刷新令牌，撤销整个令牌族
+	_, err = jwtAuth.RefreshToken(ctx, token.GetRefreshToken())
+	assert.Equal(t, auth.ErrTokenReused, err)
+
+	_, err = jwtAuth.RefreshToken(ctx, newToken.GetRefreshToken())
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	_, _, err = jwtAuth.ParseUserID(ctx, newToken.GetAccessToken())
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	// 登出后刷新令牌失效
+	token, err = jwtAuth.GenerateToken(ctx, "test", "global")
+	assert.Nil(t, err)
+
+	err = jwtAuth.DestroyToken(ctx, token.GetAccessToken())
+	assert.Nil(t, err)
+
+	_, err = jwtAuth.RefreshToken(ctx, token.GetRefreshToken())
+	assert.Equal(t, auth.ErrInvalidToken, err)
+}
+
+func TestTenantToken(t *testing.T) {
+	store, err := buntdb.NewStore(":memory:")
+	assert.Nil(t, err)
+
+	jwtAuth := New(store)
+	defer jwtAuth.Release()
+
+	ctx := auth.NewTenantContext(context.Background(), "tenant")
+	token, err := jwtAuth.GenerateToken(ctx, "test", "global")
+	assert.Nil(t, err)
+
+	claims, err := jwtAuth.ParseToken(ctx, token.GetAccessToken())
+	assert.Nil(t, err)
+	assert.Equal(t, "tenant", claims.TenantID)
+
+	// 刷新后的令牌仍属于原租户
+	newToken, err := jwtAuth.RefreshToken(context.Background(), token.GetRefreshToken())
+	assert.Nil(t, err)
+	claims, err = jwtAuth.ParseToken(ctx, newToken.GetAccessToken())
+	assert.Nil(t, err)
+	assert.Equal(t, "tenant", claims.TenantID)
+
+	// 未指定租户时为默认租户
+	token, err = jwtAuth.GenerateToken(context.Background(), "test", "global")
+	assert.Nil(t, err)
+	claims, err = jwtAuth.ParseToken(ctx, token.GetAccessToken())
+	assert.Nil(t, err)
+	assert.Empty(t, claims.TenantID)
+}
+
+func TestSessions(t *testing.T) {
+	store, err := buntdb.NewStore(":memory:")
+	assert.Nil(t, err)
+
+	jwtAuth := New(store)
+	defer jwtAuth.Release()
+
+	ctx := auth.NewClientContext(context.Background(), auth.ClientInfo{
+		IP:        "127.0.0.1",
+		UserAgent: "test",
+	})
+
+	var tokens []auth.TokenInfo
+	for i := 0; i < 3; i++ {
+		token, err := jwtAuth.GenerateToken(ctx, "test", "global")
+		assert.Nil(t, err)
+		tokens = append(tokens, token)
+	}
+
+	sessions, err := jwtAuth.QuerySessions(ctx, "test")
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 3)
+	assert.Equal(t, "127.0.0.1", sessions[0].IP)
+	assert.Equal(t, "test", sessions[0].UserAgent)
+
+	// 刷新令牌不产生新的会话
+	tokens[0], err = jwtAuth.RefreshToken(ctx, tokens[0].GetRefreshToken())
+	assert.Nil(t, err)
+	sessions, err = jwtAuth.QuerySessions(ctx, "test")
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 3)
+
+	// 撤销指定会话
+	var claims CustomClaims
+	_, _, err = new(jwt.Parser).ParseUnverified(tokens[1].GetAccessToken(), &claims)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, claims.Id)
+
+	err = jwtAuth.RevokeSession(ctx, "test", claims.SessionID)
+	assert.Nil(t, err)
+
+	_, _, err = jwtAuth.ParseUserID(ctx, tokens[1].GetAccessToken())
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	_, _, err = jwtAuth.ParseUserID(ctx, tokens[0].GetAccessToken())
+	assert.Nil(t, err)
+
+	// 撤销全部会话
+	err = jwtAuth.RevokeSessions(ctx, "test")
+	assert.Nil(t, err)
+
+	for _, token := range tokens {
+		_, _, err = jwtAuth.ParseUserID(ctx, token.GetAccessToken())
+		assert.Equal(t, auth.ErrInvalidToken, err)
+
+		_, err = jwtAuth.RefreshToken(ctx, token.GetRefreshToken())
+		assert.Equal(t, auth.ErrInvalidToken, err)
+	}
+
+	sessions, err = jwtAuth.QuerySessions(ctx, "test")
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 0)
+}
+
+func TestChallengeToken(t *testing.T) {
+	store, err := buntdb.NewStore(":memory:")
+	assert.Nil(t, err)
+
+	jwtAuth := New(store, SetChallengeExpired(60))
+	defer jwtAuth.Release()
+
+	ctx := context.Background()
+	token, expiresAt, err := jwtAuth.GenerateChallengeToken(ctx, "test")
+	assert.Nil(t, err)
+	assert.True(t, expiresAt > time.Now().Unix())
+
+	// 挑战令牌不能作为访问令牌使用
+	_, _, err = jwtAuth.ParseUserID(ctx, token)
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	userID, err := jwtAuth.ParseChallengeToken(ctx, token, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "test", userID)
+
+	userID, err = jwtAuth.ParseChallengeToken(ctx, token, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "test", userID)
+
+	_, err = jwtAuth.ParseChallengeToken(ctx, token, true)
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	_, err = jwtAuth.ParseChallengeToken(ctx, token, false)
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	// 访问令牌不能作为挑战令牌使用
+	tokenInfo, err := jwtAuth.GenerateToken(ctx, "test", "global")
+	assert.Nil(t, err)
+	_, err = jwtAuth.ParseChallengeToken(ctx, tokenInfo.GetAccessToken(), false)
+	assert.Equal(t, auth.ErrInvalidToken, err)
+}
+
+func TestUseOnce(t *testing.T) {
+	store, err := buntdb.NewStore(":memory:")
+	assert.Nil(t, err)
+
+	jwtAuth := New(store)
+	defer jwtAuth.Release()
+
+	ctx := context.Background()
+	ok, err := jwtAuth.UseOnce(ctx, "mfa:test:1", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = jwtAuth.UseOnce(ctx, "mfa:test:1", time.Minute)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	// 使用相同存储的其他实例同样不能再次使用
+	ok, err = New(store).UseOnce(ctx, "mfa:test:1", time.Minute)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	ok, err = jwtAuth.UseOnce(ctx, "mfa:test:2", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestImpersonationToken(t *testing.T) {
+	store, err := buntdb.NewStore(":memory:")
+	assert.Nil(t, err)
+
+	jwtAuth := New(store, SetExpired(3600))
+	defer jwtAuth.Release()
+
+	ctx := context.Background()
+	token, err := jwtAuth.GenerateImpersonationToken(ctx, "admin", "test", "global", 600)
+	assert.Nil(t, err)
+	assert.Empty(t, token.GetRefreshToken())
+	assert.True(t, token.GetExpiresAt() <= time.Now().Add(600*time.Second).Unix())
+
+	claims, err := jwtAuth.ParseToken(ctx, token.GetAccessToken())
+	assert.Nil(t, err)
+	assert.Equal(t, "test", claims.UserID)
+	assert.Equal(t, "global", claims.View)
+	assert.Equal(t, "admin", claims.ActorID)
+
+	// 模拟登录会话登记在被模拟的用户下
+	sessions, err := jwtAuth.QuerySessions(ctx, "test")
+	assert.Nil(t, err)
+	if assert.Len(t, sessions, 1) {
+		assert.Equal(t, "admin", sessions[0].ActorID)
+		assert.Equal(t, token.GetExpiresAt(), sessions[0].ExpiresAt)
+	}
+
+	// 有效期不能超过访问令牌的有效期
+	longToken, err := jwtAuth.GenerateImpersonationToken(ctx, "admin", "test", "global", 7200)
+	assert.Nil(t, err)
+	assert.True(t, longToken.GetExpiresAt() <= time.Now().Add(3600*time.Second).Unix())
+
+	// 普通令牌没有操作者
+	tokenInfo, err := jwtAuth.GenerateToken(ctx, "test", "global")
+	assert.Nil(t, err)
+	claims, err = jwtAuth.ParseToken(ctx, tokenInfo.GetAccessToken())
+	assert.Nil(t, err)
+	assert.Empty(t, claims.ActorID)
+
+	// 结束模拟登录
+	err = jwtAuth.DestroyToken(ctx, token.GetAccessToken())
+	assert.Nil(t, err)
+	_, err = jwtAuth.ParseToken(ctx, token.GetAccessToken())
+	assert.Equal(t, auth.ErrInvalidToken, err)
+}
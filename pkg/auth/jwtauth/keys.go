@@ -0,0 +1,184 @@
+package jwtauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/wangwei518/gin-admin/pkg/auth"
+)
+
+// 定义错误
+var (
+	ErrInvalidKey = errors.New("invalid key")
+)
+
+// Key 令牌签名密钥
+type Key struct {
+	ID        string            // 密钥ID(kid)
+	Method    jwt.SigningMethod // 签名方式
+	SignKey   interface{}       // 签名密钥(仅用于校验的密钥为nil)
+	VerifyKey interface{}       // 校验密钥
+}
+
+// IsSymmetric 是否为对称密钥(HMAC)
+func (k *Key) IsSymmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// GetSigningMethod 根据名称获取签名方式
+func GetSigningMethod(name string) (jwt.SigningMethod, error) {
+	method := jwt.GetSigningMethod(name)
+	if method == nil {
+		return nil, fmt.Errorf("unknown signing method: %s", name)
+	}
+	return method, nil
+}
+
+// NewHMACKey 创建HMAC密钥
+func NewHMACKey(id string, method jwt.SigningMethod, secret []byte) (*Key, error) {
+	if _, ok := method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, ErrInvalidKey
+	}
+	return &Key{
+		ID:        id,
+		Method:    method,
+		SignKey:   secret,
+		VerifyKey: secret,
+	}, nil
+}
+
+// NewKeyFromPEM 从PEM格式数据创建非对称密钥(私钥可用于签名，公钥仅用于校验)
+func NewKeyFromPEM(id string, method jwt.SigningMethod, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+
+	parsed, err := parsePEMBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &Key{ID: id, Method: method}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.SignKey = parsed
+		key.VerifyKey = signer.Public()
+	} else {
+		key.VerifyKey = parsed
+	}
+
+	if !checkKeyType(method, key.VerifyKey) {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// NewKeyFromFile 从PEM格式文件创建非对称密钥
+func NewKeyFromFile(id string, method jwt.SigningMethod, filename string) (*Key, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyFromPEM(id, method, data)
+}
+
+func parsePEMBlock(block *pem.Block) (interface{}, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return nil, fmt.Errorf("unsupported pem type: %s", block.Type)
+}
+
+func checkKeyType(method jwt.SigningMethod, key interface{}) bool {
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case *jwt.SigningMethodECDSA:
+		k, ok := key.(*ecdsa.PublicKey)
+		return ok && k.Curve.Params().BitSize == method.(*jwt.SigningMethodECDSA).CurveBits
+	case *signingMethodEdDSA:
+		_, ok := key.(ed25519.PublicKey)
+		return ok
+	}
+	return false
+}
+
+// NewKeySet 创建密钥集合(signing用于签发令牌，verify为轮换期间仍然有效的历史密钥)
+func NewKeySet(signing *Key, verify ...*Key) (*KeySet, error) {
+	if signing == nil || signing.SignKey == nil {
+		return nil, ErrInvalidKey
+	}
+
+	ks := &KeySet{
+		signing: signing,
+		keys:    make(map[string]*Key),
+	}
+	for _, key := range append([]*Key{signing}, verify...) {
+		if _, ok := ks.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id: %s", key.ID)
+		}
+		ks.keys[key.ID] = key
+		ks.ids = append(ks.ids, key.ID)
+	}
+	return ks, nil
+}
+
+// KeySet 密钥集合
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	ids     []string
+}
+
+// SigningKey 当前的签名密钥
+func (ks *KeySet) SigningKey() *Key {
+	return ks.signing
+}
+
+// Keyfunc 根据令牌头部的kid选择校验密钥，并校验签名方式是否一致
+func (ks *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	} else if t.Method.Alg() != key.Method.Alg() {
+		return nil, auth.ErrInvalidToken
+	}
+	return key.VerifyKey, nil
+}
+
+// SetKeySet 设定签名及校验使用的密钥集合
+func SetKeySet(ks *KeySet) Option {
+	return func(o *options) {
+		key := ks.SigningKey()
+		o.signingMethod = key.Method
+		o.signingKey = key.SignKey
+		o.keyID = key.ID
+		o.keyfunc = ks.Keyfunc
+	}
+}
@@ -0,0 +1,129 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/pkg/auth"
+)
+
+func encodePEM(t *testing.T, key interface{}) []byte {
+	buf, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: buf})
+}
+
+func encodePublicPEM(t *testing.T, key interface{}) []byte {
+	buf, err := x509.MarshalPKIXPublicKey(key)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: buf})
+}
+
+func TestKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	rsaSigner, err := NewKeyFromPEM("rsa-1", jwt.SigningMethodRS256, encodePEM(t, rsaKey))
+	assert.Nil(t, err)
+	ecSigner, err := NewKeyFromPEM("ec-1", jwt.SigningMethodES256, encodePEM(t, ecKey))
+	assert.Nil(t, err)
+	edSigner, err := NewKeyFromPEM("ed-1", SigningMethodEdDSA, encodePEM(t, edKey))
+	assert.Nil(t, err)
+
+	// 签名方式与密钥类型不一致
+	_, err = NewKeyFromPEM("ec-2", jwt.SigningMethodES384, encodePEM(t, ecKey))
+	assert.Equal(t, ErrInvalidKey, err)
+	_, err = NewKeyFromPEM("rsa-2", jwt.SigningMethodES256, encodePEM(t, rsaKey))
+	assert.Equal(t, ErrInvalidKey, err)
+
+	ctx := context.Background()
+	var tokens []string
+	for _, key := range []*Key{rsaSigner, ecSigner, edSigner} {
+		ks, err := NewKeySet(key)
+		assert.Nil(t, err)
+
+		jwtAuth := New(nil, SetKeySet(ks))
+		token, err := jwtAuth.GenerateToken(ctx, "test", "global")
+		assert.Nil(t, err)
+
+		id, _, err := jwtAuth.ParseUserID(ctx, token.GetAccessToken())
+		assert.Nil(t, err)
+		assert.Equal(t, "test", id)
+		tokens = append(tokens, token.GetAccessToken())
+	}
+
+	// 轮换密钥：历史密钥仅保留公钥用于校验
+	edVerifier, err := NewKeyFromPEM("ed-1", SigningMethodEdDSA, encodePublicPEM(t, edPub))
+	assert.Nil(t, err)
+	assert.Nil(t, edVerifier.SignKey)
+
+	_, err = NewKeySet(edVerifier)
+	assert.Equal(t, ErrInvalidKey, err)
+
+	ks, err := NewKeySet(rsaSigner, ecSigner, edVerifier)
+	assert.Nil(t, err)
+	jwtAuth := New(nil, SetKeySet(ks))
+	for _, token := range tokens {
+		id, _, err := jwtAuth.ParseUserID(ctx, token)
+		assert.Nil(t, err)
+		assert.Equal(t, "test", id)
+	}
+
+	// 未知的kid
+	ks, err = NewKeySet(ecSigner)
+	assert.Nil(t, err)
+	_, _, err = New(nil, SetKeySet(ks)).ParseUserID(ctx, tokens[0])
+	assert.NotNil(t, err)
+
+	// 对称密钥不公开
+	hmacKey, err := NewHMACKey("hmac-1", jwt.SigningMethodHS256, []byte("secret"))
+	assert.Nil(t, err)
+	ks, err = NewKeySet(hmacKey, rsaSigner, ecSigner, edVerifier)
+	assert.Nil(t, err)
+
+	jwks := ks.JWKS()
+	assert.Len(t, jwks.Keys, 3)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.Equal(t, "EC", jwks.Keys[1].KeyType)
+	assert.Equal(t, "P-256", jwks.Keys[1].Curve)
+	assert.Len(t, jwks.Keys[1].X, 43)
+	assert.Equal(t, "OKP", jwks.Keys[2].KeyType)
+	assert.Equal(t, "ed-1", jwks.Keys[2].KeyID)
+
+	_, err = NewKeySet(hmacKey, hmacKey)
+	assert.NotNil(t, err)
+}
+
+func TestKeyfuncAlgorithm(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	key, err := NewKeyFromPEM("rsa-1", jwt.SigningMethodRS256, encodePEM(t, rsaKey))
+	assert.Nil(t, err)
+	ks, err := NewKeySet(key)
+	assert.Nil(t, err)
+
+	// 使用公钥作为HMAC密钥伪造令牌
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, CustomClaims{})
+	token.Header["kid"] = "rsa-1"
+	tokenString, err := token.SignedString(encodePublicPEM(t, &rsaKey.PublicKey))
+	assert.Nil(t, err)
+
+	_, _, err = New(nil, SetKeySet(ks)).ParseUserID(context.Background(), tokenString)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), auth.ErrInvalidToken.Error())
+}
@@ -3,14 +3,26 @@ package jwtauth
 import (
 	"context"
 	"time"
+
+	"github.com/wangwei518/gin-admin/pkg/auth"
 )
 
 // Storer 令牌存储接口
 type Storer interface {
 	// 存储令牌数据，并指定到期时间
 	Set(ctx context.Context, tokenString string, expiration time.Duration) error
+	// 仅当令牌不存在时存储，返回是否存储成功
+	SetNX(ctx context.Context, tokenString string, expiration time.Duration) (bool, error)
 	// 检查令牌是否存在
 	Check(ctx context.Context, tokenString string) (bool, error)
+	// 存储会话，并指定到期时间
+	SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error
+	// 获取会话(不存在时返回nil)
+	GetSession(ctx context.Context, userID, sessionID string) (*auth.Session, error)
+	// 查询用户的会话列表
+	QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error)
+	// 删除会话
+	DeleteSession(ctx context.Context, userID, sessionID string) error
 	// 关闭存储
 	Close() error
 }
@@ -2,11 +2,14 @@ package buntdb
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/tidwall/buntdb"
+	"github.com/wangwei518/gin-admin/pkg/auth"
 )
 
 // NewStore 创建基于buntdb的文件存储
@@ -42,6 +45,28 @@ func (a *Store) Set(ctx context.Context, tokenString string, expiration time.Dur
 	})
 }
 
+// SetNX ...
+func (a *Store) SetNX(ctx context.Context, tokenString string, expiration time.Duration) (bool, error) {
+	var ok bool
+	err := a.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Get(tokenString)
+		if err == nil {
+			return nil
+		} else if err != buntdb.ErrNotFound {
+			return err
+		}
+
+		var opts *buntdb.SetOptions
+		if expiration > 0 {
+			opts = &buntdb.SetOptions{Expires: true, TTL: expiration}
+		}
+		_, _, err = tx.Set(tokenString, "1", opts)
+		ok = err == nil
+		return err
+	})
+	return ok, err
+}
+
 // Delete 删除键
 func (a *Store) Delete(ctx context.Context, tokenString string) error {
 	return a.db.Update(func(tx *buntdb.Tx) error {
@@ -67,6 +92,71 @@ func (a *Store) Check(ctx context.Context, tokenString string) (bool, error) {
 	return exists, err
 }
 
+func sessionKey(userID, sessionID string) string {
+	return fmt.Sprintf("session:%s:%s", userID, sessionID)
+}
+
+// SetSession ...
+func (a *Store) SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error {
+	buf, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return a.db.Update(func(tx *buntdb.Tx) error {
+		var opts *buntdb.SetOptions
+		if expiration > 0 {
+			opts = &buntdb.SetOptions{Expires: true, TTL: expiration}
+		}
+		_, _, err := tx.Set(sessionKey(session.UserID, session.ID), string(buf), opts)
+		return err
+	})
+}
+
+// GetSession ...
+func (a *Store) GetSession(ctx context.Context, userID, sessionID string) (*auth.Session, error) {
+	var session *auth.Session
+	err := a.db.View(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(sessionKey(userID, sessionID))
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+
+		session = new(auth.Session)
+		return json.Unmarshal([]byte(val), session)
+	})
+	return session, err
+}
+
+// QuerySessions ...
+func (a *Store) QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error) {
+	var sessions []*auth.Session
+	err := a.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		ierr := tx.AscendKeys(sessionKey(userID, "*"), func(key, value string) bool {
+			session := new(auth.Session)
+			if err = json.Unmarshal([]byte(value), session); err != nil {
+				return false
+			}
+			sessions = append(sessions, session)
+			return true
+		})
+		if ierr != nil {
+			return ierr
+		}
+		return err
+	})
+	return sessions, err
+}
+
+// DeleteSession ...
+func (a *Store) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	return a.Delete(ctx, sessionKey(userID, sessionID))
+}
+
 // Close ...
 func (a *Store) Close() error {
 	return a.db.Close()
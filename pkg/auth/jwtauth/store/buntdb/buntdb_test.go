@@ -3,8 +3,10 @@ package buntdb
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/pkg/auth"
 )
 
 func TestStore(t *testing.T) {
@@ -24,4 +26,48 @@ func TestStore(t *testing.T) {
 
 	err = store.Delete(ctx, key)
 	assert.Nil(t, err)
+
+	ok, err := store.SetNX(ctx, key, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, true, ok)
+
+	ok, err = store.SetNX(ctx, key, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, false, ok)
+}
+
+func TestSession(t *testing.T) {
+	store, err := NewStore(":memory:")
+	assert.Nil(t, err)
+
+	defer store.Close()
+
+	ctx := context.Background()
+	for _, item := range []*auth.Session{
+		{ID: "1", UserID: "foo", IP: "127.0.0.1"},
+		{ID: "2", UserID: "foo"},
+		{ID: "3", UserID: "bar"},
+	} {
+		err = store.SetSession(ctx, item, time.Minute)
+		assert.Nil(t, err)
+	}
+
+	session, err := store.GetSession(ctx, "foo", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, "127.0.0.1", session.IP)
+
+	sessions, err := store.QuerySessions(ctx, "foo")
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 2)
+
+	err = store.DeleteSession(ctx, "foo", "1")
+	assert.Nil(t, err)
+
+	session, err = store.GetSession(ctx, "foo", "1")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	sessions, err = store.QuerySessions(ctx, "foo")
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 1)
 }
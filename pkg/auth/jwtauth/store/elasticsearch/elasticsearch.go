@@ -2,11 +2,13 @@ package elasticsearch
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"time"
 
 	"github.com/olivere/elastic/v7"
+	"github.com/wangwei518/gin-admin/pkg/auth"
 )
 
 // Config configurations
@@ -137,6 +139,35 @@ func (s *Store) Set(ctx context.Context, tokenString string, expiration time.Dur
 	return nil
 }
 
+// SetNX 以令牌作为文档ID创建文档，文档已存在时返回false
+func (s *Store) SetNX(ctx context.Context, tokenString string, expiration time.Duration) (bool, error) {
+	_ = expiration
+
+	doc := BlackListDoc{
+		Token:   tokenString,
+		Issuer:  "FIXME",
+		Created: time.Now(),
+	}
+	_, err := s.Cli.Index().
+		Index(s.Index).
+		Id(tokenString).
+		OpType("create").
+		BodyJson(doc).
+		Do(ctx)
+	if err != nil {
+		if elastic.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	_, err = s.Cli.Refresh().Index(s.Index).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Delete ...
 func (s *Store) Delete(ctx context.Context, tokenString string) error {
 
@@ -207,6 +238,91 @@ func (s *Store) Check(ctx context.Context, tokenString string) (bool, error) {
 
 }
 
+func (s *Store) sessionIndex() string {
+	return s.Index + "_session"
+}
+
+func sessionDocID(userID, sessionID string) string {
+	return userID + ":" + sessionID
+}
+
+// SetSession 会话存储在单独的索引中(过期的会话在查询时过滤)
+func (s *Store) SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error {
+	_ = expiration
+
+	_, err := s.Cli.Index().
+		Index(s.sessionIndex()).
+		Id(sessionDocID(session.UserID, session.ID)).
+		BodyJson(session).
+		Refresh("true").
+		Do(ctx)
+	return err
+}
+
+// GetSession ...
+func (s *Store) GetSession(ctx context.Context, userID, sessionID string) (*auth.Session, error) {
+	result, err := s.Cli.Get().
+		Index(s.sessionIndex()).
+		Id(sessionDocID(userID, sessionID)).
+		Do(ctx)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	} else if !result.Found {
+		return nil, nil
+	}
+
+	session := new(auth.Session)
+	err = json.Unmarshal(result.Source, session)
+	if err != nil {
+		return nil, err
+	} else if session.ExpiresAt > 0 && session.ExpiresAt < time.Now().Unix() {
+		return nil, nil
+	}
+	return session, nil
+}
+
+// QuerySessions ...
+func (s *Store) QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error) {
+	query := elastic.NewBoolQuery().Filter(
+		elastic.NewTermQuery("user_id.keyword", userID),
+		elastic.NewRangeQuery("expires_at").Gt(time.Now().Unix()),
+	)
+	searchResult, err := s.Cli.Search().
+		Index(s.sessionIndex()).
+		Query(query).
+		Size(1000).
+		Do(ctx)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var sessions []*auth.Session
+	for _, item := range searchResult.Each(reflect.TypeOf(auth.Session{})) {
+		session := item.(auth.Session)
+		sessions = append(sessions, &session)
+	}
+	return sessions, nil
+}
+
+// DeleteSession ...
+func (s *Store) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	_, err := s.Cli.Delete().
+		Index(s.sessionIndex()).
+		Id(sessionDocID(userID, sessionID)).
+		Refresh("true").
+		Do(ctx)
+	if err != nil && !elastic.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 // Close ...
 func (s *Store) Close() error {
 	// seems there is no close function in olivere/elasticsearch
@@ -2,10 +2,12 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/go-redis/redis"
+	"github.com/wangwei518/gin-admin/pkg/auth"
 )
 
 // Config redis配置参数
@@ -48,8 +50,12 @@ func NewStoreWithClusterClient(cli *redis.ClusterClient, keyPrefix string) *Stor
 type redisClienter interface {
 	Get(key string) *redis.StringCmd
 	Set(key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	SetNX(key string, value interface{}, expiration time.Duration) *redis.BoolCmd
 	Expire(key string, expiration time.Duration) *redis.BoolCmd
 	Exists(keys ...string) *redis.IntCmd
+	SAdd(key string, members ...interface{}) *redis.IntCmd
+	SMembers(key string) *redis.StringSliceCmd
+	SRem(key string, members ...interface{}) *redis.IntCmd
 	TxPipeline() redis.Pipeliner
 	Del(keys ...string) *redis.IntCmd
 	Close() error
@@ -71,6 +77,12 @@ func (s *Store) Set(ctx context.Context, tokenString string, expiration time.Dur
 	return cmd.Err()
 }
 
+// SetNX ...
+func (s *Store) SetNX(ctx context.Context, tokenString string, expiration time.Duration) (bool, error) {
+	cmd := s.cli.SetNX(s.wrapperKey(tokenString), "1", expiration)
+	return cmd.Val(), cmd.Err()
+}
+
 // Delete ...
 func (s *Store) Delete(ctx context.Context, tokenString string) error {
 	cmd := s.cli.Del(tokenString)
@@ -89,6 +101,91 @@ func (s *Store) Check(ctx context.Context, tokenString string) (bool, error) {
 	return cmd.Val() > 0, nil
 }
 
+func (s *Store) sessionKey(userID, sessionID string) string {
+	return s.wrapperKey(fmt.Sprintf("session:%s:%s", userID, sessionID))
+}
+
+// 用户的会话ID集合
+func (s *Store) sessionSetKey(userID string) string {
+	return s.wrapperKey(fmt.Sprintf("sessions:%s", userID))
+}
+
+// SetSession ...
+func (s *Store) SetSession(ctx context.Context, session *auth.Session, expiration time.Duration) error {
+	buf, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	setKey := s.sessionSetKey(session.UserID)
+	pipe := s.cli.TxPipeline()
+	pipe.Set(s.sessionKey(session.UserID, session.ID), buf, expiration)
+	pipe.SAdd(setKey, session.ID)
+	if expiration > 0 {
+		// 集合的有效期不短于其中最晚到期的会话
+		ttl := pipe.TTL(setKey)
+		_, err = pipe.Exec()
+		if err != nil {
+			return err
+		}
+		if ttl.Val() < expiration {
+			return s.cli.Expire(setKey, expiration).Err()
+		}
+		return nil
+	}
+	_, err = pipe.Exec()
+	return err
+}
+
+// GetSession ...
+func (s *Store) GetSession(ctx context.Context, userID, sessionID string) (*auth.Session, error) {
+	cmd := s.cli.Get(s.sessionKey(userID, sessionID))
+	if err := cmd.Err(); err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	session := new(auth.Session)
+	err := json.Unmarshal([]byte(cmd.Val()), session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// QuerySessions ...
+func (s *Store) QuerySessions(ctx context.Context, userID string) ([]*auth.Session, error) {
+	cmd := s.cli.SMembers(s.sessionSetKey(userID))
+	if err := cmd.Err(); err != nil {
+		return nil, err
+	}
+
+	var sessions []*auth.Session
+	for _, sessionID := range cmd.Val() {
+		session, err := s.GetSession(ctx, userID, sessionID)
+		if err != nil {
+			return nil, err
+		} else if session == nil {
+			// 清理已过期的会话
+			s.cli.SRem(s.sessionSetKey(userID), sessionID)
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// DeleteSession ...
+func (s *Store) DeleteSession(ctx context.Context, userID, sessionID string) error {
+	pipe := s.cli.TxPipeline()
+	pipe.Del(s.sessionKey(userID, sessionID))
+	pipe.SRem(s.sessionSetKey(userID), sessionID)
+	_, err := pipe.Exec()
+	return err
+}
+
 // Close ...
 func (s *Store) Close() error {
 	return s.cli.Close()
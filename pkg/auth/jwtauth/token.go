@@ -9,6 +9,9 @@ type tokenInfo struct {
 	AccessToken string `json:"access_token"` // 访问令牌
 	TokenType   string `json:"token_type"`   // 令牌类型
 	ExpiresAt   int64  `json:"expires_at"`   // 令牌到期时间
+
+	RefreshToken     string `json:"refresh_token"`      // 刷新令牌
+	RefreshExpiresAt int64  `json:"refresh_expires_at"` // 刷新令牌到期时间
 }
 
 func (t *tokenInfo) GetAccessToken() string {
@@ -23,6 +26,14 @@ func (t *tokenInfo) GetExpiresAt() int64 {
 	return t.ExpiresAt
 }
 
+func (t *tokenInfo) GetRefreshToken() string {
+	return t.RefreshToken
+}
+
+func (t *tokenInfo) GetRefreshExpiresAt() int64 {
+	return t.RefreshExpiresAt
+}
+
 func (t *tokenInfo) EncodeToJSON() ([]byte, error) {
 	return json.Marshal(t)
 }
@@ -0,0 +1,138 @@
+package counter
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Counter 带有效期的计数器(用于统计登录失败次数等)
+type Counter interface {
+	// 计数加1并返回当前值(首次计数时设定有效期)
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	// 获取当前计数值(不存在或已过期时返回0)
+	Get(ctx context.Context, key string) (int64, error)
+	// 清除计数
+	Reset(ctx context.Context, key string) error
+	// 查询指定前缀的全部有效计数
+	Scan(ctx context.Context, prefix string) ([]*Item, error)
+	// 关闭存储
+	Close() error
+}
+
+// Item 计数项
+type Item struct {
+	Key       string    // 计数键
+	Value     int64     // 计数值
+	ExpiresAt time.Time // 到期时间(为零值则永不过期)
+}
+
+// 内存计数器的过期清理间隔
+const gcInterval = time.Minute
+
+type memoryItem struct {
+	value     int64
+	expiresAt time.Time
+}
+
+func (a *memoryItem) expired(now time.Time) bool {
+	return !a.expiresAt.IsZero() && !now.Before(a.expiresAt)
+}
+
+// NewMemoryCounter 创建基于内存的计数器
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{
+		items: make(map[string]*memoryItem),
+	}
+}
+
+// MemoryCounter 内存计数器(仅适用于单实例部署)
+type MemoryCounter struct {
+	lock   sync.Mutex
+	items  map[string]*memoryItem
+	lastGC time.Time
+}
+
+// Incr ...
+func (a *MemoryCounter) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+	a.gc(now)
+
+	item, ok := a.items[key]
+	if !ok || item.expired(now) {
+		item = &memoryItem{}
+		if expiration > 0 {
+			item.expiresAt = now.Add(expiration)
+		}
+		a.items[key] = item
+	}
+	item.value++
+	return item.value, nil
+}
+
+// Get ...
+func (a *MemoryCounter) Get(ctx context.Context, key string) (int64, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	item, ok := a.items[key]
+	if !ok || item.expired(time.Now()) {
+		return 0, nil
+	}
+	return item.value, nil
+}
+
+// Reset ...
+func (a *MemoryCounter) Reset(ctx context.Context, key string) error {
+	a.lock.Lock()
+	delete(a.items, key)
+	a.lock.Unlock()
+	return nil
+}
+
+// Scan ...
+func (a *MemoryCounter) Scan(ctx context.Context, prefix string) ([]*Item, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+	var items []*Item
+	for key, item := range a.items {
+		if !strings.HasPrefix(key, prefix) || item.expired(now) {
+			continue
+		}
+		items = append(items, &Item{
+			Key:       key,
+			Value:     item.value,
+			ExpiresAt: item.expiresAt,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Key < items[j].Key
+	})
+	return items, nil
+}
+
+// 清理过期的计数
+func (a *MemoryCounter) gc(now time.Time) {
+	if now.Sub(a.lastGC) < gcInterval {
+		return
+	}
+	a.lastGC = now
+
+	for key, item := range a.items {
+		if item.expired(now) {
+			delete(a.items, key)
+		}
+	}
+}
+
+// Close ...
+func (a *MemoryCounter) Close() error {
+	return nil
+}
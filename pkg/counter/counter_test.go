@@ -0,0 +1,54 @@
+package counter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCounter(t *testing.T) {
+	c := NewMemoryCounter()
+	defer c.Close()
+
+	ctx := context.Background()
+	for i := int64(1); i <= 3; i++ {
+		n, err := c.Incr(ctx, "foo", time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, i, n)
+	}
+
+	n, err := c.Get(ctx, "foo")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+
+	err = c.Reset(ctx, "foo")
+	assert.Nil(t, err)
+
+	n, err = c.Get(ctx, "foo")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	_, err = c.Incr(ctx, "bar", 10*time.Millisecond)
+	assert.Nil(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	n, err = c.Get(ctx, "bar")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	n, err = c.Incr(ctx, "bar", time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+
+	_, err = c.Incr(ctx, "lock:foo", 0)
+	assert.Nil(t, err)
+
+	items, err := c.Scan(ctx, "lock:")
+	assert.Nil(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "lock:foo", items[0].Key)
+		assert.True(t, items[0].ExpiresAt.IsZero())
+	}
+}
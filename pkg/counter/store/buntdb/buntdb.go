@@ -0,0 +1,145 @@
+package buntdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/buntdb"
+	"github.com/wangwei518/gin-admin/pkg/counter"
+)
+
+// NewStore 创建基于buntdb的计数器存储
+func NewStore(path string) (*Store, error) {
+	if path != ":memory:" {
+		os.MkdirAll(filepath.Dir(path), 0777)
+	}
+
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		db: db,
+	}, nil
+}
+
+// NewStoreWithDB 使用已打开的buntdb实例创建存储
+func NewStoreWithDB(db *buntdb.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// Store buntdb存储
+type Store struct {
+	db *buntdb.DB
+}
+
+// Incr ...
+func (a *Store) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	var value int64
+	err := a.db.Update(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(key)
+		if err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+
+		// 保留首次计数时设定的有效期
+		ttl := expiration
+		if err == nil {
+			value, _ = strconv.ParseInt(val, 10, 64)
+			if d, err := tx.TTL(key); err == nil && d > 0 {
+				ttl = d
+			}
+		}
+		value++
+
+		var opts *buntdb.SetOptions
+		if ttl > 0 {
+			opts = &buntdb.SetOptions{Expires: true, TTL: ttl}
+		}
+		_, _, err = tx.Set(key, strconv.FormatInt(value, 10), opts)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// Get ...
+func (a *Store) Get(ctx context.Context, key string) (int64, error) {
+	var value int64
+	err := a.db.View(func(tx *buntdb.Tx) error {
+		val, err := tx.Get(key)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		value, _ = strconv.ParseInt(val, 10, 64)
+		return nil
+	})
+	return value, err
+}
+
+// Reset ...
+func (a *Store) Reset(ctx context.Context, key string) error {
+	return a.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(key)
+		if err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+		return nil
+	})
+}
+
+// Scan ...
+func (a *Store) Scan(ctx context.Context, prefix string) ([]*counter.Item, error) {
+	var items []*counter.Item
+	err := a.db.View(func(tx *buntdb.Tx) error {
+		now := time.Now()
+		var err error
+		ierr := tx.AscendGreaterOrEqual("", prefix, func(key, val string) bool {
+			if !strings.HasPrefix(key, prefix) {
+				return false
+			}
+
+			ttl, terr := tx.TTL(key)
+			if terr != nil {
+				if terr == buntdb.ErrNotFound {
+					return true
+				}
+				err = terr
+				return false
+			}
+
+			item := &counter.Item{Key: key}
+			item.Value, _ = strconv.ParseInt(val, 10, 64)
+			if ttl > 0 {
+				item.ExpiresAt = now.Add(ttl)
+			}
+			items = append(items, item)
+			return true
+		})
+		if ierr != nil {
+			return ierr
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Close ...
+func (a *Store) Close() error {
+	return a.db.Close()
+}
@@ -0,0 +1,54 @@
+package buntdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	store, err := NewStore(":memory:")
+	assert.Nil(t, err)
+
+	defer store.Close()
+
+	ctx := context.Background()
+	for i := int64(1); i <= 3; i++ {
+		n, err := store.Incr(ctx, "foo", time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, i, n)
+	}
+
+	n, err := store.Get(ctx, "foo")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), n)
+
+	err = store.Reset(ctx, "foo")
+	assert.Nil(t, err)
+
+	n, err = store.Get(ctx, "foo")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), n)
+
+	err = store.Reset(ctx, "foo")
+	assert.Nil(t, err)
+
+	_, err = store.Incr(ctx, "lock:foo", time.Minute)
+	assert.Nil(t, err)
+	_, err = store.Incr(ctx, "lock:bar", 0)
+	assert.Nil(t, err)
+	_, err = store.Incr(ctx, "locked", 0)
+	assert.Nil(t, err)
+
+	items, err := store.Scan(ctx, "lock:")
+	assert.Nil(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, "lock:bar", items[0].Key)
+		assert.True(t, items[0].ExpiresAt.IsZero())
+		assert.Equal(t, "lock:foo", items[1].Key)
+		assert.Equal(t, int64(1), items[1].Value)
+		assert.False(t, items[1].ExpiresAt.IsZero())
+	}
+}
@@ -0,0 +1,169 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/wangwei518/gin-admin/pkg/counter"
+)
+
+// Config redis配置参数
+type Config struct {
+	Addr      string // 地址(IP:Port)
+	DB        int    // 数据库
+	Password  string // 密码
+	KeyPrefix string // 存储key的前缀
+}
+
+// NewStore 创建基于redis的计数器存储
+func NewStore(cfg *Config) *Store {
+	cli := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		DB:       cfg.DB,
+		Password: cfg.Password,
+	})
+	return &Store{
+		cli:    cli,
+		prefix: cfg.KeyPrefix,
+	}
+}
+
+// NewStoreWithClient 使用redis客户端创建存储实例
+func NewStoreWithClient(cli *redis.Client, keyPrefix string) *Store {
+	return &Store{
+		cli:    cli,
+		prefix: keyPrefix,
+	}
+}
+
+// NewStoreWithClusterClient 使用redis集群客户端创建存储实例
+func NewStoreWithClusterClient(cli *redis.ClusterClient, keyPrefix string) *Store {
+	return &Store{
+		cli:    cli,
+		prefix: keyPrefix,
+	}
+}
+
+type redisClienter interface {
+	Get(key string) *redis.StringCmd
+	Incr(key string) *redis.IntCmd
+	Expire(key string, expiration time.Duration) *redis.BoolCmd
+	Del(keys ...string) *redis.IntCmd
+	Scan(cursor uint64, match string, count int64) *redis.ScanCmd
+	TTL(key string) *redis.DurationCmd
+	Close() error
+}
+
+// Store redis存储
+type Store struct {
+	cli    redisClienter
+	prefix string
+}
+
+func (s *Store) wrapperKey(key string) string {
+	return fmt.Sprintf("%s%s", s.prefix, key)
+}
+
+// Incr ...
+func (s *Store) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	key = s.wrapperKey(key)
+	value, err := s.cli.Incr(key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// 首次计数时设定有效期
+	if value == 1 && expiration > 0 {
+		if err := s.cli.Expire(key, expiration).Err(); err != nil {
+			return 0, err
+		}
+	}
+	return value, nil
+}
+
+// Get ...
+func (s *Store) Get(ctx context.Context, key string) (int64, error) {
+	value, err := s.cli.Get(s.wrapperKey(key)).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return value, nil
+}
+
+// Reset ...
+func (s *Store) Reset(ctx context.Context, key string) error {
+	return s.cli.Del(s.wrapperKey(key)).Err()
+}
+
+// Scan 查询指定前缀的全部计数(集群模式下仅扫描单个节点)
+func (s *Store) Scan(ctx context.Context, prefix string) ([]*counter.Item, error) {
+	var (
+		items  []*counter.Item
+		cursor uint64
+	)
+
+	match := s.wrapperKey(escapePattern(prefix)) + "*"
+	for {
+		keys, next, err := s.cli.Scan(cursor, match, 100).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			value, err := s.cli.Get(key).Int64()
+			if err != nil {
+				if err == redis.Nil {
+					continue
+				}
+				return nil, err
+			}
+
+			item := &counter.Item{
+				Key:   strings.TrimPrefix(key, s.prefix),
+				Value: value,
+			}
+			ttl, err := s.cli.TTL(key).Result()
+			if err != nil {
+				return nil, err
+			} else if ttl > 0 {
+				item.ExpiresAt = time.Now().Add(ttl)
+			}
+			items = append(items, item)
+		}
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Key < items[j].Key
+	})
+	return items, nil
+}
+
+// 转义redis匹配模式中的特殊字符
+func escapePattern(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			buf.WriteRune('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// Close ...
+func (s *Store) Close() error {
+	return s.cli.Close()
+}
@@ -24,9 +24,12 @@ var (
 	ErrInvalidPassword         = New400Response("无效的密码")
 	ErrInvalidUser             = New400Response("无效的用户")
 	ErrUserDisable             = New400Response("用户被禁用，请联系管理员")
+	ErrInvalidMFACode          = New400Response("无效的动态验证码")
 
 	ErrNoPerm          = NewResponse(401, "无访问权限", 401)
 	ErrInvalidToken    = NewResponse(9999, "令牌失效", 401)
+	ErrInvalidCaptcha  = NewResponse(9998, "无效的验证码", 400)
+	ErrLoginLocked     = NewResponse(9997, "登录失败次数过多，已被临时锁定，请稍后再试", 429)
 	ErrNotFound        = NewResponse(404, "资源不存在", 404)
 	ErrMethodNotAllow  = NewResponse(405, "方法不被允许", 405)
 	ErrTooManyRequests = NewResponse(429, "请求过于频繁", 429)
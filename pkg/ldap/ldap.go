@@ -0,0 +1,332 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+)
+
+// 定义错误
+var (
+	ErrUserNotFound       = errors.New("ldap: user not found")
+	ErrMultipleEntries    = errors.New("ldap: multiple entries matched")
+	ErrInvalidCredentials = errors.New("ldap: invalid credentials")
+)
+
+// Entry LDAP条目
+type Entry = ldapv3.Entry
+
+// Config LDAP客户端配置
+type Config struct {
+	Addr               string        // 服务地址(ldap://host:389 或 ldaps://host:636)
+	StartTLS           bool          // 是否在ldap://连接上启用StartTLS
+	InsecureSkipVerify bool          // 是否跳过证书校验
+	CAFile             string        // CA证书文件(PEM)
+	BindDN             string        // 用于搜索用户的服务账号DN(为空则匿名绑定)
+	BindPassword       string        // 服务账号密码
+	BaseDN             string        // 用户搜索的基础DN
+	UserFilter         string        // 用户搜索过滤器(%s为转义后的用户名)
+	Attributes         []string      // 搜索时需要返回的属性
+	GroupAttribute     string        // 用户条目中记录所属组的属性(未设置GroupBaseDN时使用)
+	GroupBaseDN        string        // 组搜索的基础DN(为空则读取GroupAttribute属性)
+	GroupFilter        string        // 组搜索过滤器(%s为转义后的用户DN)
+	DialTimeout        time.Duration // 连接超时时间
+	ReadTimeout        time.Duration // 请求超时时间
+	PoolSize           int           // 连接池大小
+}
+
+// 默认配置
+const (
+	DefaultUserFilter     = "(uid=%s)"
+	DefaultGroupAttribute = "memberOf"
+	DefaultGroupFilter    = "(member=%s)"
+)
+
+// New 创建LDAP客户端
+func New(cfg Config) (*Client, error) {
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = DefaultUserFilter
+	}
+	if cfg.GroupAttribute == "" {
+		cfg.GroupAttribute = DefaultGroupAttribute
+	}
+	if cfg.GroupFilter == "" {
+		cfg.GroupFilter = DefaultGroupFilter
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 1
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		cfg:       cfg,
+		tlsConfig: tlsConfig,
+		pool:      make(chan *ldapv3.Conn, cfg.PoolSize),
+	}, nil
+}
+
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if u, err := url.Parse(cfg.Addr); err == nil {
+		tlsConfig.ServerName = u.Hostname()
+	}
+
+	if cfg.CAFile != "" {
+		buf, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(buf) {
+			return nil, fmt.Errorf("ldap: no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// Client LDAP客户端(先以服务账号搜索用户，再以用户身份绑定)
+type Client struct {
+	cfg       Config
+	tlsConfig *tls.Config
+	pool      chan *ldapv3.Conn
+}
+
+// UserFilter 构建用户搜索过滤器
+func (c *Client) UserFilter(userName string) string {
+	return BuildFilter(c.cfg.UserFilter, userName)
+}
+
+// BuildFilter 使用转义后的值替换过滤器中的%s
+func BuildFilter(filter, value string) string {
+	return strings.Replace(filter, "%s", ldapv3.EscapeFilter(value), -1)
+}
+
+func (c *Client) dial() (*ldapv3.Conn, error) {
+	conn, err := ldapv3.DialURL(c.cfg.Addr,
+		ldapv3.DialWithDialer(&net.Dialer{Timeout: c.cfg.DialTimeout}),
+		ldapv3.DialWithTLSConfig(c.tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.cfg.ReadTimeout > 0 {
+		conn.SetTimeout(c.cfg.ReadTimeout)
+	}
+
+	if c.cfg.StartTLS && strings.HasPrefix(strings.ToLower(c.cfg.Addr), "ldap://") {
+		err = conn.StartTLS(c.tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	err = c.bindService(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c *Client) bindService(conn *ldapv3.Conn) error {
+	if c.cfg.BindDN == "" {
+		return conn.UnauthenticatedBind("")
+	}
+	return conn.Bind(c.cfg.BindDN, c.cfg.BindPassword)
+}
+
+// 从连接池获取已使用服务账号绑定的连接
+func (c *Client) get() (*ldapv3.Conn, error) {
+	for {
+		select {
+		case conn := <-c.pool:
+			if conn.IsClosing() {
+				conn.Close()
+				continue
+			}
+			return conn, nil
+		default:
+			return c.dial()
+		}
+	}
+}
+
+// 归还连接，连接池已满时关闭
+func (c *Client) put(conn *ldapv3.Conn) {
+	if conn.IsClosing() {
+		conn.Close()
+		return
+	}
+
+	select {
+	case c.pool <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// Search 以服务账号搜索用户条目
+func (c *Client) Search(ctx context.Context, userName string) (*Entry, error) {
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := c.search(conn, userName)
+	if err != nil && err != ErrUserNotFound && err != ErrMultipleEntries {
+		conn.Close()
+		return nil, err
+	}
+	c.put(conn)
+	return entry, err
+}
+
+func (c *Client) searchAttributes() []string {
+	attrs := append([]string{"dn"}, c.cfg.Attributes...)
+	if c.cfg.GroupBaseDN == "" {
+		attrs = append(attrs, c.cfg.GroupAttribute)
+	}
+	return attrs
+}
+
+func (c *Client) search(conn *ldapv3.Conn, userName string) (*Entry, error) {
+	timeLimit := int(c.cfg.ReadTimeout / time.Second)
+	req := ldapv3.NewSearchRequest(
+		c.cfg.BaseDN,
+		ldapv3.ScopeWholeSubtree,
+		ldapv3.NeverDerefAliases,
+		2,
+		timeLimit,
+		false,
+		c.UserFilter(userName),
+		c.searchAttributes(),
+		nil,
+	)
+
+	result, err := conn.Search(req)
+	if err != nil {
+		if ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultSizeLimitExceeded) {
+			return nil, ErrMultipleEntries
+		}
+		return nil, err
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return result.Entries[0], nil
+	default:
+		return nil, ErrMultipleEntries
+	}
+}
+
+// Authenticate 搜索用户条目并以用户DN和密码绑定校验
+func (c *Client) Authenticate(ctx context.Context, userName, password string) (*Entry, error) {
+	if password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := c.search(conn, userName)
+	if err != nil {
+		if err == ErrUserNotFound || err == ErrMultipleEntries {
+			c.put(conn)
+		} else {
+			conn.Close()
+		}
+		return nil, err
+	}
+
+	err = conn.Bind(entry.DN, password)
+	if err != nil {
+		if !ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultInvalidCredentials) {
+			conn.Close()
+			return nil, err
+		}
+		err = ErrInvalidCredentials
+	}
+
+	// 恢复为服务账号身份后再放回连接池
+	if berr := c.bindService(conn); berr != nil {
+		conn.Close()
+	} else {
+		c.put(conn)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Groups 查询用户所属组的DN列表
+func (c *Client) Groups(ctx context.Context, entry *Entry) ([]string, error) {
+	if c.cfg.GroupBaseDN == "" {
+		return entry.GetAttributeValues(c.cfg.GroupAttribute), nil
+	}
+
+	conn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+
+	req := ldapv3.NewSearchRequest(
+		c.cfg.GroupBaseDN,
+		ldapv3.ScopeWholeSubtree,
+		ldapv3.NeverDerefAliases,
+		0,
+		int(c.cfg.ReadTimeout/time.Second),
+		false,
+		BuildFilter(c.cfg.GroupFilter, entry.DN),
+		[]string{"dn"},
+		nil,
+	)
+	result, err := conn.Search(req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.put(conn)
+
+	groups := make([]string, len(result.Entries))
+	for i, item := range result.Entries {
+		groups[i] = item.DN
+	}
+	return groups, nil
+}
+
+// Close 关闭连接池中的所有连接
+func (c *Client) Close() error {
+	for {
+		select {
+		case conn := <-c.pool:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
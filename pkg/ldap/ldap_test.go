@@ -0,0 +1,49 @@
+package ldap
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildFilter(t *testing.T) {
+	assert.Equal(t, "(uid=admin)", BuildFilter(DefaultUserFilter, "admin"))
+	assert.Equal(t, `(sAMAccountName=a\2a\29\28b)`, BuildFilter("(sAMAccountName=%s)", "a*)(b"))
+	assert.Equal(t, "(|(uid=tom)(mail=tom))", BuildFilter("(|(uid=%s)(mail=%s))", "tom"))
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(Config{Addr: "ldaps://ldap.example.com:636"})
+	assert.Nil(t, err)
+	assert.Equal(t, "ldap.example.com", c.tlsConfig.ServerName)
+	assert.Equal(t, DefaultUserFilter, c.cfg.UserFilter)
+	assert.Equal(t, 1, cap(c.pool))
+	assert.Equal(t, []string{"dn", DefaultGroupAttribute}, c.searchAttributes())
+
+	entry := &Entry{
+		DN: "uid=tom,ou=people,dc=example,dc=com",
+		Attributes: []*ldapv3.EntryAttribute{
+			{Name: "memberOf", Values: []string{"cn=admins,ou=groups,dc=example,dc=com"}},
+		},
+	}
+	groups, err := c.Groups(context.Background(), entry)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"cn=admins,ou=groups,dc=example,dc=com"}, groups)
+
+	dir, err := ioutil.TempDir("", "ldap")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, ioutil.WriteFile(caFile, []byte("invalid"), 0644))
+	_, err = New(Config{Addr: "ldap://127.0.0.1:389", CAFile: caFile})
+	assert.NotNil(t, err)
+
+	_, err = New(Config{Addr: "ldap://127.0.0.1:389", CAFile: filepath.Join(dir, "none.pem")})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,206 @@
+package lockout
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/wangwei518/gin-admin/pkg/counter"
+)
+
+// 锁定对象的类型
+const (
+	TypeUser = "user"
+	TypeIP   = "ip"
+)
+
+// 计数键前缀
+const (
+	failureKeyPrefix = "login_failure:"
+	lockKeyPrefix    = "login_lock:"
+)
+
+// Config 登录锁定配置
+type Config struct {
+	UserThreshold  int           // 同一用户名登录失败达到该次数后锁定(为0则不锁定)
+	IPThreshold    int           // 同一IP登录失败达到该次数后锁定(为0则不锁定)
+	Window         time.Duration // 登录失败次数的统计周期
+	LockDuration   time.Duration // 锁定时长
+	DelayThreshold int           // 同一用户名登录失败达到该次数后开始延迟响应(为0则不延迟)
+	DelayStep      time.Duration // 首次延迟时长(此后每次失败加倍)
+	MaxDelay       time.Duration // 最大延迟时长
+}
+
+// Lock 锁定信息
+type Lock struct {
+	Type      string    // 锁定对象的类型(user/ip)
+	Name      string    // 用户名或IP
+	ExpiresAt time.Time // 解锁时间
+}
+
+// New 创建登录锁定器
+func New(c counter.Counter, cfg Config) *Locker {
+	return &Locker{
+		counter: c,
+		cfg:     cfg,
+	}
+}
+
+// Locker 登录锁定器(按用户名及客户端IP统计登录失败次数)
+type Locker struct {
+	counter counter.Counter
+	cfg     Config
+}
+
+func failureKey(typ, name string) string {
+	return failureKeyPrefix + typ + ":" + name
+}
+
+func lockKey(typ, name string) string {
+	return lockKeyPrefix + typ + ":" + name
+}
+
+// 返回需要统计失败次数的对象
+func targets(userName, ip string) map[string]string {
+	targets := make(map[string]string)
+	if userName != "" {
+		targets[TypeUser] = userName
+	}
+	if ip != "" {
+		targets[TypeIP] = ip
+	}
+	return targets
+}
+
+func (a *Locker) threshold(typ string) int64 {
+	if typ == TypeIP {
+		return int64(a.cfg.IPThreshold)
+	}
+	return int64(a.cfg.UserThreshold)
+}
+
+// Check 检查用户名或IP是否处于锁定状态(未锁定时返回nil)
+func (a *Locker) Check(ctx context.Context, userName, ip string) (*Lock, error) {
+	for _, typ := range []string{TypeUser, TypeIP} {
+		name, ok := targets(userName, ip)[typ]
+		if !ok {
+			continue
+		}
+
+		n, err := a.counter.Get(ctx, lockKey(typ, name))
+		if err != nil {
+			return nil, err
+		} else if n == 0 {
+			continue
+		}
+
+		lock := &Lock{Type: typ, Name: name}
+		items, err := a.counter.Scan(ctx, lockKey(typ, name))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.Key == lockKey(typ, name) {
+				lock.ExpiresAt = item.ExpiresAt
+			}
+		}
+		return lock, nil
+	}
+	return nil, nil
+}
+
+// Fail 记录一次登录失败，返回应延迟响应的时长，达到阈值时返回锁定信息
+func (a *Locker) Fail(ctx context.Context, userName, ip string) (time.Duration, *Lock, error) {
+	var (
+		delay time.Duration
+		lock  *Lock
+	)
+
+	for typ, name := range targets(userName, ip) {
+		n, err := a.counter.Incr(ctx, failureKey(typ, name), a.cfg.Window)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if typ == TypeUser {
+			delay = a.delay(n)
+		}
+
+		if threshold := a.threshold(typ); threshold <= 0 || n < threshold {
+			continue
+		}
+
+		_, err = a.counter.Incr(ctx, lockKey(typ, name), a.cfg.LockDuration)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		// 锁定后重新统计失败次数
+		err = a.counter.Reset(ctx, failureKey(typ, name))
+		if err != nil {
+			return 0, nil, err
+		}
+
+		lock = &Lock{Type: typ, Name: name}
+		if a.cfg.LockDuration > 0 {
+			lock.ExpiresAt = time.Now().Add(a.cfg.LockDuration)
+		}
+	}
+
+	return delay, lock, nil
+}
+
+// 计算第n次失败的延迟时长(超过阈值后每次加倍)
+func (a *Locker) delay(n int64) time.Duration {
+	if n < int64(a.cfg.DelayThreshold) || a.cfg.DelayStep <= 0 {
+		return 0
+	}
+
+	d := a.cfg.DelayStep
+	for i := int64(a.cfg.DelayThreshold); i < n; i++ {
+		d *= 2
+		if a.cfg.MaxDelay > 0 && d >= a.cfg.MaxDelay {
+			return a.cfg.MaxDelay
+		}
+	}
+
+	return d
+}
+
+// Succeed 登录成功后清除用户名的失败次数(IP的失败次数不清除，防止利用有效账号重置)
+func (a *Locker) Succeed(ctx context.Context, userName string) error {
+	return a.counter.Reset(ctx, failureKey(TypeUser, userName))
+}
+
+// Query 查询全部锁定信息
+func (a *Locker) Query(ctx context.Context) ([]*Lock, error) {
+	items, err := a.counter.Scan(ctx, lockKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	locks := make([]*Lock, 0, len(items))
+	for _, item := range items {
+		key := strings.TrimPrefix(item.Key, lockKeyPrefix)
+		i := strings.Index(key, ":")
+		if i < 0 {
+			continue
+		}
+		locks = append(locks, &Lock{
+			Type:      key[:i],
+			Name:      key[i+1:],
+			ExpiresAt: item.ExpiresAt,
+		})
+	}
+	return locks, nil
+}
+
+// Unlock 解除锁定并清除失败次数
+func (a *Locker) Unlock(ctx context.Context, typ, name string) error {
+	err := a.counter.Reset(ctx, lockKey(typ, name))
+	if err != nil {
+		return err
+	}
+
+	return a.counter.Reset(ctx, failureKey(typ, name))
+}
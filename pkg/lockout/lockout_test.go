@@ -0,0 +1,84 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/pkg/counter"
+)
+
+func TestLocker(t *testing.T) {
+	locker := New(counter.NewMemoryCounter(), Config{
+		UserThreshold:  3,
+		IPThreshold:    5,
+		Window:         time.Minute,
+		LockDuration:   time.Minute,
+		DelayThreshold: 2,
+		DelayStep:      100 * time.Millisecond,
+		MaxDelay:       150 * time.Millisecond,
+	})
+
+	ctx := context.Background()
+	delays := []time.Duration{0, 100 * time.Millisecond, 150 * time.Millisecond}
+	for i, expected := range delays {
+		delay, lock, err := locker.Fail(ctx, "tom", "10.0.0.1")
+		assert.Nil(t, err)
+		assert.Equal(t, expected, delay)
+		if i < len(delays)-1 {
+			assert.Nil(t, lock)
+		} else if assert.NotNil(t, lock) {
+			assert.Equal(t, TypeUser, lock.Type)
+			assert.Equal(t, "tom", lock.Name)
+		}
+	}
+
+	lock, err := locker.Check(ctx, "tom", "10.0.0.2")
+	assert.Nil(t, err)
+	if assert.NotNil(t, lock) {
+		assert.Equal(t, TypeUser, lock.Type)
+		assert.False(t, lock.ExpiresAt.IsZero())
+	}
+
+	lock, err = locker.Check(ctx, "jerry", "10.0.0.1")
+	assert.Nil(t, err)
+	assert.Nil(t, lock)
+
+	// 同一IP累计失败达到阈值后锁定IP
+	for i := 0; i < 2; i++ {
+		_, lock, err = locker.Fail(ctx, "jerry", "10.0.0.1")
+		assert.Nil(t, err)
+	}
+	if assert.NotNil(t, lock) {
+		assert.Equal(t, TypeIP, lock.Type)
+	}
+
+	lock, err = locker.Check(ctx, "jerry", "10.0.0.1")
+	assert.Nil(t, err)
+	if assert.NotNil(t, lock) {
+		assert.Equal(t, "10.0.0.1", lock.Name)
+	}
+
+	locks, err := locker.Query(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, locks, 2)
+
+	err = locker.Unlock(ctx, TypeIP, "10.0.0.1")
+	assert.Nil(t, err)
+	err = locker.Unlock(ctx, TypeUser, "tom")
+	assert.Nil(t, err)
+
+	locks, err = locker.Query(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, locks, 0)
+
+	// 登录成功后清除用户名的失败次数
+	_, _, err = locker.Fail(ctx, "tom", "")
+	assert.Nil(t, err)
+	err = locker.Succeed(ctx, "tom")
+	assert.Nil(t, err)
+	delay, _, err := locker.Fail(ctx, "tom", "")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), delay)
+}
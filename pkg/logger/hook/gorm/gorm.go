@@ -62,6 +62,10 @@ func (h *Hook) Exec(entry *logrus.Entry) error {
 		item.UserID, _ = v.(string)
 		delete(data, logger.UserIDKey)
 	}
+	if v, ok := data[logger.ActorIDKey]; ok {
+		item.ActorID, _ = v.(string)
+		delete(data, logger.ActorIDKey)
+	}
 	if v, ok := data[logger.SpanTitleKey]; ok {
 		item.SpanTitle, _ = v.(string)
 		delete(data, logger.SpanTitleKey)
@@ -98,6 +102,7 @@ type LogItem struct {
 	Message      string    `gorm:"column:message;size:1024;"`             // 消息
 	TraceID      string    `gorm:"column:trace_id;size:128;index;"`       // 跟踪ID
 	UserID       string    `gorm:"column:user_id;size:36;index;"`         // 用户ID
+	ActorID      string    `gorm:"column:actor_id;size:36;index;"`        // 操作者ID(模拟登录时为实际操作的用户ID)
 	SpanTitle    string    `gorm:"column:span_title;size:256;"`           // 跟踪单元标题
 	SpanFunction string    `gorm:"column:span_function;size:256;"`        // 跟踪单元函数名
 	Data         string    `gorm:"column:data;type:text;"`                // 日志数据(json)
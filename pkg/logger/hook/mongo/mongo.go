@@ -71,6 +71,10 @@ func (h *Hook) Exec(entry *logrus.Entry) error {
 		item.UserID, _ = v.(string)
 		delete(data, logger.UserIDKey)
 	}
+	if v, ok := data[logger.ActorIDKey]; ok {
+		item.ActorID, _ = v.(string)
+		delete(data, logger.ActorIDKey)
+	}
 	if v, ok := data[logger.SpanTitleKey]; ok {
 		item.SpanTitle, _ = v.(string)
 		delete(data, logger.SpanTitleKey)
@@ -104,6 +108,7 @@ type LogItem struct {
 	Message      string    `bson:"message"`       // 消息
 	TraceID      string    `bson:"trace_id"`      // 跟踪ID
 	UserID       string    `bson:"user_id"`       // 用户ID
+	ActorID      string    `bson:"actor_id"`      // 操作者ID(模拟登录时为实际操作的用户ID)
 	SpanTitle    string    `bson:"span_title"`    // 跟踪单元标题
 	SpanFunction string    `bson:"span_function"` // 跟踪单元函数名
 	Data         string    `bson:"data"`          // 日志数据(json)
@@ -11,6 +11,7 @@ import (
 const (
 	TraceIDKey      = "trace_id"
 	UserIDKey       = "user_id"
+	ActorIDKey      = "actor_id"
 	SpanTitleKey    = "span_title"
 	SpanFunctionKey = "span_function"
 	VersionKey      = "version"
@@ -80,6 +81,7 @@ func getTraceID() string {
 type (
 	traceIDContextKey struct{}
 	userIDContextKey  struct{}
+	actorIDContextKey struct{}
 )
 
 // NewTraceIDContext 创建跟踪ID上下文
@@ -114,6 +116,22 @@ func FromUserIDContext(ctx context.Context) string {
 	return ""
 }
 
+// NewActorIDContext 创建操作者ID上下文(模拟登录时为实际操作的用户ID)
+func NewActorIDContext(ctx context.Context, actorID string) context.Context {
+	return context.WithValue(ctx, actorIDContextKey{}, actorID)
+}
+
+// FromActorIDContext 从上下文中获取操作者ID
+func FromActorIDContext(ctx context.Context) string {
+	v := ctx.Value(actorIDContextKey{})
+	if v != nil {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
 type spanOptions struct {
 	Title    string
 	FuncName string
@@ -156,6 +174,9 @@ func StartSpan(ctx context.Context, opts ...SpanOption) *Entry {
 	if v := FromUserIDContext(ctx); v != "" {
 		fields[UserIDKey] = v
 	}
+	if v := FromActorIDContext(ctx); v != "" {
+		fields[ActorIDKey] = v
+	}
 	if v := o.Title; v != "" {
 		fields[SpanTitleKey] = v
 	}
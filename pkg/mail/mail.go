@@ -0,0 +1,95 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/wangwei518/gin-admin/pkg/logger"
+)
+
+// Message 邮件
+type Message struct {
+	From    string    `json:"from"`    // 发件人
+	To      []string  `json:"to"`      // 收件人列表
+	Subject string    `json:"subject"` // 主题
+	Body    string    `json:"body"`    // 正文(纯文本)
+	SentAt  time.Time `json:"sent_at"` // 发送时间
+}
+
+// Sender 邮件发送接口
+type Sender interface {
+	// 发送邮件
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewFileSender 创建文件发送器(将邮件逐行以JSON格式追加写入文件，用于开发及测试)
+func NewFileSender(path string) *FileSender {
+	return &FileSender{path: path}
+}
+
+// FileSender 文件发送器
+type FileSender struct {
+	lock sync.Mutex
+	path string
+}
+
+// Send 发送邮件
+func (s *FileSender) Send(ctx context.Context, msg *Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0777); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	msg.SentAt = time.Now()
+	return json.NewEncoder(f).Encode(msg)
+}
+
+// ReadFile 读取文件发送器写入的全部邮件
+func ReadFile(path string) ([]*Message, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var msgs []*Message
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		msg := new(Message)
+		if err := dec.Decode(msg); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// NewLogSender 创建日志发送器(仅将邮件内容输出到日志，用于开发及测试)
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// LogSender 日志发送器
+type LogSender struct{}
+
+// Send 发送邮件
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	msg.SentAt = time.Now()
+	logger.StartSpan(ctx, logger.SetSpanTitle("发送邮件"), logger.SetSpanFuncName("Send")).
+		Infof("收件人：%v，主题：%s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
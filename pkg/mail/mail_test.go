@@ -0,0 +1,62 @@
+package mail
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSender(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mail")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "mail", "outbox.log")
+	sender := NewFileSender(path)
+	for _, subject := range []string{"foo", "bar"} {
+		err = sender.Send(context.Background(), &Message{
+			From:    "noreply@example.com",
+			To:      []string{"user@example.com"},
+			Subject: subject,
+			Body:    "hello",
+		})
+		assert.Nil(t, err)
+	}
+
+	msgs, err := ReadFile(path)
+	assert.Nil(t, err)
+	if assert.Len(t, msgs, 2) {
+		assert.Equal(t, "foo", msgs[0].Subject)
+		assert.Equal(t, "bar", msgs[1].Subject)
+		assert.Equal(t, []string{"user@example.com"}, msgs[1].To)
+		assert.False(t, msgs[1].SentAt.IsZero())
+	}
+}
+
+func TestEncodeMessage(t *testing.T) {
+	body := strings.Repeat("重置密码", 20)
+	data := string(encodeMessage(&Message{
+		From:    "noreply@example.com",
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "重置密码",
+		Body:    body,
+	}))
+
+	assert.Contains(t, data, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, data, "Subject: =?UTF-8?b?")
+
+	parts := strings.SplitN(data, "\r\n\r\n", 2)
+	assert.Len(t, parts, 2)
+	for _, line := range strings.Split(strings.TrimSpace(parts[1]), "\r\n") {
+		assert.True(t, len(line) <= 76)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Replace(parts[1], "\r\n", "", -1))
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(decoded))
+}
@@ -0,0 +1,135 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// JSONWebKey JSON Web Key
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet JSON Web Key Set
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// 获取ID令牌的校验公钥(未找到时重新拉取身份提供方的公钥)
+func (c *Client) getKey(ctx context.Context, p *Provider, kid string) (interface{}, error) {
+	c.lock.RLock()
+	key, ok := c.lookupKey(kid)
+	refreshed := c.keysRefreshedAt
+	c.lock.RUnlock()
+	if ok {
+		return key, nil
+	} else if time.Since(refreshed) < keysRefreshInterval {
+		return nil, fmt.Errorf("oidc: key %q not found", kid)
+	}
+
+	var set JSONWebKeySet
+	err := c.getJSON(ctx, p.JWKSURI, nil, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, item := range set.Keys {
+		if item.Use != "" && item.Use != "sig" {
+			continue
+		}
+		pub, err := item.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[item.Kid] = pub
+	}
+
+	c.lock.Lock()
+	c.keys = keys
+	c.keysRefreshedAt = time.Now()
+	key, ok = c.lookupKey(kid)
+	c.lock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("oidc: key %q not found", kid)
+	}
+	return key, nil
+}
+
+// 未指定kid时仅在只有一个公钥的情况下使用该公钥
+func (c *Client) lookupKey(kid string) (interface{}, bool) {
+	if key, ok := c.keys[kid]; ok {
+		return key, true
+	} else if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// PublicKey 解析公钥(支持RSA及EC)
+func (k JSONWebKey) PublicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		} else if !e.IsInt64() {
+			return nil, errors.New("oidc: invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("oidc: unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		} else if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("oidc: invalid ec public key")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("oidc: unsupported key type %q", k.Kty)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,339 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// 定义错误
+var (
+	ErrInvalidIDToken = errors.New("oidc: invalid id token")
+	ErrInvalidNonce   = errors.New("oidc: invalid nonce")
+)
+
+// DefaultScopes 默认的授权范围
+var DefaultScopes = []string{"openid", "profile", "email"}
+
+// Config OIDC客户端配置
+type Config struct {
+	Issuer       string       // 身份提供方地址(用于发现配置及校验ID令牌的iss)
+	ClientID     string       // 客户端ID
+	ClientSecret string       // 客户端密钥(公共客户端仅使用PKCE时为空)
+	RedirectURL  string       // 授权回调地址
+	Scopes       []string     // 授权范围
+	HTTPClient   *http.Client // 请求身份提供方使用的HTTP客户端
+}
+
+// Provider 身份提供方配置(/.well-known/openid-configuration)
+type Provider struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserInfoEndpoint      string   `json:"userinfo_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	CodeChallengeMethods  []string `json:"code_challenge_methods_supported"`
+}
+
+// Token 授权码换取的令牌
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Claims ID令牌或用户信息的声明
+type Claims map[string]interface{}
+
+// String 获取字符串类型的声明
+func (c Claims) String(name string) string {
+	if v, ok := c[name].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// Strings 获取字符串数组类型的声明(单个字符串视为只有一个元素的数组)
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// New 创建OIDC客户端(首次使用时发现身份提供方配置)
+func New(cfg Config) *Client {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DefaultScopes
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{
+		cfg:  cfg,
+		keys: make(map[string]interface{}),
+	}
+}
+
+// Client OIDC客户端(授权码模式+PKCE)
+type Client struct {
+	cfg             Config
+	lock            sync.RWMutex
+	provider        *Provider
+	keys            map[string]interface{}
+	keysRefreshedAt time.Time
+}
+
+// 身份提供方公钥的最短刷新间隔
+const keysRefreshInterval = time.Minute
+
+func (c *Client) getJSON(ctx context.Context, rawurl string, header http.Header, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+	if err != nil {
+		return err
+	}
+	for k, vals := range header {
+		req.Header[k] = vals
+	}
+	req.Header.Set("Accept", "application/json")
+	return c.doJSON(req.WithContext(ctx), v)
+}
+
+func (c *Client) doJSON(req *http.Request, v interface{}) error {
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &e) == nil && e.Error != "" {
+			return fmt.Errorf("oidc: %s: %s %s", req.URL.Path, e.Error, e.Description)
+		}
+		return fmt.Errorf("oidc: %s: unexpected status %d", req.URL.Path, resp.StatusCode)
+	}
+	return json.Unmarshal(body, v)
+}
+
+// Provider 获取身份提供方配置
+func (c *Client) Provider(ctx context.Context) (*Provider, error) {
+	c.lock.RLock()
+	p := c.provider
+	c.lock.RUnlock()
+	if p != nil {
+		return p, nil
+	}
+
+	wellKnown := strings.TrimSuffix(c.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	p = new(Provider)
+	err := c.getJSON(ctx, wellKnown, nil, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Issuer != c.cfg.Issuer {
+		return nil, fmt.Errorf("oidc: issuer mismatch, expected %q got %q", c.cfg.Issuer, p.Issuer)
+	} else if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JWKSURI == "" {
+		return nil, errors.New("oidc: incomplete provider configuration")
+	}
+
+	c.lock.Lock()
+	c.provider = p
+	c.lock.Unlock()
+	return p, nil
+}
+
+// AuthCodeURL 生成授权请求地址
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	p, err := c.Provider(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	values := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.cfg.ClientID},
+		"redirect_uri":          {c.cfg.RedirectURL},
+		"scope":                 {strings.Join(c.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {CodeChallenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return p.AuthorizationEndpoint + sep + values.Encode(), nil
+}
+
+// Exchange 使用授权码及PKCE校验码换取令牌
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	p, err := c.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if c.cfg.ClientSecret == "" {
+		values.Set("client_id", c.cfg.ClientID)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.TokenEndpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+	}
+
+	token := new(Token)
+	err = c.doJSON(req.WithContext(ctx), token)
+	if err != nil {
+		return nil, err
+	} else if token.IDToken == "" {
+		return nil, errors.New("oidc: token response has no id_token")
+	}
+	return token, nil
+}
+
+// VerifyIDToken 校验ID令牌的签名、签发方、受众、有效期及nonce
+func (c *Client) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (Claims, error) {
+	p, err := c.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("oidc: unsupported signing method %s", t.Method.Alg())
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		return c.getKey(ctx, p, kid)
+	})
+	if err != nil {
+		return nil, ErrInvalidIDToken
+	}
+
+	result := Claims(claims)
+	if result.String("iss") != p.Issuer {
+		return nil, ErrInvalidIDToken
+	} else if !c.checkAudience(result) {
+		return nil, ErrInvalidIDToken
+	} else if _, ok := claims["exp"]; !ok {
+		return nil, ErrInvalidIDToken
+	} else if result.String("sub") == "" {
+		return nil, ErrInvalidIDToken
+	} else if result.String("nonce") != nonce {
+		return nil, ErrInvalidNonce
+	}
+	return result, nil
+}
+
+// 受众必须包含客户端ID，存在多个受众时azp必须为客户端ID
+func (c *Client) checkAudience(claims Claims) bool {
+	aud := claims.Strings("aud")
+	var found bool
+	for _, item := range aud {
+		if item == c.cfg.ClientID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	if azp := claims.String("azp"); azp != "" || len(aud) > 1 {
+		return azp == c.cfg.ClientID
+	}
+	return true
+}
+
+// UserInfo 使用访问令牌获取用户信息
+func (c *Client) UserInfo(ctx context.Context, accessToken string) (Claims, error) {
+	p, err := c.Provider(ctx)
+	if err != nil {
+		return nil, err
+	} else if p.UserInfoEndpoint == "" {
+		return nil, nil
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+accessToken)
+
+	claims := Claims{}
+	err = c.getJSON(ctx, p.UserInfoEndpoint, header, &claims)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// GenerateState 生成随机的state或nonce
+func GenerateState() (string, error) {
+	return randomString(16)
+}
+
+// GenerateCodeVerifier 生成PKCE校验码
+func GenerateCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// CodeChallenge 计算PKCE校验码的S256摘要
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,114 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/pkg/oidc"
+	"github.com/wangwei518/gin-admin/pkg/oidc/oidctest"
+)
+
+func TestClient(t *testing.T) {
+	idp := oidctest.NewServer("gin-admin")
+	defer idp.Close()
+
+	idp.SetClaims(map[string]interface{}{
+		"sub":                "1001",
+		"preferred_username": "tom",
+		"groups":             []string{"admins"},
+	})
+
+	c := oidc.New(oidc.Config{
+		Issuer:      idp.Issuer(),
+		ClientID:    "gin-admin",
+		RedirectURL: "http://127.0.0.1/callback",
+	})
+
+	ctx := context.Background()
+	state, err := oidc.GenerateState()
+	assert.Nil(t, err)
+	nonce, err := oidc.GenerateState()
+	assert.Nil(t, err)
+	verifier, err := oidc.GenerateCodeVerifier()
+	assert.Nil(t, err)
+
+	authURL, err := c.AuthCodeURL(ctx, state, nonce, verifier)
+	assert.Nil(t, err)
+
+	code, cbState, err := idp.Authorize(authURL)
+	assert.Nil(t, err)
+	assert.Equal(t, state, cbState)
+
+	// 错误的PKCE校验码
+	_, err = c.Exchange(ctx, code, "invalid")
+	assert.NotNil(t, err)
+
+	code, _, err = idp.Authorize(authURL)
+	assert.Nil(t, err)
+	token, err := c.Exchange(ctx, code, verifier)
+	assert.Nil(t, err)
+
+	_, err = c.VerifyIDToken(ctx, token.IDToken, "invalid")
+	assert.Equal(t, oidc.ErrInvalidNonce, err)
+
+	claims, err := c.VerifyIDToken(ctx, token.IDToken, nonce)
+	assert.Nil(t, err)
+	assert.Equal(t, "1001", claims.String("sub"))
+	assert.Equal(t, "tom", claims.String("preferred_username"))
+	assert.Equal(t, []string{"admins"}, claims.Strings("groups"))
+
+	userInfo, err := c.UserInfo(ctx, token.AccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, "tom", userInfo.String("preferred_username"))
+
+	now := time.Now()
+	for _, item := range []map[string]interface{}{
+		{"iss": "http://evil", "aud": "gin-admin", "sub": "1", "exp": now.Add(time.Minute).Unix()},
+		{"iss": idp.Issuer(), "aud": "other", "sub": "1", "exp": now.Add(time.Minute).Unix()},
+		{"iss": idp.Issuer(), "aud": []string{"gin-admin", "other"}, "sub": "1", "exp": now.Add(time.Minute).Unix()},
+		{"iss": idp.Issuer(), "aud": "gin-admin", "sub": "1", "exp": now.Add(-time.Minute).Unix()},
+		{"iss": idp.Issuer(), "aud": "gin-admin", "sub": "1"},
+	} {
+		raw, err := idp.SignIDToken(item)
+		assert.Nil(t, err)
+		_, err = c.VerifyIDToken(ctx, raw, "")
+		assert.Equal(t, oidc.ErrInvalidIDToken, err)
+	}
+
+	// 多个受众时azp必须为客户端ID
+	raw, err := idp.SignIDToken(map[string]interface{}{
+		"iss": idp.Issuer(), "aud": []string{"gin-admin", "other"}, "azp": "gin-admin",
+		"sub": "1", "exp": now.Add(time.Minute).Unix(),
+	})
+	assert.Nil(t, err)
+	_, err = c.VerifyIDToken(ctx, raw, "")
+	assert.Nil(t, err)
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	store := oidc.NewMemorySessionStore()
+	defer store.Close()
+
+	ctx := context.Background()
+	err := store.Set(ctx, "foo", &oidc.Session{Nonce: "n", CodeVerifier: "v"}, time.Minute)
+	assert.Nil(t, err)
+
+	session, err := store.Take(ctx, "foo")
+	assert.Nil(t, err)
+	if assert.NotNil(t, session) {
+		assert.Equal(t, "v", session.CodeVerifier)
+	}
+
+	session, err = store.Take(ctx, "foo")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	err = store.Set(ctx, "bar", &oidc.Session{}, time.Millisecond)
+	assert.Nil(t, err)
+	time.Sleep(5 * time.Millisecond)
+	session, err = store.Take(ctx, "bar")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+}
@@ -0,0 +1,83 @@
+package oidc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Session 授权请求的会话信息(以state为键保存，回调时取出)
+type Session struct {
+	Nonce        string            `json:"nonce"`
+	CodeVerifier string            `json:"code_verifier"`
+	Extra        map[string]string `json:"extra,omitempty"` // 发起方附加的数据(回调时原样取出)
+}
+
+// SessionStore 授权请求会话存储
+type SessionStore interface {
+	// 保存会话
+	Set(ctx context.Context, state string, session *Session, expiration time.Duration) error
+	// 取出并删除会话(不存在或已过期时返回nil)
+	Take(ctx context.Context, state string) (*Session, error)
+	// 关闭存储
+	Close() error
+}
+
+type memorySession struct {
+	session   *Session
+	expiresAt time.Time
+}
+
+// NewMemorySessionStore 创建基于内存的会话存储
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		items: make(map[string]*memorySession),
+	}
+}
+
+// MemorySessionStore 内存会话存储(仅适用于单实例部署)
+type MemorySessionStore struct {
+	lock  sync.Mutex
+	items map[string]*memorySession
+}
+
+// Set ...
+func (a *MemorySessionStore) Set(ctx context.Context, state string, session *Session, expiration time.Duration) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+	for key, item := range a.items {
+		if now.After(item.expiresAt) {
+			delete(a.items, key)
+		}
+	}
+
+	a.items[state] = &memorySession{
+		session:   session,
+		expiresAt: now.Add(expiration),
+	}
+	return nil
+}
+
+// Take ...
+func (a *MemorySessionStore) Take(ctx context.Context, state string) (*Session, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	item, ok := a.items[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(a.items, state)
+
+	if time.Now().After(item.expiresAt) {
+		return nil, nil
+	}
+	return item.session, nil
+}
+
+// Close ...
+func (a *MemorySessionStore) Close() error {
+	return nil
+}
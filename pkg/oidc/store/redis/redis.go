@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/wangwei518/gin-admin/pkg/oidc"
+)
+
+// Config redis配置参数
+type Config struct {
+	Addr      string // 地址(IP:Port)
+	DB        int    // 数据库
+	Password  string // 密码
+	KeyPrefix string // 存储key的前缀
+}
+
+// NewStore 创建基于redis的授权请求会话存储
+func NewStore(cfg *Config) *Store {
+	cli := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		DB:       cfg.DB,
+		Password: cfg.Password,
+	})
+	return &Store{
+		cli:    cli,
+		prefix: cfg.KeyPrefix,
+	}
+}
+
+// Store redis存储
+type Store struct {
+	cli    *redis.Client
+	prefix string
+}
+
+func (s *Store) wrapperKey(key string) string {
+	return fmt.Sprintf("%s%s", s.prefix, key)
+}
+
+// Set ...
+func (s *Store) Set(ctx context.Context, state string, session *oidc.Session, expiration time.Duration) error {
+	buf, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return s.cli.Set(s.wrapperKey(state), buf, expiration).Err()
+}
+
+// Take ...
+func (s *Store) Take(ctx context.Context, state string) (*oidc.Session, error) {
+	key := s.wrapperKey(state)
+
+	var getCmd *redis.StringCmd
+	_, err := s.cli.TxPipelined(func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(key)
+		pipe.Del(key)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	buf, err := getCmd.Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	session := new(oidc.Session)
+	err = json.Unmarshal(buf, session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Close ...
+func (s *Store) Close() error {
+	return s.cli.Close()
+}
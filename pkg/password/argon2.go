@@ -0,0 +1,133 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2idName argon2id算法名称
+const Argon2idName = "argon2id"
+
+// Argon2idParams argon2id参数
+type Argon2idParams struct {
+	Memory      uint32 // 内存开销(KiB)
+	Iterations  uint32 // 迭代次数
+	Parallelism uint8  // 并行度
+	SaltLength  uint32 // 盐长度
+	KeyLength   uint32 // 哈希长度
+}
+
+// DefaultArgon2idParams 默认的argon2id参数
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      64 * 1024,
+	Iterations:  1,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// NewArgon2id 创建argon2id算法
+func NewArgon2id(params Argon2idParams) *Argon2id {
+	if params.Memory == 0 {
+		params.Memory = DefaultArgon2idParams.Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = DefaultArgon2idParams.Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = DefaultArgon2idParams.Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = DefaultArgon2idParams.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = DefaultArgon2idParams.KeyLength
+	}
+	return &Argon2id{params: params}
+}
+
+// Argon2id argon2id算法($argon2id$v=19$m=65536,t=1,p=2$salt$hash)
+type Argon2id struct {
+	params Argon2idParams
+}
+
+// Name 算法名称
+func (a *Argon2id) Name() string {
+	return Argon2idName
+}
+
+// Hash 计算哈希值
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	p := a.params
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2idName, argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Match 判断是否为argon2id哈希值
+func (a *Argon2id) Match(encoded string) bool {
+	return strings.HasPrefix(encoded, "$"+Argon2idName+"$")
+}
+
+func (a *Argon2id) decode(encoded string) (p Argon2idParams, salt, key []byte, err error) {
+	vals := strings.Split(encoded, "$")
+	if len(vals) != 6 || vals[1] != Argon2idName {
+		err = ErrInvalidHash
+		return
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(vals[2], "v=%d", &version); err != nil || version != argon2.Version {
+		err = ErrInvalidHash
+		return
+	}
+
+	if _, err = fmt.Sscanf(vals[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		err = ErrInvalidHash
+		return
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(vals[4])
+	if err != nil {
+		err = ErrInvalidHash
+		return
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(vals[5])
+	if err != nil {
+		err = ErrInvalidHash
+		return
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+	return
+}
+
+// Verify 校验密码
+func (a *Argon2id) Verify(encoded, password string) (bool, error) {
+	p, salt, key, err := a.decode(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash 判断参数是否发生变化
+func (a *Argon2id) NeedsRehash(encoded string) bool {
+	p, _, _, err := a.decode(encoded)
+	return err != nil || p != a.params
+}
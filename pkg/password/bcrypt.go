@@ -0,0 +1,64 @@
+package password
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptName bcrypt算法名称
+const BcryptName = "bcrypt"
+
+// NewBcrypt 创建bcrypt算法
+func NewBcrypt(cost int) *Bcrypt {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &Bcrypt{cost: cost}
+}
+
+// Bcrypt bcrypt算法($2a$cost$salthash)
+type Bcrypt struct {
+	cost int
+}
+
+// Name 算法名称
+func (a *Bcrypt) Name() string {
+	return BcryptName
+}
+
+// Hash 计算哈希值
+func (a *Bcrypt) Hash(password string) (string, error) {
+	buf, err := bcrypt.GenerateFromPassword([]byte(password), a.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// Match 判断是否为bcrypt哈希值
+func (a *Bcrypt) Match(encoded string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(encoded, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify 校验密码
+func (a *Bcrypt) Verify(encoded, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err == nil {
+		return true, nil
+	} else if err == bcrypt.ErrMismatchedHashAndPassword {
+		return false, nil
+	}
+	return false, ErrInvalidHash
+}
+
+// NeedsRehash 判断cost是否发生变化
+func (a *Bcrypt) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost != a.cost
+}
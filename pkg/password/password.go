@@ -0,0 +1,77 @@
+package password
+
+import (
+	"errors"
+)
+
+// 定义错误
+var (
+	ErrUnknownFormat = errors.New("password: unknown hash format")
+	ErrInvalidHash   = errors.New("password: invalid hash")
+)
+
+// Hasher 密码哈希算法
+type Hasher interface {
+	// 算法名称
+	Name() string
+	// 计算密码的哈希值(自描述的编码格式)
+	Hash(password string) (string, error)
+	// 判断编码后的哈希值是否属于当前算法
+	Match(encoded string) bool
+	// 校验密码
+	Verify(encoded, password string) (bool, error)
+	// 判断哈希值的参数是否与当前配置不一致(需要重新计算)
+	NeedsRehash(encoded string) bool
+}
+
+// New 创建密码管理(使用hasher计算新的哈希值，legacy仅用于校验历史哈希值)
+func New(hasher Hasher, legacy ...Hasher) *Manager {
+	return &Manager{
+		hasher:  hasher,
+		hashers: append([]Hasher{hasher}, legacy...),
+	}
+}
+
+// Manager 密码管理
+type Manager struct {
+	hasher  Hasher
+	hashers []Hasher
+}
+
+// Hash 使用当前算法计算密码的哈希值
+func (m *Manager) Hash(password string) (string, error) {
+	return m.hasher.Hash(password)
+}
+
+// Verify 校验密码，rehash为true表示哈希值需要使用当前算法重新计算
+func (m *Manager) Verify(encoded, password string) (ok, rehash bool, err error) {
+	for _, h := range m.hashers {
+		if !h.Match(encoded) {
+			continue
+		}
+
+		ok, err = h.Verify(encoded, password)
+		if err != nil || !ok {
+			return false, false, err
+		}
+
+		if h != m.hasher {
+			return true, true, nil
+		}
+		return true, h.NeedsRehash(encoded), nil
+	}
+	return false, false, ErrUnknownFormat
+}
+
+// IsEncoded 判断是否为可识别的哈希值(不含历史算法)
+func (m *Manager) IsEncoded(s string) bool {
+	for _, h := range m.hashers {
+		if _, ok := h.(*SHA1); ok {
+			continue
+		}
+		if h.Match(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+func testHasher(t *testing.T, h Hasher) {
+	encoded, err := h.Hash("abc-123")
+	assert.Nil(t, err)
+	assert.True(t, h.Match(encoded))
+	assert.False(t, h.NeedsRehash(encoded))
+
+	ok, err := h.Verify(encoded, "abc-123")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify(encoded, "abc-1234")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	other, err := h.Hash("abc-123")
+	assert.Nil(t, err)
+	assert.NotEqual(t, encoded, other)
+}
+
+func TestBcrypt(t *testing.T) {
+	h := NewBcrypt(4)
+	testHasher(t, h)
+
+	encoded, _ := h.Hash("abc-123")
+	assert.True(t, NewBcrypt(5).NeedsRehash(encoded))
+}
+
+func TestArgon2id(t *testing.T) {
+	h := NewArgon2id(Argon2idParams{Memory: 1024})
+	testHasher(t, h)
+
+	encoded, _ := h.Hash("abc-123")
+	assert.True(t, NewArgon2id(Argon2idParams{Memory: 2048}).NeedsRehash(encoded))
+
+	_, err := h.Verify("$argon2id$v=19$m=a$b$c", "abc-123")
+	assert.Equal(t, ErrInvalidHash, err)
+}
+
+func TestManager(t *testing.T) {
+	m := New(NewArgon2id(Argon2idParams{Memory: 1024}), NewBcrypt(4), NewSHA1())
+
+	ok, rehash, err := m.Verify(util.SHA1HashString("abc-123"), "abc-123")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	encoded, _ := NewBcrypt(4).Hash("abc-123")
+	ok, rehash, err = m.Verify(encoded, "abc-123")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+	assert.True(t, m.IsEncoded(encoded))
+
+	encoded, err = m.Hash("abc-123")
+	assert.Nil(t, err)
+	ok, rehash, err = m.Verify(encoded, "abc-123")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, _, err = m.Verify(encoded, "foo")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = m.Verify("abc-123", "abc-123")
+	assert.Equal(t, ErrUnknownFormat, err)
+	assert.False(t, m.IsEncoded("abc-123"))
+	assert.False(t, m.IsEncoded(util.SHA1HashString("abc-123")))
+}
+
+func TestPolicy(t *testing.T) {
+	p := &Policy{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		BannedWords:   []string{"Admin"},
+	}
+
+	assert.Equal(t, ErrTooShort, p.Validate(""))
+	assert.Equal(t, ErrTooShort, p.Validate("Ab-1234"))
+	assert.Equal(t, ErrMissingUpper, p.Validate("abc-1234"))
+	assert.Equal(t, ErrMissingLower, p.Validate("ABC-1234"))
+	assert.Equal(t, ErrMissingDigit, p.Validate("Abc-defg"))
+	assert.Equal(t, ErrMissingSymbol, p.Validate("Abc12345"))
+	assert.Equal(t, ErrBannedWord, p.Validate("myADMIN-123"))
+	assert.Nil(t, p.Validate("Abc-1234"))
+
+	assert.Equal(t, ErrTooShort, new(Policy).Validate(""))
+	assert.Nil(t, new(Policy).Validate("a"))
+
+	// 明文的摘要(如md5(""))不能作为密码提交
+	assert.Equal(t, ErrDigest, new(Policy).Validate("d41d8cd98f00b204e9800998ecf8427e"))
+	assert.Equal(t, ErrDigest, new(Policy).Validate("da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+	assert.Nil(t, new(Policy).Validate("D41D8CD98F00B204E9800998ECF8427E"))
+}
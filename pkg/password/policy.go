@@ -0,0 +1,88 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 定义不符合密码策略的错误
+var (
+	ErrTooShort      = errors.New("password: too short")
+	ErrMissingUpper  = errors.New("password: missing upper case letter")
+	ErrMissingLower  = errors.New("password: missing lower case letter")
+	ErrMissingDigit  = errors.New("password: missing digit")
+	ErrMissingSymbol = errors.New("password: missing symbol")
+	ErrBannedWord    = errors.New("password: contains banned word")
+	ErrDigest        = errors.New("password: looks like a digest")
+)
+
+// Policy 密码复杂度策略
+type Policy struct {
+	MinLength     int      // 最小长度(按字符计算)
+	RequireUpper  bool     // 必须包含大写字母
+	RequireLower  bool     // 必须包含小写字母
+	RequireDigit  bool     // 必须包含数字
+	RequireSymbol bool     // 必须包含特殊字符
+	BannedWords   []string // 禁止包含的词(不区分大小写)
+}
+
+// IsDigest 判断是否为摘要形式的字符串(32、40或64位的小写十六进制，如MD5、SHA1、SHA256)
+// 客户端提交密码的摘要时，服务端无法校验密码的复杂度
+func IsDigest(s string) bool {
+	switch len(s) {
+	case 32, 40, 64:
+	default:
+		return false
+	}
+
+	for _, r := range s {
+		if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate 校验密码是否符合策略(密码必须为明文)
+func (p *Policy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength || password == "" {
+		return ErrTooShort
+	} else if IsDigest(password) {
+		return ErrDigest
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !upper:
+		return ErrMissingUpper
+	case p.RequireLower && !lower:
+		return ErrMissingLower
+	case p.RequireDigit && !digit:
+		return ErrMissingDigit
+	case p.RequireSymbol && !symbol:
+		return ErrMissingSymbol
+	}
+
+	lp := strings.ToLower(password)
+	for _, word := range p.BannedWords {
+		if word != "" && strings.Contains(lp, strings.ToLower(word)) {
+			return ErrBannedWord
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package password
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+// SHA1Name SHA1算法名称
+const SHA1Name = "sha1"
+
+// NewSHA1 创建SHA1算法(仅用于校验历史密码)
+func NewSHA1() *SHA1 {
+	return &SHA1{}
+}
+
+// SHA1 无盐的SHA1算法(40位十六进制字符串)
+type SHA1 struct{}
+
+// Name 算法名称
+func (a *SHA1) Name() string {
+	return SHA1Name
+}
+
+// Hash 计算哈希值
+func (a *SHA1) Hash(password string) (string, error) {
+	return util.SHA1HashString(password), nil
+}
+
+// Match 判断是否为SHA1哈希值
+func (a *SHA1) Match(encoded string) bool {
+	if len(encoded) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(encoded)
+	return err == nil
+}
+
+// Verify 校验密码
+func (a *SHA1) Verify(encoded, password string) (bool, error) {
+	other := util.SHA1HashString(password)
+	return subtle.ConstantTimeCompare([]byte(encoded), []byte(other)) == 1, nil
+}
+
+// NeedsRehash SHA1始终需要重新计算
+func (a *SHA1) NeedsRehash(encoded string) bool {
+	return true
+}
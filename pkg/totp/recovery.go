@@ -0,0 +1,60 @@
+package totp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// 恢复码字符集(去除易混淆的字符)
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// GenerateRecoveryCodes 生成一次性恢复码(格式：xxxxx-xxxxx)
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, n)
+	buf := make([]byte, 10)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == 5 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(recoveryAlphabet[int(b)%len(recoveryAlphabet)])
+		}
+		codes[i] = sb.String()
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.Replace(code, "-", "", -1)
+}
+
+// HashRecoveryCode 计算恢复码的哈希值(用于存储)
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchRecoveryCode 在恢复码哈希列表中查找匹配项，返回其索引(未找到返回-1)
+func MatchRecoveryCode(hashes []string, code string) int {
+	if normalizeRecoveryCode(code) == "" {
+		return -1
+	}
+
+	hash := HashRecoveryCode(code)
+	index := -1
+	for i, item := range hashes {
+		if subtle.ConstantTimeCompare([]byte(item), []byte(hash)) == 1 {
+			index = i
+		}
+	}
+	return index
+}
@@ -0,0 +1,125 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 默认参数(与主流认证器应用兼容)
+const (
+	DefaultDigits = 6
+	DefaultPeriod = 30
+	DefaultSkew   = 1
+	SecretLength  = 20
+)
+
+// ErrInvalidSecret 无效的密钥
+var ErrInvalidSecret = errors.New("totp: invalid secret")
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret 生成随机密钥(base32编码)
+func GenerateSecret() (string, error) {
+	buf := make([]byte, SecretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(buf), nil
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Replace(secret, " ", "", -1))
+	key, err := encoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// GenerateCode 生成指定时间的动态验证码
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(t.Unix())/DefaultPeriod), nil
+}
+
+// hotp 计算HOTP值(RFC 4226)
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	h := hmac.New(sha1.New, key)
+	h.Write(msg[:])
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < DefaultDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", DefaultDigits, value%mod)
+}
+
+// Validate 校验动态验证码(允许前后skew个时间步长的偏差)
+func Validate(code, secret string, t time.Time, skew int) bool {
+	_, ok := ValidateStep(code, secret, t, skew)
+	return ok
+}
+
+// ValidateStep 校验动态验证码，并返回验证码对应的时间步长(用于防止验证码在有效期内被重复使用)
+func ValidateStep(code, secret string, t time.Time, skew int) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != DefaultDigits {
+		return 0, false
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	counter := int64(t.Unix()) / DefaultPeriod
+	step := int64(-1)
+	for i := -skew; i <= skew; i++ {
+		c := counter + int64(i)
+		if c < 0 {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(hotp(key, uint64(c))), []byte(code)) == 1 {
+			step = c
+		}
+	}
+	return step, step >= 0
+}
+
+// URI 生成认证器应用的配置URI(otpauth://，可用于生成二维码)
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(account)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+
+	values := make(url.Values)
+	values.Set("secret", secret)
+	if issuer != "" {
+		values.Set("issuer", issuer)
+	}
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprintf("%d", DefaultDigits))
+	values.Set("period", fmt.Sprintf("%d", DefaultPeriod))
+
+	return "otpauth://totp/" + label + "?" + values.Encode()
+}
@@ -0,0 +1,93 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCode(t *testing.T) {
+	// RFC 6238 附录B测试向量(SHA1，取后6位)
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for ts, expected := range cases {
+		code, err := GenerateCode(secret, time.Unix(ts, 0))
+		assert.Nil(t, err)
+		assert.Equal(t, expected, code)
+	}
+
+	_, err := GenerateCode("!!!", time.Now())
+	assert.Equal(t, ErrInvalidSecret, err)
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Now()
+	code, err := GenerateCode(secret, now)
+	assert.Nil(t, err)
+
+	assert.True(t, Validate(code, secret, now, DefaultSkew))
+	assert.True(t, Validate(code, strings.ToLower(secret), now, DefaultSkew))
+	assert.True(t, Validate(code, secret, now.Add(DefaultPeriod*time.Second), DefaultSkew))
+	assert.False(t, Validate(code, secret, now.Add(3*DefaultPeriod*time.Second), DefaultSkew))
+	assert.False(t, Validate("", secret, now, DefaultSkew))
+	assert.False(t, Validate("abc", secret, now, DefaultSkew))
+}
+
+func TestValidateStep(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.Nil(t, err)
+
+	now := time.Now()
+	code, err := GenerateCode(secret, now)
+	assert.Nil(t, err)
+
+	step, ok := ValidateStep(code, secret, now, DefaultSkew)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/DefaultPeriod, step)
+
+	step, ok = ValidateStep(code, secret, now.Add(DefaultPeriod*time.Second), DefaultSkew)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/DefaultPeriod, step)
+
+	_, ok = ValidateStep("000000", "!!!", now, DefaultSkew)
+	assert.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("gin-admin", "tom@example.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/gin-admin:tom@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=gin-admin")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	assert.Nil(t, err)
+	assert.Len(t, codes, 10)
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		assert.Len(t, code, 11)
+		hashes[i] = HashRecoveryCode(code)
+	}
+
+	assert.Equal(t, 3, MatchRecoveryCode(hashes, codes[3]))
+	assert.Equal(t, 3, MatchRecoveryCode(hashes, strings.ToUpper(strings.Replace(codes[3], "-", "", -1))))
+	assert.Equal(t, -1, MatchRecoveryCode(hashes, "foo"))
+	assert.Equal(t, -1, MatchRecoveryCode(hashes, ""))
+}
@@ -0,0 +1,167 @@
+package file
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/wangwei518/gin-admin/pkg/watcher"
+)
+
+var _ watcher.Watcher = (*Watcher)(nil)
+
+const (
+	// 默认的检查间隔
+	defaultInterval = time.Second
+	// 文件超过该大小后重新创建(其他实例检测到文件变化后全量加载策略)
+	maxFileSize = 1 << 20
+)
+
+// NewWatcher 创建基于文件的变更通知
+// 变更消息追加写入到指定文件，各实例定期检查文件中新增的消息，仅适用于同一主机上的多实例部署
+func NewWatcher(path string, interval time.Duration) (*Watcher, error) {
+	os.MkdirAll(filepath.Dir(path), 0777)
+
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	// 只处理创建之后的消息
+	w := &Watcher{
+		Dispatcher: watcher.NewDispatcher(),
+		path:       path,
+		interval:   interval,
+		fi:         fi,
+		offset:     fi.Size(),
+		closed:     make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w, nil
+}
+
+// Watcher 基于文件的变更通知
+type Watcher struct {
+	*watcher.Dispatcher
+	path      string
+	interval  time.Duration
+	fi        os.FileInfo
+	offset    int64
+	lock      sync.Mutex
+	closeOnce sync.Once
+	closed    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func (w *Watcher) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.closed:
+			return
+		case <-ticker.C:
+			_ = w.poll()
+		}
+	}
+}
+
+// 读取文件中新增的消息
+func (w *Watcher) poll() error {
+	f, err := os.Open(w.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	// 文件被重新创建后无法确定期间的消息是否完整，需要全量加载
+	if !os.SameFile(w.fi, fi) || fi.Size() < w.offset {
+		w.fi = fi
+		w.offset = 0
+		w.Notify("")
+	}
+
+	if fi.Size() == w.offset {
+		return nil
+	}
+
+	buf := make([]byte, fi.Size()-w.offset)
+	n, err := f.ReadAt(buf, w.offset)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	buf = buf[:n]
+
+	// 只处理完整的行，未写完的消息留到下次读取
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			break
+		}
+		if line := bytes.TrimSpace(buf[:i]); len(line) > 0 {
+			w.Dispatch(line)
+		}
+		buf = buf[i+1:]
+		w.offset += int64(i + 1)
+	}
+	return nil
+}
+
+// Update 通知其他实例全量加载策略
+func (w *Watcher) Update() error {
+	return w.Publish("")
+}
+
+// Publish 发送变更消息
+func (w *Watcher) Publish(payload string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if fi, err := os.Stat(w.path); err == nil && fi.Size() > maxFileSize {
+		os.Remove(w.path)
+	}
+
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(append(w.Encode(payload), '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Close 停止检查文件
+func (w *Watcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closed)
+		w.wg.Wait()
+	})
+}
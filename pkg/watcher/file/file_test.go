@@ -0,0 +1,69 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	lock     sync.Mutex
+	payloads []string
+}
+
+func (a *recorder) callback(payload string) {
+	a.lock.Lock()
+	a.payloads = append(a.payloads, payload)
+	a.lock.Unlock()
+}
+
+func (a *recorder) get() []string {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return append([]string(nil), a.payloads...)
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watcher")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "watcher", "casbin.log")
+	foo, err := NewWatcher(path, 10*time.Millisecond)
+	assert.Nil(t, err)
+	defer foo.Close()
+
+	bar, err := NewWatcher(path, 10*time.Millisecond)
+	assert.Nil(t, err)
+	defer bar.Close()
+
+	var fooRecorder, barRecorder recorder
+	foo.SetUpdateCallback(fooRecorder.callback)
+	bar.SetUpdateCallback(barRecorder.callback)
+
+	err = foo.Publish("roles:1")
+	assert.Nil(t, err)
+	err = foo.Update()
+	assert.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		return len(barRecorder.get()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"roles:1", ""}, barRecorder.get())
+	assert.Empty(t, fooRecorder.get())
+
+	// 文件被重新创建后通知全量加载
+	err = os.Remove(path)
+	assert.Nil(t, err)
+	err = ioutil.WriteFile(path, nil, 0666)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		return len(fooRecorder.get()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{""}, fooRecorder.get())
+}
@@ -0,0 +1,142 @@
+package redis
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/wangwei518/gin-admin/pkg/watcher"
+)
+
+var _ watcher.Watcher = (*Watcher)(nil)
+
+// 接收消息的超时时间(超时后发送ping检查连接状态)
+const receiveTimeout = 30 * time.Second
+
+// Config redis配置参数
+type Config struct {
+	Addr     string // 地址(IP:Port)
+	DB       int    // 数据库
+	Password string // 密码
+	Channel  string // 发布订阅的频道
+}
+
+// NewWatcher 创建基于redis发布订阅的变更通知
+func NewWatcher(cfg *Config) (*Watcher, error) {
+	cli := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		DB:       cfg.DB,
+		Password: cfg.Password,
+	})
+
+	w, err := NewWatcherWithClient(cli, cfg.Channel)
+	if err != nil {
+		cli.Close()
+		return nil, err
+	}
+	w.closeClient = true
+	return w, nil
+}
+
+// NewWatcherWithClient 使用redis客户端创建变更通知
+func NewWatcherWithClient(cli *redis.Client, channel string) (*Watcher, error) {
+	ps := cli.Subscribe(channel)
+
+	// 等待订阅成功
+	_, err := ps.ReceiveTimeout(receiveTimeout)
+	if err != nil {
+		ps.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		Dispatcher: watcher.NewDispatcher(),
+		cli:        cli,
+		ps:         ps,
+		channel:    channel,
+		closed:     make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.receive()
+	return w, nil
+}
+
+// Watcher 基于redis发布订阅的变更通知(适用于多主机部署)
+type Watcher struct {
+	*watcher.Dispatcher
+	cli         *redis.Client
+	ps          *redis.PubSub
+	channel     string
+	closeClient bool
+	closeOnce   sync.Once
+	closed      chan struct{}
+	wg          sync.WaitGroup
+}
+
+func (w *Watcher) isClosed() bool {
+	select {
+	case <-w.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// 接收消息，连接中断后自动重新订阅
+func (w *Watcher) receive() {
+	defer w.wg.Done()
+
+	for {
+		msg, err := w.ps.ReceiveTimeout(receiveTimeout)
+		if w.isClosed() {
+			return
+		}
+
+		if err != nil {
+			if e, ok := err.(net.Error); ok && e.Timeout() {
+				_ = w.ps.Ping()
+				continue
+			}
+
+			select {
+			case <-w.closed:
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			// 重新订阅成功，中断期间可能丢失了消息
+			if m.Kind == "subscribe" {
+				w.Notify("")
+			}
+		case *redis.Message:
+			w.Dispatch([]byte(m.Payload))
+		}
+	}
+}
+
+// Update 通知其他实例全量加载策略
+func (w *Watcher) Update() error {
+	return w.Publish("")
+}
+
+// Publish 发送变更消息
+func (w *Watcher) Publish(payload string) error {
+	return w.cli.Publish(w.channel, w.Encode(payload)).Err()
+}
+
+// Close 停止接收消息
+func (w *Watcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closed)
+		w.ps.Close()
+		w.wg.Wait()
+		if w.closeClient {
+			w.cli.Close()
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package watcher
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/wangwei518/gin-admin/pkg/util"
+)
+
+// Watcher 策略变更通知(实现casbin的persist.Watcher接口)
+// 变更消息只发送给其他实例，发送消息的实例自身不会收到回调
+type Watcher interface {
+	persist.Watcher
+	// 发送指定内容的变更消息(Update发送空消息，表示需要全量加载策略)
+	Publish(payload string) error
+}
+
+// Message 变更消息
+type Message struct {
+	Sender  string `json:"sender"`  // 发送消息的实例ID
+	Payload string `json:"payload"` // 消息内容
+}
+
+// NewDispatcher 创建消息分发器(每次创建生成新的实例ID)
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		id: util.NewRecordID(),
+	}
+}
+
+// Dispatcher 消息分发器(编码本实例发送的消息，并将其他实例的消息分发给回调函数)
+type Dispatcher struct {
+	id       string
+	lock     sync.RWMutex
+	callback func(string)
+}
+
+// InstanceID 获取实例ID
+func (a *Dispatcher) InstanceID() string {
+	return a.id
+}
+
+// SetUpdateCallback 设定收到其他实例的变更消息时调用的回调函数
+func (a *Dispatcher) SetUpdateCallback(callback func(string)) error {
+	a.lock.Lock()
+	a.callback = callback
+	a.lock.Unlock()
+	return nil
+}
+
+// Encode 编码本实例发送的消息
+func (a *Dispatcher) Encode(payload string) []byte {
+	buf, _ := json.Marshal(&Message{
+		Sender:  a.id,
+		Payload: payload,
+	})
+	return buf
+}
+
+// Dispatch 解码消息并调用回调函数(忽略本实例发送的消息，无法解码的消息视为需要全量加载)
+func (a *Dispatcher) Dispatch(data []byte) {
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		a.Notify("")
+		return
+	} else if msg.Sender == a.id {
+		return
+	}
+	a.Notify(msg.Payload)
+}
+
+// Notify 直接调用回调函数(如连接中断后可能丢失了消息，需要全量加载时使用)
+func (a *Dispatcher) Notify(payload string) {
+	a.lock.RLock()
+	callback := a.callback
+	a.lock.RUnlock()
+
+	if callback != nil {
+		callback(payload)
+	}
+}
@@ -0,0 +1,28 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher(t *testing.T) {
+	foo := NewDispatcher()
+	bar := NewDispatcher()
+	assert.NotEqual(t, foo.InstanceID(), bar.InstanceID())
+
+	var payloads []string
+	err := bar.SetUpdateCallback(func(payload string) {
+		payloads = append(payloads, payload)
+	})
+	assert.Nil(t, err)
+
+	// 忽略本实例发送的消息
+	bar.Dispatch(bar.Encode("self"))
+	assert.Empty(t, payloads)
+
+	bar.Dispatch(foo.Encode("roles:1"))
+	bar.Dispatch(foo.Encode(""))
+	bar.Dispatch([]byte("invalid"))
+	assert.Equal(t, []string{"roles:1", "", ""}, payloads)
+}